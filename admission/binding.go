@@ -0,0 +1,135 @@
+package admission
+
+import (
+	"fmt"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	admissionregV1beta1 "k8s.io/api/admissionregistration/v1beta1"
+)
+
+// BindingBuilder provides a way to build values of type admissionregV1beta1.ValidatingAdmissionPolicyBinding
+type BindingBuilder map[string]interface{}
+
+// Binding starts a new ValidatingAdmissionPolicyBinding of the named policy,
+// using the provided object metadata. The binding defaults to the Deny validation action.
+func Binding(metadata objmeta.Builder, policyName string) BindingBuilder {
+	meta, err := metadata.U()
+	return BindingBuilder(unstruct.SetErr(map[string]interface{}{"metadata": meta}, err)).
+		setSpec("policyName", policyName).
+		ValidationActions(admissionregV1beta1.Deny)
+}
+
+// U returns the unstructured value of the builder
+func (b BindingBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b BindingBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder. An error is returned if the
+// binding is missing a policy name or validation actions.
+func (b BindingBuilder) T() (admissionregV1beta1.ValidatingAdmissionPolicyBinding, error) {
+	var binding admissionregV1beta1.ValidatingAdmissionPolicyBinding
+	if err := unstruct.FromUnstructured(b, &binding); err != nil {
+		return admissionregV1beta1.ValidatingAdmissionPolicyBinding{}, err
+	}
+	if err := naming.Validate("ValidatingAdmissionPolicyBinding", binding.Name); err != nil {
+		return admissionregV1beta1.ValidatingAdmissionPolicyBinding{}, err
+	}
+	if _, ok := b["spec"]; ok {
+		if binding.Spec.PolicyName == "" {
+			return admissionregV1beta1.ValidatingAdmissionPolicyBinding{}, fmt.Errorf("admission: binding %s must set a policy name", binding.Name)
+		}
+		if len(binding.Spec.ValidationActions) == 0 {
+			return admissionregV1beta1.ValidatingAdmissionPolicyBinding{}, fmt.Errorf("admission: binding %s must set validation actions", binding.Name)
+		}
+	}
+	return binding, nil
+}
+
+// ValidationActions sets how failed validations are enforced (Deny, Warn and/or Audit)
+func (b BindingBuilder) ValidationActions(actions ...admissionregV1beta1.ValidationAction) BindingBuilder {
+	var slice []interface{}
+	for _, a := range actions {
+		slice = append(slice, string(a))
+	}
+	return b.setSpec("validationActions", slice)
+}
+
+// ParamRef sets the parameter resource, of the policy's paramKind, used by the binding
+func (b BindingBuilder) ParamRef(namespace, name string) BindingBuilder {
+	ref := b.getParamRef()
+	ref["name"] = name
+	if namespace != "" {
+		ref["namespace"] = namespace
+	}
+	return b
+}
+
+// ParamSelector selects the parameter resources with the labels, evaluating the policy once per resource
+func (b BindingBuilder) ParamSelector(labels map[string]string) BindingBuilder {
+	b.getParamRef()["selector"] = selector(labels)
+	return b
+}
+
+// ParameterNotFoundAction sets whether requests are allowed or denied when no parameter resource is found
+func (b BindingBuilder) ParameterNotFoundAction(action admissionregV1beta1.ParameterNotFoundActionType) BindingBuilder {
+	b.getParamRef()["parameterNotFoundAction"] = string(action)
+	return b
+}
+
+// MatchRules restricts the binding to the operations and resources
+func (b BindingBuilder) MatchRules(r ...RuleBuilder) BindingBuilder {
+	b.getMatchResources()["resourceRules"] = rules(r)
+	return b
+}
+
+// NamespaceSelector restricts the binding to objects in namespaces with the labels
+func (b BindingBuilder) NamespaceSelector(labels map[string]string) BindingBuilder {
+	b.getMatchResources()["namespaceSelector"] = selector(labels)
+	return b
+}
+
+// ObjectSelector restricts the binding to objects with the labels
+func (b BindingBuilder) ObjectSelector(labels map[string]string) BindingBuilder {
+	b.getMatchResources()["objectSelector"] = selector(labels)
+	return b
+}
+
+func (b BindingBuilder) setSpec(key string, val interface{}) BindingBuilder {
+	spec := b.getBindingSpec()
+	spec[key] = val
+	b["spec"] = spec
+	return b
+}
+
+func (b BindingBuilder) getBindingSpec() map[string]interface{} {
+	specIface, ok := b["spec"]
+	if !ok {
+		specIface = map[string]interface{}{}
+	}
+	return specIface.(map[string]interface{})
+}
+
+func (b BindingBuilder) getParamRef() map[string]interface{} {
+	ref, ok := b.getBindingSpec()["paramRef"].(map[string]interface{})
+	if !ok {
+		ref = map[string]interface{}{}
+		b.setSpec("paramRef", ref)
+	}
+	return ref
+}
+
+func (b BindingBuilder) getMatchResources() map[string]interface{} {
+	match, ok := b.getBindingSpec()["matchResources"].(map[string]interface{})
+	if !ok {
+		match = map[string]interface{}{}
+		b.setSpec("matchResources", match)
+	}
+	return match
+}
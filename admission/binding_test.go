@@ -0,0 +1,98 @@
+package admission
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/objmeta"
+	admissionregV1 "k8s.io/api/admissionregistration/v1"
+	admissionregV1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBindingTyped(t *testing.T) {
+	deny := admissionregV1beta1.DenyAction
+	tests := map[string]struct {
+		builder  BindingBuilder
+		expected admissionregV1beta1.ValidatingAdmissionPolicyBinding
+	}{
+		"empty": {
+			builder:  BindingBuilder{},
+			expected: admissionregV1beta1.ValidatingAdmissionPolicyBinding{},
+		},
+		"defaults": {
+			builder: Binding(objmeta.Name("replica-limit"), "replica-limit"),
+			expected: admissionregV1beta1.ValidatingAdmissionPolicyBinding{
+				ObjectMeta: metaV1.ObjectMeta{Name: "replica-limit"},
+				Spec: admissionregV1beta1.ValidatingAdmissionPolicyBindingSpec{
+					PolicyName:        "replica-limit",
+					ValidationActions: []admissionregV1beta1.ValidationAction{admissionregV1beta1.Deny},
+				},
+			},
+		},
+		"params and match resources": {
+			builder: Binding(objmeta.Name("replica-limit-prod"), "replica-limit").
+				ValidationActions(admissionregV1beta1.Warn, admissionregV1beta1.Audit).
+				ParamRef("default", "limits").
+				ParameterNotFoundAction(admissionregV1beta1.DenyAction).
+				MatchRules(Rule(admissionregV1.Create).APIGroups("apps").APIVersions("v1").Resources("deployments")).
+				NamespaceSelector(map[string]string{"env": "prod"}).
+				ObjectSelector(map[string]string{"app": "web"}),
+			expected: admissionregV1beta1.ValidatingAdmissionPolicyBinding{
+				ObjectMeta: metaV1.ObjectMeta{Name: "replica-limit-prod"},
+				Spec: admissionregV1beta1.ValidatingAdmissionPolicyBindingSpec{
+					PolicyName:        "replica-limit",
+					ValidationActions: []admissionregV1beta1.ValidationAction{admissionregV1beta1.Warn, admissionregV1beta1.Audit},
+					ParamRef:          &admissionregV1beta1.ParamRef{Name: "limits", Namespace: "default", ParameterNotFoundAction: &deny},
+					MatchResources: &admissionregV1beta1.MatchResources{
+						NamespaceSelector: &metaV1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+						ObjectSelector:    &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+						ResourceRules: []admissionregV1beta1.NamedRuleWithOperations{{
+							RuleWithOperations: admissionregV1beta1.RuleWithOperations{
+								Operations: []admissionregV1.OperationType{admissionregV1.Create},
+								Rule:       admissionregV1.Rule{APIGroups: []string{"apps"}, APIVersions: []string{"v1"}, Resources: []string{"deployments"}},
+							},
+						}},
+					},
+				},
+			},
+		},
+		"param selector": {
+			builder: Binding(objmeta.Name("replica-limit"), "replica-limit").ParamSelector(map[string]string{"limits": "replicas"}),
+			expected: admissionregV1beta1.ValidatingAdmissionPolicyBinding{
+				ObjectMeta: metaV1.ObjectMeta{Name: "replica-limit"},
+				Spec: admissionregV1beta1.ValidatingAdmissionPolicyBindingSpec{
+					PolicyName:        "replica-limit",
+					ValidationActions: []admissionregV1beta1.ValidationAction{admissionregV1beta1.Deny},
+					ParamRef:          &admissionregV1beta1.ParamRef{Selector: &metaV1.LabelSelector{MatchLabels: map[string]string{"limits": "replicas"}}},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			binding, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(binding, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", binding, test.expected)
+			}
+		})
+	}
+}
+
+func TestBindingInvalid(t *testing.T) {
+	tests := map[string]BindingBuilder{
+		"no policy name": Binding(objmeta.Name("binding"), ""),
+		"no actions":     Binding(objmeta.Name("binding"), "policy").ValidationActions(),
+	}
+	for name, builder := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := builder.T(); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
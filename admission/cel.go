@@ -0,0 +1,79 @@
+package admission
+
+import (
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	plugincel "k8s.io/apiserver/pkg/admission/plugin/cel"
+	"k8s.io/apiserver/pkg/cel/environment"
+)
+
+// expression is a CEL expression compiled with the variables available
+// to admission match conditions and validating admission policies
+type expression struct {
+	expr        string
+	returnTypes []*cel.Type
+}
+
+func (e expression) GetExpression() string {
+	return e.expr
+}
+
+func (e expression) ReturnTypes() []*cel.Type {
+	return e.returnTypes
+}
+
+type variable struct {
+	expression
+	name string
+}
+
+func (v variable) GetName() string {
+	return v.name
+}
+
+func boolExpression(expr string) expression {
+	return expression{expr: expr, returnTypes: []*cel.Type{cel.BoolType}}
+}
+
+func stringExpression(expr string) expression {
+	return expression{expr: expr, returnTypes: []*cel.Type{cel.StringType}}
+}
+
+func anyVariable(name, expr string) variable {
+	return variable{expression: expression{expr: expr, returnTypes: []*cel.Type{cel.AnyType}}, name: name}
+}
+
+// celCompiler compiles expressions using the API server's CEL environment
+type celCompiler struct {
+	compiler *plugincel.CompositedCompiler
+	options  plugincel.OptionalVariableDeclarations
+}
+
+func newCELCompiler(hasParams bool) (celCompiler, error) {
+	compiler, err := plugincel.NewCompositedCompiler(environment.MustBaseEnvSet(environment.DefaultCompatibilityVersion()))
+	if err != nil {
+		return celCompiler{}, err
+	}
+	return celCompiler{
+		compiler: compiler,
+		options:  plugincel.OptionalVariableDeclarations{HasParams: hasParams, HasAuthorizer: true},
+	}, nil
+}
+
+// variable compiles and declares a variable usable as variables.<name> by later expressions
+func (c celCompiler) variable(v variable) error {
+	result := c.compiler.CompileAndStoreVariable(v, c.options, environment.NewExpressions)
+	if result.Error != nil {
+		return fmt.Errorf("admission: variable %s: %s", v.name, result.Error.Detail)
+	}
+	return nil
+}
+
+func (c celCompiler) compile(field string, expr expression) error {
+	result := c.compiler.CompileCELExpression(expr, c.options, environment.NewExpressions)
+	if result.Error != nil {
+		return fmt.Errorf("admission: %s %q: %s", field, expr.expr, result.Error.Detail)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package admission
+
+import (
+	"fmt"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	admissionregV1 "k8s.io/api/admissionregistration/v1"
+)
+
+// ValidatingBuilder provides a way to build values of type admissionregV1.ValidatingWebhookConfiguration
+type ValidatingBuilder map[string]interface{}
+
+// Validating starts a new ValidatingWebhookConfiguration builder using the provided object metadata
+func Validating(metadata objmeta.Builder) ValidatingBuilder {
+	meta, err := metadata.U()
+	return ValidatingBuilder(unstruct.SetErr(map[string]interface{}{"metadata": meta}, err))
+}
+
+// U returns the unstructured value of the builder
+func (b ValidatingBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b ValidatingBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder. An error is returned if a
+// webhook is invalid or sets a reinvocationPolicy.
+func (b ValidatingBuilder) T() (admissionregV1.ValidatingWebhookConfiguration, error) {
+	var config admissionregV1.ValidatingWebhookConfiguration
+	if err := unstruct.FromUnstructured(b, &config); err != nil {
+		return admissionregV1.ValidatingWebhookConfiguration{}, err
+	}
+	if err := naming.Validate("ValidatingWebhookConfiguration", config.Name); err != nil {
+		return admissionregV1.ValidatingWebhookConfiguration{}, err
+	}
+	hooks, _ := b["webhooks"].([]interface{})
+	for _, hook := range hooks {
+		if _, ok := hook.(map[string]interface{})["reinvocationPolicy"]; ok {
+			return admissionregV1.ValidatingWebhookConfiguration{}, fmt.Errorf("admission: validating webhooks do not support reinvocationPolicy")
+		}
+	}
+	for _, hook := range config.Webhooks {
+		if err := validateWebhook(hook.Name, hook.ClientConfig, hook.TimeoutSeconds, hook.MatchConditions); err != nil {
+			return admissionregV1.ValidatingWebhookConfiguration{}, err
+		}
+	}
+	return config, nil
+}
+
+// Webhooks sets the webhooks of the configuration
+func (b ValidatingBuilder) Webhooks(hooks ...WebhookBuilder) ValidatingBuilder {
+	b["webhooks"] = webhooks(hooks)
+	return b
+}
+
+// MutatingBuilder provides a way to build values of type admissionregV1.MutatingWebhookConfiguration
+type MutatingBuilder map[string]interface{}
+
+// Mutating starts a new MutatingWebhookConfiguration builder using the provided object metadata
+func Mutating(metadata objmeta.Builder) MutatingBuilder {
+	meta, err := metadata.U()
+	return MutatingBuilder(unstruct.SetErr(map[string]interface{}{"metadata": meta}, err))
+}
+
+// U returns the unstructured value of the builder
+func (b MutatingBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b MutatingBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder. An error is returned if a webhook is invalid.
+func (b MutatingBuilder) T() (admissionregV1.MutatingWebhookConfiguration, error) {
+	var config admissionregV1.MutatingWebhookConfiguration
+	if err := unstruct.FromUnstructured(b, &config); err != nil {
+		return admissionregV1.MutatingWebhookConfiguration{}, err
+	}
+	if err := naming.Validate("MutatingWebhookConfiguration", config.Name); err != nil {
+		return admissionregV1.MutatingWebhookConfiguration{}, err
+	}
+	for _, hook := range config.Webhooks {
+		if err := validateWebhook(hook.Name, hook.ClientConfig, hook.TimeoutSeconds, hook.MatchConditions); err != nil {
+			return admissionregV1.MutatingWebhookConfiguration{}, err
+		}
+	}
+	return config, nil
+}
+
+// Webhooks sets the webhooks of the configuration
+func (b MutatingBuilder) Webhooks(hooks ...WebhookBuilder) MutatingBuilder {
+	b["webhooks"] = webhooks(hooks)
+	return b
+}
+
+func webhooks(hooks []WebhookBuilder) []interface{} {
+	var slice []interface{}
+	for _, h := range hooks {
+		slice = append(slice, unstruct.Nest(h))
+	}
+	return slice
+}
@@ -0,0 +1,103 @@
+package admission
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/objmeta"
+	admissionregV1 "k8s.io/api/admissionregistration/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidatingTyped(t *testing.T) {
+	none := admissionregV1.SideEffectClassNone
+	tests := map[string]struct {
+		builder  ValidatingBuilder
+		expected admissionregV1.ValidatingWebhookConfiguration
+	}{
+		"empty": {
+			builder:  ValidatingBuilder{},
+			expected: admissionregV1.ValidatingWebhookConfiguration{},
+		},
+		"with webhook": {
+			builder: Validating(objmeta.Name("policy")).Webhooks(Webhook("pods.policy.example.com").URL("https://policy.example.com")),
+			expected: admissionregV1.ValidatingWebhookConfiguration{
+				ObjectMeta: metaV1.ObjectMeta{Name: "policy"},
+				Webhooks: []admissionregV1.ValidatingWebhook{{
+					Name:                    "pods.policy.example.com",
+					ClientConfig:            admissionregV1.WebhookClientConfig{URL: strPtr("https://policy.example.com")},
+					SideEffects:             &none,
+					AdmissionReviewVersions: []string{"v1"},
+				}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(config, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", config, test.expected)
+			}
+		})
+	}
+}
+
+func TestMutatingTyped(t *testing.T) {
+	none, ifNeeded := admissionregV1.SideEffectClassNone, admissionregV1.IfNeededReinvocationPolicy
+	tests := map[string]struct {
+		builder  MutatingBuilder
+		expected admissionregV1.MutatingWebhookConfiguration
+	}{
+		"empty": {
+			builder:  MutatingBuilder{},
+			expected: admissionregV1.MutatingWebhookConfiguration{},
+		},
+		"with reinvocation": {
+			builder: Mutating(objmeta.Name("defaults")).Webhooks(
+				Webhook("pods.defaults.example.com").Service("system", "defaults", "/mutate").ReinvocationPolicy(admissionregV1.IfNeededReinvocationPolicy),
+			),
+			expected: admissionregV1.MutatingWebhookConfiguration{
+				ObjectMeta: metaV1.ObjectMeta{Name: "defaults"},
+				Webhooks: []admissionregV1.MutatingWebhook{{
+					Name: "pods.defaults.example.com",
+					ClientConfig: admissionregV1.WebhookClientConfig{
+						Service: &admissionregV1.ServiceReference{Namespace: "system", Name: "defaults", Path: strPtr("/mutate")},
+					},
+					SideEffects:             &none,
+					AdmissionReviewVersions: []string{"v1"},
+					ReinvocationPolicy:      &ifNeeded,
+				}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(config, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", config, test.expected)
+			}
+		})
+	}
+}
+
+func TestConfigurationInvalid(t *testing.T) {
+	hook := Webhook("pods.policy.example.com").URL("https://policy.example.com").AddMatchCondition("bad", "object.")
+	if _, err := Validating(objmeta.Name("policy")).Webhooks(hook).T(); err == nil {
+		t.Error("expecting error, got none")
+	}
+	if _, err := Mutating(objmeta.Name("policy")).Webhooks(hook).T(); err == nil {
+		t.Error("expecting error, got none")
+	}
+	reinvoked := Webhook("pods.policy.example.com").URL("https://policy.example.com").ReinvocationPolicy(admissionregV1.IfNeededReinvocationPolicy)
+	if _, err := Validating(objmeta.Name("policy")).Webhooks(reinvoked).T(); err == nil {
+		t.Error("expecting error, got none")
+	}
+}
@@ -0,0 +1,215 @@
+package admission
+
+import (
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	admissionregV1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PolicyBuilder provides a way to build values of type admissionregV1beta1.ValidatingAdmissionPolicy
+type PolicyBuilder map[string]interface{}
+
+// Policy starts a new ValidatingAdmissionPolicy builder using the provided object metadata
+func Policy(metadata objmeta.Builder) PolicyBuilder {
+	meta, err := metadata.U()
+	return PolicyBuilder(unstruct.SetErr(map[string]interface{}{"metadata": meta}, err))
+}
+
+// U returns the unstructured value of the builder
+func (b PolicyBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b PolicyBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder. An error is returned if one of
+// the policy's CEL expressions (variables, match conditions, validations,
+// messages or audit annotations) does not compile.
+func (b PolicyBuilder) T() (admissionregV1beta1.ValidatingAdmissionPolicy, error) {
+	var policy admissionregV1beta1.ValidatingAdmissionPolicy
+	if err := unstruct.FromUnstructured(b, &policy); err != nil {
+		return admissionregV1beta1.ValidatingAdmissionPolicy{}, err
+	}
+	if err := naming.Validate("ValidatingAdmissionPolicy", policy.Name); err != nil {
+		return admissionregV1beta1.ValidatingAdmissionPolicy{}, err
+	}
+	if err := compilePolicy(policy.Spec); err != nil {
+		return admissionregV1beta1.ValidatingAdmissionPolicy{}, err
+	}
+	return policy, nil
+}
+
+// ParamKind sets the kind of the resource (i.e. v1 ConfigMap) used to parameterize the policy
+func (b PolicyBuilder) ParamKind(apiVersion, kind string) PolicyBuilder {
+	return b.setSpec("paramKind", map[string]interface{}{"apiVersion": apiVersion, "kind": kind})
+}
+
+// MatchRules sets the operations and resources validated by the policy
+func (b PolicyBuilder) MatchRules(r ...RuleBuilder) PolicyBuilder {
+	b.getMatchConstraints()["resourceRules"] = rules(r)
+	return b
+}
+
+// ExcludeRules sets the operations and resources excluded from the policy
+func (b PolicyBuilder) ExcludeRules(r ...RuleBuilder) PolicyBuilder {
+	b.getMatchConstraints()["excludeResourceRules"] = rules(r)
+	return b
+}
+
+// NamespaceSelector restricts the policy to objects in namespaces with the labels
+func (b PolicyBuilder) NamespaceSelector(labels map[string]string) PolicyBuilder {
+	b.getMatchConstraints()["namespaceSelector"] = selector(labels)
+	return b
+}
+
+// ObjectSelector restricts the policy to objects with the labels
+func (b PolicyBuilder) ObjectSelector(labels map[string]string) PolicyBuilder {
+	b.getMatchConstraints()["objectSelector"] = selector(labels)
+	return b
+}
+
+// FailurePolicy sets whether requests are rejected (Fail) or admitted (Ignore) when the policy errors
+func (b PolicyBuilder) FailurePolicy(policy admissionregV1beta1.FailurePolicyType) PolicyBuilder {
+	return b.setSpec("failurePolicy", string(policy))
+}
+
+// Validations sets the validations of the policy
+func (b PolicyBuilder) Validations(validations ...ValidationBuilder) PolicyBuilder {
+	var slice []interface{}
+	for _, v := range validations {
+		slice = append(slice, unstruct.Nest(v))
+	}
+	return b.setSpec("validations", slice)
+}
+
+// AddVariable adds a CEL expression available to later expressions as variables.<name>
+func (b PolicyBuilder) AddVariable(name, expr string) PolicyBuilder {
+	return b.appendSpec("variables", map[string]interface{}{"name": name, "expression": expr})
+}
+
+// AddMatchCondition adds a CEL expression that must be true for the policy to be evaluated
+func (b PolicyBuilder) AddMatchCondition(name, expr string) PolicyBuilder {
+	return b.appendSpec("matchConditions", map[string]interface{}{"name": name, "expression": expr})
+}
+
+// AddAuditAnnotation adds an audit annotation whose value is the string result of the CEL expression
+func (b PolicyBuilder) AddAuditAnnotation(key, valueExpr string) PolicyBuilder {
+	return b.appendSpec("auditAnnotations", map[string]interface{}{"key": key, "valueExpression": valueExpr})
+}
+
+func (b PolicyBuilder) setSpec(key string, val interface{}) PolicyBuilder {
+	spec := b.getPolicySpec()
+	spec[key] = val
+	b["spec"] = spec
+	return b
+}
+
+func (b PolicyBuilder) appendSpec(key string, val interface{}) PolicyBuilder {
+	slice, _ := b.getPolicySpec()[key].([]interface{})
+	return b.setSpec(key, append(slice, val))
+}
+
+func (b PolicyBuilder) getPolicySpec() map[string]interface{} {
+	specIface, ok := b["spec"]
+	if !ok {
+		specIface = map[string]interface{}{}
+	}
+	return specIface.(map[string]interface{})
+}
+
+func (b PolicyBuilder) getMatchConstraints() map[string]interface{} {
+	spec := b.getPolicySpec()
+	match, ok := spec["matchConstraints"].(map[string]interface{})
+	if !ok {
+		match = map[string]interface{}{}
+		b.setSpec("matchConstraints", match)
+	}
+	return match
+}
+
+func compilePolicy(spec admissionregV1beta1.ValidatingAdmissionPolicySpec) error {
+	if len(spec.Variables) == 0 && len(spec.MatchConditions) == 0 && len(spec.Validations) == 0 && len(spec.AuditAnnotations) == 0 {
+		return nil
+	}
+	compiler, err := newCELCompiler(spec.ParamKind != nil)
+	if err != nil {
+		return err
+	}
+	for _, v := range spec.Variables {
+		if err := compiler.variable(anyVariable(v.Name, v.Expression)); err != nil {
+			return err
+		}
+	}
+	for _, cond := range spec.MatchConditions {
+		if err := compiler.compile("matchCondition "+cond.Name, boolExpression(cond.Expression)); err != nil {
+			return err
+		}
+	}
+	for _, v := range spec.Validations {
+		if err := compiler.compile("validation", boolExpression(v.Expression)); err != nil {
+			return err
+		}
+		if v.MessageExpression != "" {
+			if err := compiler.compile("messageExpression", stringExpression(v.MessageExpression)); err != nil {
+				return err
+			}
+		}
+	}
+	for _, a := range spec.AuditAnnotations {
+		if err := compiler.compile("auditAnnotation "+a.Key, stringExpression(a.ValueExpression)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidationBuilder provides a way to build values of type admissionregV1beta1.Validation
+type ValidationBuilder map[string]interface{}
+
+// Validation starts a new validation with a CEL expression that must
+// evaluate to true for the request to be admitted
+func Validation(expr string) ValidationBuilder {
+	return ValidationBuilder{"expression": expr}
+}
+
+// U returns the unstructured value of the builder
+func (b ValidationBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b ValidationBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b ValidationBuilder) T() (admissionregV1beta1.Validation, error) {
+	var validation admissionregV1beta1.Validation
+	if err := unstruct.FromUnstructured(b, &validation); err != nil {
+		return admissionregV1beta1.Validation{}, err
+	}
+	return validation, nil
+}
+
+// Message sets the message returned when the validation fails
+func (b ValidationBuilder) Message(msg string) ValidationBuilder {
+	b["message"] = msg
+	return b
+}
+
+// MessageExpression sets a CEL expression producing the message returned when the validation fails
+func (b ValidationBuilder) MessageExpression(expr string) ValidationBuilder {
+	b["messageExpression"] = expr
+	return b
+}
+
+// Reason sets the reason returned when the validation fails (i.e. Forbidden, Invalid)
+func (b ValidationBuilder) Reason(reason metaV1.StatusReason) ValidationBuilder {
+	b["reason"] = string(reason)
+	return b
+}
@@ -0,0 +1,137 @@
+package admission
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/objmeta"
+	admissionregV1 "k8s.io/api/admissionregistration/v1"
+	admissionregV1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPolicyTyped(t *testing.T) {
+	fail := admissionregV1beta1.Fail
+	tests := map[string]struct {
+		builder  PolicyBuilder
+		expected admissionregV1beta1.ValidatingAdmissionPolicy
+	}{
+		"empty": {
+			builder:  PolicyBuilder{},
+			expected: admissionregV1beta1.ValidatingAdmissionPolicy{},
+		},
+		"replica limit": {
+			builder: Policy(objmeta.Name("replica-limit")).
+				ParamKind("v1", "ConfigMap").
+				MatchRules(Rule(admissionregV1.Create, admissionregV1.Update).APIGroups("apps").APIVersions("v1").Resources("deployments")).
+				NamespaceSelector(map[string]string{"env": "prod"}).
+				FailurePolicy(admissionregV1beta1.Fail).
+				AddVariable("replicas", "object.spec.replicas").
+				AddMatchCondition("not-system", `!request.userInfo.username.startsWith("system:")`).
+				Validations(
+					Validation("variables.replicas <= int(params.data.maxReplicas)").
+						MessageExpression(`"replicas must be at most " + params.data.maxReplicas`).
+						Reason(metaV1.StatusReasonInvalid),
+					Validation("has(object.metadata.labels)").Message("labels are required"),
+				).
+				AddAuditAnnotation("replicas", "string(variables.replicas)"),
+			expected: admissionregV1beta1.ValidatingAdmissionPolicy{
+				ObjectMeta: metaV1.ObjectMeta{Name: "replica-limit"},
+				Spec: admissionregV1beta1.ValidatingAdmissionPolicySpec{
+					ParamKind: &admissionregV1beta1.ParamKind{APIVersion: "v1", Kind: "ConfigMap"},
+					MatchConstraints: &admissionregV1beta1.MatchResources{
+						NamespaceSelector: &metaV1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+						ResourceRules: []admissionregV1beta1.NamedRuleWithOperations{{
+							RuleWithOperations: admissionregV1beta1.RuleWithOperations{
+								Operations: []admissionregV1.OperationType{admissionregV1.Create, admissionregV1.Update},
+								Rule:       admissionregV1.Rule{APIGroups: []string{"apps"}, APIVersions: []string{"v1"}, Resources: []string{"deployments"}},
+							},
+						}},
+					},
+					FailurePolicy: &fail,
+					Variables:     []admissionregV1beta1.Variable{{Name: "replicas", Expression: "object.spec.replicas"}},
+					MatchConditions: []admissionregV1beta1.MatchCondition{
+						{Name: "not-system", Expression: `!request.userInfo.username.startsWith("system:")`},
+					},
+					Validations: []admissionregV1beta1.Validation{
+						{
+							Expression:        "variables.replicas <= int(params.data.maxReplicas)",
+							MessageExpression: `"replicas must be at most " + params.data.maxReplicas`,
+							Reason:            reasonPtr(metaV1.StatusReasonInvalid),
+						},
+						{Expression: "has(object.metadata.labels)", Message: "labels are required"},
+					},
+					AuditAnnotations: []admissionregV1beta1.AuditAnnotation{{Key: "replicas", ValueExpression: "string(variables.replicas)"}},
+				},
+			},
+		},
+		"exclude rules and object selector": {
+			builder: Policy(objmeta.Name("no-latest")).
+				MatchRules(Rule(admissionregV1.Create).Resources("pods").ResourceNames("web")).
+				ExcludeRules(Rule(admissionregV1.Create).Resources("pods/ephemeralcontainers")).
+				ObjectSelector(map[string]string{"app": "web"}).
+				Validations(Validation(`object.spec.containers.all(c, !c.image.endsWith(":latest"))`)),
+			expected: admissionregV1beta1.ValidatingAdmissionPolicy{
+				ObjectMeta: metaV1.ObjectMeta{Name: "no-latest"},
+				Spec: admissionregV1beta1.ValidatingAdmissionPolicySpec{
+					MatchConstraints: &admissionregV1beta1.MatchResources{
+						ObjectSelector: &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+						ResourceRules: []admissionregV1beta1.NamedRuleWithOperations{{
+							ResourceNames: []string{"web"},
+							RuleWithOperations: admissionregV1beta1.RuleWithOperations{
+								Operations: []admissionregV1.OperationType{admissionregV1.Create},
+								Rule:       admissionregV1.Rule{APIGroups: []string{"*"}, APIVersions: []string{"*"}, Resources: []string{"pods"}},
+							},
+						}},
+						ExcludeResourceRules: []admissionregV1beta1.NamedRuleWithOperations{{
+							RuleWithOperations: admissionregV1beta1.RuleWithOperations{
+								Operations: []admissionregV1.OperationType{admissionregV1.Create},
+								Rule:       admissionregV1.Rule{APIGroups: []string{"*"}, APIVersions: []string{"*"}, Resources: []string{"pods/ephemeralcontainers"}},
+							},
+						}},
+					},
+					Validations: []admissionregV1beta1.Validation{{Expression: `object.spec.containers.all(c, !c.image.endsWith(":latest"))`}},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			policy, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(policy, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", policy, test.expected)
+			}
+		})
+	}
+}
+
+func TestPolicyInvalid(t *testing.T) {
+	base := func() PolicyBuilder {
+		return Policy(objmeta.Name("policy")).MatchRules(Rule(admissionregV1.Create).Resources("pods"))
+	}
+	tests := map[string]PolicyBuilder{
+		"validation syntax error":  base().Validations(Validation("object.spec.replicas <")),
+		"validation not bool":      base().Validations(Validation("object.spec.replicas")),
+		"params without paramKind": base().Validations(Validation("object.spec.replicas < params.max")),
+		"message not string":       base().Validations(Validation("true").MessageExpression("1")),
+		"undeclared variable":      base().Validations(Validation("variables.missing > 1")),
+		"bad variable":             base().AddVariable("bad", "object.").Validations(Validation("true")),
+		"bad match condition":      base().AddMatchCondition("bad", "1").Validations(Validation("true")),
+		"bad audit annotation":     base().AddAuditAnnotation("bad", "object.").Validations(Validation("true")),
+	}
+	for name, builder := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := builder.T(); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
+
+func reasonPtr(reason metaV1.StatusReason) *metaV1.StatusReason {
+	return &reason
+}
@@ -0,0 +1,99 @@
+// Package admission contains builder types to build admission webhook
+// configurations and validating admission policies of the admissionregistration.k8s.io API
+package admission
+
+import (
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	admissionregV1 "k8s.io/api/admissionregistration/v1"
+)
+
+// RuleBuilder provides a way to build values of type admissionregV1.RuleWithOperations
+type RuleBuilder map[string]interface{}
+
+// Rule starts a new rule matching the operations (i.e. CREATE, UPDATE) for
+// all API groups and versions
+func Rule(ops ...admissionregV1.OperationType) RuleBuilder {
+	var slice []interface{}
+	for _, op := range ops {
+		slice = append(slice, string(op))
+	}
+	return RuleBuilder{
+		"operations":  slice,
+		"apiGroups":   []interface{}{"*"},
+		"apiVersions": []interface{}{"*"},
+	}
+}
+
+// U returns the unstructured value of the builder
+func (b RuleBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b RuleBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b RuleBuilder) T() (admissionregV1.RuleWithOperations, error) {
+	var rule admissionregV1.RuleWithOperations
+	if err := unstruct.FromUnstructured(b, &rule); err != nil {
+		return admissionregV1.RuleWithOperations{}, err
+	}
+	return rule, nil
+}
+
+// APIGroups sets the API groups of the resources ("" is the core group)
+func (b RuleBuilder) APIGroups(groups ...string) RuleBuilder {
+	b["apiGroups"] = stringSlice(groups)
+	return b
+}
+
+// APIVersions sets the API versions of the resources
+func (b RuleBuilder) APIVersions(versions ...string) RuleBuilder {
+	b["apiVersions"] = stringSlice(versions)
+	return b
+}
+
+// Resources sets the resources (i.e. pods, deployments/scale) the rule applies to
+func (b RuleBuilder) Resources(resources ...string) RuleBuilder {
+	b["resources"] = stringSlice(resources)
+	return b
+}
+
+// Scope restricts the rule to Cluster or Namespaced resources
+func (b RuleBuilder) Scope(scope admissionregV1.ScopeType) RuleBuilder {
+	b["scope"] = string(scope)
+	return b
+}
+
+// ResourceNames restricts the rule to the named resources. It is only
+// supported by validating admission policy rules and is ignored by webhooks.
+func (b RuleBuilder) ResourceNames(names ...string) RuleBuilder {
+	b["resourceNames"] = stringSlice(names)
+	return b
+}
+
+func rules(rules []RuleBuilder) []interface{} {
+	var slice []interface{}
+	for _, r := range rules {
+		slice = append(slice, unstruct.Nest(r))
+	}
+	return slice
+}
+
+func selector(labels map[string]string) map[string]interface{} {
+	match := map[string]interface{}{}
+	for k, v := range labels {
+		match[k] = v
+	}
+	return map[string]interface{}{"matchLabels": match}
+}
+
+func stringSlice(strs []string) []interface{} {
+	var slice []interface{}
+	for _, s := range strs {
+		slice = append(slice, s)
+	}
+	return slice
+}
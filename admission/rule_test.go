@@ -0,0 +1,49 @@
+package admission
+
+import (
+	"reflect"
+	"testing"
+
+	admissionregV1 "k8s.io/api/admissionregistration/v1"
+)
+
+func TestRuleTyped(t *testing.T) {
+	namespaced := admissionregV1.NamespacedScope
+	tests := map[string]struct {
+		builder  RuleBuilder
+		expected admissionregV1.RuleWithOperations
+	}{
+		"defaults": {
+			builder: Rule(admissionregV1.Create).Resources("pods"),
+			expected: admissionregV1.RuleWithOperations{
+				Operations: []admissionregV1.OperationType{admissionregV1.Create},
+				Rule:       admissionregV1.Rule{APIGroups: []string{"*"}, APIVersions: []string{"*"}, Resources: []string{"pods"}},
+			},
+		},
+		"all fields": {
+			builder: Rule(admissionregV1.Create, admissionregV1.Update).
+				APIGroups("apps").APIVersions("v1").Resources("deployments", "deployments/scale").Scope(admissionregV1.NamespacedScope),
+			expected: admissionregV1.RuleWithOperations{
+				Operations: []admissionregV1.OperationType{admissionregV1.Create, admissionregV1.Update},
+				Rule: admissionregV1.Rule{
+					APIGroups:   []string{"apps"},
+					APIVersions: []string{"v1"},
+					Resources:   []string{"deployments", "deployments/scale"},
+					Scope:       &namespaced,
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rule, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(rule, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", rule, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package admission
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	admissionregV1 "k8s.io/api/admissionregistration/v1"
+)
+
+// WebhookBuilder provides a way to build values of type admissionregV1.ValidatingWebhook
+// and admissionregV1.MutatingWebhook
+type WebhookBuilder map[string]interface{}
+
+// Webhook starts a new webhook with the fully qualified name (i.e. pods.policy.example.com).
+// The webhook defaults to no side effects and the v1 admission review version.
+func Webhook(name string) WebhookBuilder {
+	return WebhookBuilder{
+		"name":                    name,
+		"sideEffects":             string(admissionregV1.SideEffectClassNone),
+		"admissionReviewVersions": []interface{}{"v1"},
+	}
+}
+
+// U returns the unstructured value of the builder
+func (b WebhookBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b WebhookBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder as a validating webhook (use
+// MutatingBuilder.T for mutating webhooks). An error is returned if the
+// webhook is invalid (see validateWebhook).
+func (b WebhookBuilder) T() (admissionregV1.ValidatingWebhook, error) {
+	var hook admissionregV1.ValidatingWebhook
+	if err := unstruct.FromUnstructured(b, &hook); err != nil {
+		return admissionregV1.ValidatingWebhook{}, err
+	}
+	if err := validateWebhook(hook.Name, hook.ClientConfig, hook.TimeoutSeconds, hook.MatchConditions); err != nil {
+		return admissionregV1.ValidatingWebhook{}, err
+	}
+	return hook, nil
+}
+
+// Rules sets the operations and resources intercepted by the webhook
+func (b WebhookBuilder) Rules(r ...RuleBuilder) WebhookBuilder {
+	b["rules"] = rules(r)
+	return b
+}
+
+// Service calls the webhook through the service at path (port 443 unless set with ServicePort)
+func (b WebhookBuilder) Service(namespace, name, path string) WebhookBuilder {
+	svc := map[string]interface{}{"namespace": namespace, "name": name}
+	if path != "" {
+		svc["path"] = path
+	}
+	b.getClientConfig()["service"] = svc
+	return b
+}
+
+// ServicePort sets the port of the webhook's service
+func (b WebhookBuilder) ServicePort(port int32) WebhookBuilder {
+	svc, ok := b.getClientConfig()["service"].(map[string]interface{})
+	if !ok {
+		svc = map[string]interface{}{}
+		b.getClientConfig()["service"] = svc
+	}
+	svc["port"] = int64(port)
+	return b
+}
+
+// URL calls the webhook at the https URL, outside of the cluster
+func (b WebhookBuilder) URL(url string) WebhookBuilder {
+	b.getClientConfig()["url"] = url
+	return b
+}
+
+// CABundle sets the PEM encoded CA bundle used to validate the webhook's serving certificate
+func (b WebhookBuilder) CABundle(caBundle []byte) WebhookBuilder {
+	b.getClientConfig()["caBundle"] = base64.StdEncoding.EncodeToString(caBundle)
+	return b
+}
+
+// FailurePolicy sets whether requests are rejected (Fail) or admitted (Ignore) when the webhook errors
+func (b WebhookBuilder) FailurePolicy(policy admissionregV1.FailurePolicyType) WebhookBuilder {
+	b["failurePolicy"] = string(policy)
+	return b
+}
+
+// MatchPolicy sets how rules match requests for equivalent resources (Exact or Equivalent)
+func (b WebhookBuilder) MatchPolicy(policy admissionregV1.MatchPolicyType) WebhookBuilder {
+	b["matchPolicy"] = string(policy)
+	return b
+}
+
+// SideEffects declares whether the webhook has side effects (None or NoneOnDryRun)
+func (b WebhookBuilder) SideEffects(class admissionregV1.SideEffectClass) WebhookBuilder {
+	b["sideEffects"] = string(class)
+	return b
+}
+
+// TimeoutSeconds sets how long to wait for the webhook, between 1 and 30 seconds
+func (b WebhookBuilder) TimeoutSeconds(secs int32) WebhookBuilder {
+	b["timeoutSeconds"] = int64(secs)
+	return b
+}
+
+// NamespaceSelector restricts the webhook to objects in namespaces with the labels
+func (b WebhookBuilder) NamespaceSelector(labels map[string]string) WebhookBuilder {
+	b["namespaceSelector"] = selector(labels)
+	return b
+}
+
+// ObjectSelector restricts the webhook to objects with the labels
+func (b WebhookBuilder) ObjectSelector(labels map[string]string) WebhookBuilder {
+	b["objectSelector"] = selector(labels)
+	return b
+}
+
+// AdmissionReviewVersions sets the AdmissionReview versions the webhook accepts, in order of preference
+func (b WebhookBuilder) AdmissionReviewVersions(versions ...string) WebhookBuilder {
+	b["admissionReviewVersions"] = stringSlice(versions)
+	return b
+}
+
+// AddMatchCondition adds a CEL expression, evaluated with object, oldObject,
+// request and authorizer, that must be true for the webhook to be called
+func (b WebhookBuilder) AddMatchCondition(name, expr string) WebhookBuilder {
+	conds, _ := b["matchConditions"].([]interface{})
+	b["matchConditions"] = append(conds, map[string]interface{}{"name": name, "expression": expr})
+	return b
+}
+
+// ReinvocationPolicy sets whether a mutating webhook is called again (IfNeeded)
+// after other mutations. It is rejected for validating webhooks.
+func (b WebhookBuilder) ReinvocationPolicy(policy admissionregV1.ReinvocationPolicyType) WebhookBuilder {
+	b["reinvocationPolicy"] = string(policy)
+	return b
+}
+
+func (b WebhookBuilder) getClientConfig() map[string]interface{} {
+	cc, ok := b["clientConfig"].(map[string]interface{})
+	if !ok {
+		cc = map[string]interface{}{}
+		b["clientConfig"] = cc
+	}
+	return cc
+}
+
+// validateWebhook checks that the webhook is called through exactly one of
+// a service or URL, that its timeout is within 1 and 30 seconds and that its
+// match conditions compile
+func validateWebhook(name string, cc admissionregV1.WebhookClientConfig, timeout *int32, conds []admissionregV1.MatchCondition) error {
+	if (cc.Service == nil) == (cc.URL == nil) {
+		return fmt.Errorf("admission: webhook %s must set exactly one of service or url", name)
+	}
+	if timeout != nil && (*timeout < 1 || *timeout > 30) {
+		return fmt.Errorf("admission: webhook %s timeoutSeconds must be between 1 and 30", name)
+	}
+	if len(conds) == 0 {
+		return nil
+	}
+	compiler, err := newCELCompiler(false)
+	if err != nil {
+		return err
+	}
+	for _, cond := range conds {
+		if err := compiler.compile("matchCondition "+cond.Name, boolExpression(cond.Expression)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package admission
+
+import (
+	"reflect"
+	"testing"
+
+	admissionregV1 "k8s.io/api/admissionregistration/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWebhookTyped(t *testing.T) {
+	none, fail, equivalent := admissionregV1.SideEffectClassNone, admissionregV1.Fail, admissionregV1.Equivalent
+	tests := map[string]struct {
+		builder  WebhookBuilder
+		expected admissionregV1.ValidatingWebhook
+	}{
+		"service": {
+			builder: Webhook("pods.policy.example.com").Service("system", "policy", "/validate").CABundle([]byte("ca")),
+			expected: admissionregV1.ValidatingWebhook{
+				Name: "pods.policy.example.com",
+				ClientConfig: admissionregV1.WebhookClientConfig{
+					Service:  &admissionregV1.ServiceReference{Namespace: "system", Name: "policy", Path: strPtr("/validate")},
+					CABundle: []byte("ca"),
+				},
+				SideEffects:             &none,
+				AdmissionReviewVersions: []string{"v1"},
+			},
+		},
+		"url with all fields": {
+			builder: Webhook("pods.policy.example.com").
+				URL("https://policy.example.com/validate").
+				Rules(Rule(admissionregV1.Create).APIGroups("").APIVersions("v1").Resources("pods")).
+				FailurePolicy(admissionregV1.Fail).
+				MatchPolicy(admissionregV1.Equivalent).
+				TimeoutSeconds(5).
+				NamespaceSelector(map[string]string{"policy": "enabled"}).
+				ObjectSelector(map[string]string{"app": "web"}).
+				AdmissionReviewVersions("v1", "v1beta1").
+				AddMatchCondition("not-system", `!request.userInfo.username.startsWith("system:")`),
+			expected: admissionregV1.ValidatingWebhook{
+				Name:         "pods.policy.example.com",
+				ClientConfig: admissionregV1.WebhookClientConfig{URL: strPtr("https://policy.example.com/validate")},
+				Rules: []admissionregV1.RuleWithOperations{{
+					Operations: []admissionregV1.OperationType{admissionregV1.Create},
+					Rule:       admissionregV1.Rule{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}},
+				}},
+				FailurePolicy:           &fail,
+				MatchPolicy:             &equivalent,
+				SideEffects:             &none,
+				TimeoutSeconds:          int32Ptr(5),
+				NamespaceSelector:       &metaV1.LabelSelector{MatchLabels: map[string]string{"policy": "enabled"}},
+				ObjectSelector:          &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				AdmissionReviewVersions: []string{"v1", "v1beta1"},
+				MatchConditions: []admissionregV1.MatchCondition{
+					{Name: "not-system", Expression: `!request.userInfo.username.startsWith("system:")`},
+				},
+			},
+		},
+		"service port": {
+			builder: Webhook("pods.policy.example.com").Service("system", "policy", "").ServicePort(8443),
+			expected: admissionregV1.ValidatingWebhook{
+				Name: "pods.policy.example.com",
+				ClientConfig: admissionregV1.WebhookClientConfig{
+					Service: &admissionregV1.ServiceReference{Namespace: "system", Name: "policy", Port: int32Ptr(8443)},
+				},
+				SideEffects:             &none,
+				AdmissionReviewVersions: []string{"v1"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			hook, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(hook, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", hook, test.expected)
+			}
+		})
+	}
+}
+
+func TestWebhookInvalid(t *testing.T) {
+	svc := func() WebhookBuilder {
+		return Webhook("pods.policy.example.com").Service("system", "policy", "/validate")
+	}
+	tests := map[string]WebhookBuilder{
+		"no client config":       Webhook("pods.policy.example.com"),
+		"service and url":        svc().URL("https://policy.example.com"),
+		"timeout too long":       svc().TimeoutSeconds(31),
+		"condition syntax error": svc().AddMatchCondition("bad", "object.metadata.name =="),
+		"condition not bool":     svc().AddMatchCondition("string", "object.metadata.name"),
+		"condition unknown var":  svc().AddMatchCondition("params", "params.enabled"),
+	}
+	for name, builder := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := builder.T(); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func strPtr(s string) *string {
+	return &s
+}
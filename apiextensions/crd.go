@@ -0,0 +1,161 @@
+// Package apiextensions contains builder types to build values of type
+// apiextV1.CustomResourceDefinition, including OpenAPI schemas generated from Go types.
+//
+// Schemas are generated by reflection (see SchemaFrom), which reads
+// validation markers from the kubebuilder struct tag (see MarkerTag). The
+// comment markers used by kubebuilder (+kubebuilder:validation:Minimum=1)
+// are not visible at run time and are read from the source files of the
+// types by ParseMarkers.
+package apiextensions
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	apiextV1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// Builder provides a way to build values of type apiextV1.CustomResourceDefinition
+type Builder map[string]interface{}
+
+// Object starts a new CustomResourceDefinition builder using the provided object metadata
+func Object(metadata objmeta.Builder) Builder {
+	meta, err := metadata.U()
+	return Builder(unstruct.SetErr(map[string]interface{}{"metadata": meta}, err))
+}
+
+// For starts a new CustomResourceDefinition builder for the resource kind
+// and plural name in the API group. The definition is named <plural>.<group>
+// and is namespaced by default.
+func For(group, kind, plural string) Builder {
+	return Object(objmeta.Name(plural + "." + group)).
+		Group(group).
+		Names(Names(kind, plural)).
+		Scope(apiextV1.NamespaceScoped)
+}
+
+// U returns the unstructured value of the builder
+func (b Builder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b Builder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder. An error is returned if the
+// definition's name is not <plural>.<group>, if it has no versions or if
+// there is not exactly one storage version.
+func (b Builder) T() (apiextV1.CustomResourceDefinition, error) {
+	var crd apiextV1.CustomResourceDefinition
+	if err := unstruct.FromUnstructured(b, &crd); err != nil {
+		return apiextV1.CustomResourceDefinition{}, err
+	}
+	if err := naming.Validate("CustomResourceDefinition", crd.Name); err != nil {
+		return apiextV1.CustomResourceDefinition{}, err
+	}
+	if err := validate(crd); err != nil {
+		return apiextV1.CustomResourceDefinition{}, err
+	}
+	return crd, nil
+}
+
+// Group sets the API group of the custom resource (i.e. stable.example.com)
+func (b Builder) Group(group string) Builder {
+	return b.setSpec("group", group)
+}
+
+// Names sets the names used to serve the custom resource
+func (b Builder) Names(names NamesBuilder) Builder {
+	return b.setSpec("names", unstruct.Nest(names))
+}
+
+// Scope sets whether the custom resource is Cluster or Namespaced scoped
+func (b Builder) Scope(scope apiextV1.ResourceScope) Builder {
+	return b.setSpec("scope", scope)
+}
+
+// Versions sets the API versions of the custom resource
+func (b Builder) Versions(versions ...VersionBuilder) Builder {
+	var slice []interface{}
+	for _, v := range versions {
+		slice = append(slice, unstruct.Nest(v))
+	}
+	return b.setSpec("versions", slice)
+}
+
+// PreserveUnknownFields sets whether unknown fields are persisted (deprecated, must be false for v1)
+func (b Builder) PreserveUnknownFields(preserve bool) Builder {
+	return b.setSpec("preserveUnknownFields", preserve)
+}
+
+// NoneConversion sets the conversion strategy to None, where only the
+// apiVersion of the stored objects is changed
+func (b Builder) NoneConversion() Builder {
+	return b.setSpec("conversion", map[string]interface{}{"strategy": apiextV1.NoneConverter})
+}
+
+// WebhookConversion sets the conversion strategy to Webhook, calling the service at path.
+// The caBundle is used to validate the webhook's serving certificate.
+func (b Builder) WebhookConversion(svcNamespace, svcName, path string, caBundle []byte, reviewVersions ...string) Builder {
+	svc := map[string]interface{}{"namespace": svcNamespace, "name": svcName}
+	if path != "" {
+		svc["path"] = path
+	}
+	clientConfig := map[string]interface{}{"service": svc}
+	if len(caBundle) > 0 {
+		clientConfig["caBundle"] = base64.StdEncoding.EncodeToString(caBundle)
+	}
+	if len(reviewVersions) == 0 {
+		reviewVersions = []string{"v1"}
+	}
+	var versions []interface{}
+	for _, v := range reviewVersions {
+		versions = append(versions, v)
+	}
+	return b.setSpec("conversion", map[string]interface{}{
+		"strategy": apiextV1.WebhookConverter,
+		"webhook":  map[string]interface{}{"clientConfig": clientConfig, "conversionReviewVersions": versions},
+	})
+}
+
+func (b Builder) setSpec(key string, val interface{}) Builder {
+	spec := b.getCRDSpec()
+	spec[key] = val
+	b["spec"] = spec
+	return b
+}
+
+func (b Builder) getCRDSpec() map[string]interface{} {
+	specIface, ok := b["spec"]
+	if !ok {
+		specIface = map[string]interface{}{}
+	}
+	return specIface.(map[string]interface{})
+}
+
+func validate(crd apiextV1.CustomResourceDefinition) error {
+	if crd.Spec.Names.Plural == "" && len(crd.Spec.Versions) == 0 {
+		return nil
+	}
+	if expected := crd.Spec.Names.Plural + "." + crd.Spec.Group; crd.Name != expected {
+		return fmt.Errorf("apiextensions: definition name %q must be %q", crd.Name, expected)
+	}
+	if len(crd.Spec.Versions) == 0 {
+		return fmt.Errorf("apiextensions: definition %s must have at least one version", crd.Name)
+	}
+	storage := 0
+	for _, v := range crd.Spec.Versions {
+		if v.Storage {
+			storage++
+		}
+	}
+	if storage != 1 {
+		return fmt.Errorf("apiextensions: definition %s must have exactly one storage version, found %d", crd.Name, storage)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package apiextensions
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/objmeta"
+	apiextV1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCRDTyped(t *testing.T) {
+	names := apiextV1.CustomResourceDefinitionNames{Kind: "CronTab", Plural: "crontabs", Singular: "crontab", ListKind: "CronTabList"}
+	tests := map[string]struct {
+		builder  Builder
+		expected apiextV1.CustomResourceDefinition
+	}{
+		"empty": {
+			builder:  Builder{},
+			expected: apiextV1.CustomResourceDefinition{},
+		},
+		"object meta only": {
+			builder:  Object(objmeta.Name("crontabs.stable.example.com")),
+			expected: apiextV1.CustomResourceDefinition{ObjectMeta: metaV1.ObjectMeta{Name: "crontabs.stable.example.com"}},
+		},
+		"for with version": {
+			builder: For("stable.example.com", "CronTab", "crontabs").Versions(Version("v1").Storage(true)),
+			expected: apiextV1.CustomResourceDefinition{
+				ObjectMeta: metaV1.ObjectMeta{Name: "crontabs.stable.example.com"},
+				Spec: apiextV1.CustomResourceDefinitionSpec{
+					Group:    "stable.example.com",
+					Names:    names,
+					Scope:    apiextV1.NamespaceScoped,
+					Versions: []apiextV1.CustomResourceDefinitionVersion{{Name: "v1", Served: true, Storage: true}},
+				},
+			},
+		},
+		"cluster scoped with webhook conversion": {
+			builder: For("stable.example.com", "CronTab", "crontabs").
+				Scope(apiextV1.ClusterScoped).
+				Versions(Version("v1beta1").Deprecated("use v1"), Version("v1").Storage(true)).
+				WebhookConversion("system", "converter", "/convert", []byte("ca")),
+			expected: apiextV1.CustomResourceDefinition{
+				ObjectMeta: metaV1.ObjectMeta{Name: "crontabs.stable.example.com"},
+				Spec: apiextV1.CustomResourceDefinitionSpec{
+					Group: "stable.example.com",
+					Names: names,
+					Scope: apiextV1.ClusterScoped,
+					Versions: []apiextV1.CustomResourceDefinitionVersion{
+						{Name: "v1beta1", Served: true, Deprecated: true, DeprecationWarning: strPtr("use v1")},
+						{Name: "v1", Served: true, Storage: true},
+					},
+					Conversion: &apiextV1.CustomResourceConversion{
+						Strategy: apiextV1.WebhookConverter,
+						Webhook: &apiextV1.WebhookConversion{
+							ClientConfig: &apiextV1.WebhookClientConfig{
+								Service:  &apiextV1.ServiceReference{Namespace: "system", Name: "converter", Path: strPtr("/convert")},
+								CABundle: []byte("ca"),
+							},
+							ConversionReviewVersions: []string{"v1"},
+						},
+					},
+				},
+			},
+		},
+		"none conversion": {
+			builder: For("stable.example.com", "CronTab", "crontabs").Versions(Version("v1").Storage(true)).NoneConversion(),
+			expected: apiextV1.CustomResourceDefinition{
+				ObjectMeta: metaV1.ObjectMeta{Name: "crontabs.stable.example.com"},
+				Spec: apiextV1.CustomResourceDefinitionSpec{
+					Group:      "stable.example.com",
+					Names:      names,
+					Scope:      apiextV1.NamespaceScoped,
+					Versions:   []apiextV1.CustomResourceDefinitionVersion{{Name: "v1", Served: true, Storage: true}},
+					Conversion: &apiextV1.CustomResourceConversion{Strategy: apiextV1.NoneConverter},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crd, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(crd, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", crd, test.expected)
+			}
+		})
+	}
+}
+
+func TestCRDInvalid(t *testing.T) {
+	tests := map[string]Builder{
+		"no versions":   For("stable.example.com", "CronTab", "crontabs"),
+		"no storage":    For("stable.example.com", "CronTab", "crontabs").Versions(Version("v1")),
+		"two storage":   For("stable.example.com", "CronTab", "crontabs").Versions(Version("v1").Storage(true), Version("v2").Storage(true)),
+		"name mismatch": Object(objmeta.Name("crontabs")).Group("stable.example.com").Names(Names("CronTab", "crontabs")).Versions(Version("v1").Storage(true)),
+	}
+	for name, builder := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := builder.T(); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
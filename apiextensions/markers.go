@@ -0,0 +1,122 @@
+package apiextensions
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	apiextV1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// Markers maps the struct fields, as <type>.<field>, to the kubebuilder
+// comment markers declared on them, in the form read from MarkerTag
+// (i.e. validation:Minimum=1). See ParseMarkers.
+type Markers map[string][]string
+
+// fieldMarkers lists the prefixes of the comment markers applying to fields,
+// other markers (i.e. +kubebuilder:object:root or +k8s:deepcopy-gen) are ignored
+var fieldMarkers = []string{
+	"+kubebuilder:validation:",
+	"+kubebuilder:default=",
+	"+optional",
+	"+required",
+	"+listType=",
+	"+listMapKey=",
+	"+mapType=",
+}
+
+// ParseMarkers reads the comment markers of the struct fields declared in
+// the Go files of dir, which is usually the directory of the API types of an
+// operator, for instance:
+//
+//	// +kubebuilder:validation:Minimum=1
+//	// +kubebuilder:validation:Maximum=10
+//	Replicas int32 `json:"replicas"`
+//
+// The markers are matched to the fields by the name of their struct type, so
+// the types passed to Markers.SchemaFrom must be declared in dir.
+func ParseMarkers(dir string) (Markers, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("apiextensions: markers: %w", err)
+	}
+	markers := Markers{}
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("apiextensions: markers: %w", err)
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			spec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			st, ok := spec.Type.(*ast.StructType)
+			if !ok {
+				return true
+			}
+			for _, field := range st.Fields.List {
+				found := commentMarkers(field.Doc)
+				if len(found) == 0 {
+					continue
+				}
+				for _, ident := range field.Names {
+					markers[spec.Name.Name+"."+ident.Name] = found
+				}
+				// embedded fields are named after their type
+				if len(field.Names) == 0 {
+					markers[spec.Name.Name+"."+embeddedName(field.Type)] = found
+				}
+			}
+			return true
+		})
+	}
+	return markers, nil
+}
+
+// SchemaFrom generates the schema of obj as the SchemaFrom function does,
+// applying the comment markers of its fields before the markers of their
+// MarkerTag struct tag
+func (m Markers) SchemaFrom(obj any) (apiextV1.JSONSchemaProps, error) {
+	return schemaFrom(obj, m)
+}
+
+// commentMarkers returns the field markers of the comment group, without
+// their + and kubebuilder: prefixes
+func commentMarkers(doc *ast.CommentGroup) []string {
+	if doc == nil {
+		return nil
+	}
+	var markers []string
+	for _, c := range doc.List {
+		line := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+		for _, prefix := range fieldMarkers {
+			if strings.HasPrefix(line, prefix) {
+				markers = append(markers, strings.TrimPrefix(strings.TrimPrefix(line, "+"), "kubebuilder:"))
+				break
+			}
+		}
+	}
+	return markers
+}
+
+func embeddedName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(e.X)
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	case *ast.Ident:
+		return e.Name
+	}
+	return ""
+}
@@ -0,0 +1,102 @@
+package apiextensions
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	apiextV1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// widgetSpec is declared in the source parsed by TestParseMarkers
+type widgetSpec struct {
+	Size     int32    `json:"size"`
+	Color    string   `json:"color" kubebuilder:"validation:MaxLength=10"`
+	Sizes    []string `json:"sizes,omitempty"`
+	Selector string   `json:"selector"`
+}
+
+const widgetSource = `package v1
+
+// +kubebuilder:object:root=true
+type widgetSpec struct {
+	// Size of the widget
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	// +kubebuilder:default=1
+	Size int32 ` + "`json:\"size\"`" + `
+
+	// +kubebuilder:validation:Enum=red;blue
+	// +optional
+	Color string ` + "`json:\"color\"`" + `
+
+	// +listType=set
+	Sizes []string ` + "`json:\"sizes,omitempty\"`" + `
+
+	// +kubebuilder:validation:Pattern=^[a-z]+(,[a-z]+)*$
+	Selector string ` + "`json:\"selector\"`" + `
+}
+`
+
+func TestParseMarkers(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "types.go"), []byte(widgetSource), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	markers, err := ParseMarkers(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema, err := markers.SchemaFrom(widgetSpec{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	set, def := "set", apiextV1.JSON{Raw: []byte("1")}
+	min, max, maxLength := float64(1), float64(10), int64(10)
+	expected := apiextV1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextV1.JSONSchemaProps{
+			"size": {Type: "integer", Format: "int32", Minimum: &min, Maximum: &max, Default: &def},
+			"color": {
+				Type:      "string",
+				MaxLength: &maxLength,
+				Enum:      []apiextV1.JSON{{Raw: []byte(`"red"`)}, {Raw: []byte(`"blue"`)}},
+			},
+			"sizes":    {Type: "array", Items: &apiextV1.JSONSchemaPropsOrArray{Schema: &apiextV1.JSONSchemaProps{Type: "string"}}, XListType: &set},
+			"selector": {Type: "string", Pattern: "^[a-z]+(,[a-z]+)*$"},
+		},
+		Required: []string{"size", "selector"},
+	}
+	if !reflect.DeepEqual(schema, expected) {
+		t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", schema, expected)
+	}
+}
+
+func TestParseMarkersInvalid(t *testing.T) {
+	if _, err := ParseMarkers(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expecting error, got none")
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "types.go"), []byte("package v1\n\ntype widgetSpec struct {\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseMarkers(dir); err == nil {
+		t.Error("expecting error, got none")
+	}
+
+	dir = t.TempDir()
+	src := "package v1\n\ntype widgetSpec struct {\n\t// +kubebuilder:validation:Minimum=one\n\tSize int32\n}\n"
+	if err := os.WriteFile(filepath.Join(dir, "types.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	markers, err := ParseMarkers(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := markers.SchemaFrom(widgetSpec{}); err == nil {
+		t.Error("expecting error, got none")
+	}
+}
@@ -0,0 +1,74 @@
+package apiextensions
+
+import (
+	"strings"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	apiextV1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// NamesBuilder provides a way to build values of type apiextV1.CustomResourceDefinitionNames
+type NamesBuilder map[string]interface{}
+
+// Names starts new resource names with the kind (i.e. CronTab) and plural
+// name (i.e. crontabs). The singular name defaults to the lowercased kind and
+// the list kind to <kind>List.
+func Names(kind, plural string) NamesBuilder {
+	return NamesBuilder{
+		"kind":     kind,
+		"plural":   plural,
+		"singular": strings.ToLower(kind),
+		"listKind": kind + "List",
+	}
+}
+
+// U returns the unstructured value of the builder
+func (b NamesBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b NamesBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b NamesBuilder) T() (apiextV1.CustomResourceDefinitionNames, error) {
+	var names apiextV1.CustomResourceDefinitionNames
+	if err := unstruct.FromUnstructured(b, &names); err != nil {
+		return apiextV1.CustomResourceDefinitionNames{}, err
+	}
+	return names, nil
+}
+
+// Singular sets the singular name of the resource
+func (b NamesBuilder) Singular(name string) NamesBuilder {
+	b["singular"] = name
+	return b
+}
+
+// ListKind sets the kind of the resource's list type
+func (b NamesBuilder) ListKind(kind string) NamesBuilder {
+	b["listKind"] = kind
+	return b
+}
+
+// ShortNames sets the short names (i.e. ct) usable from the CLI
+func (b NamesBuilder) ShortNames(names ...string) NamesBuilder {
+	b["shortNames"] = stringSlice(names)
+	return b
+}
+
+// Categories sets the grouped resources (i.e. all) the resource belongs to
+func (b NamesBuilder) Categories(categories ...string) NamesBuilder {
+	b["categories"] = stringSlice(categories)
+	return b
+}
+
+func stringSlice(strs []string) []interface{} {
+	var slice []interface{}
+	for _, s := range strs {
+		slice = append(slice, s)
+	}
+	return slice
+}
@@ -0,0 +1,39 @@
+package apiextensions
+
+import (
+	"reflect"
+	"testing"
+
+	apiextV1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestNamesTyped(t *testing.T) {
+	tests := map[string]struct {
+		builder  NamesBuilder
+		expected apiextV1.CustomResourceDefinitionNames
+	}{
+		"defaults": {
+			builder:  Names("CronTab", "crontabs"),
+			expected: apiextV1.CustomResourceDefinitionNames{Kind: "CronTab", Plural: "crontabs", Singular: "crontab", ListKind: "CronTabList"},
+		},
+		"all names": {
+			builder: Names("CronTab", "crontabs").Singular("cron").ListKind("CronTabs").ShortNames("ct").Categories("all", "batch"),
+			expected: apiextV1.CustomResourceDefinitionNames{
+				Kind: "CronTab", Plural: "crontabs", Singular: "cron", ListKind: "CronTabs",
+				ShortNames: []string{"ct"}, Categories: []string{"all", "batch"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			names, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(names, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", names, test.expected)
+			}
+		})
+	}
+}
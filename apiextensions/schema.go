@@ -0,0 +1,307 @@
+package apiextensions
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	apiextV1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// MarkerTag is the struct tag read by SchemaFrom for kubebuilder-style
+// validation markers, separated by commas. Go types only carry struct tags
+// at run time: the markers kubebuilder reads from comments must be parsed
+// from the source with ParseMarkers. For instance:
+//
+//	Replicas int32 `json:"replicas" kubebuilder:"validation:Minimum=1,validation:Maximum=10"`
+//
+// Supported markers are validation:Minimum, validation:Maximum,
+// validation:ExclusiveMinimum, validation:ExclusiveMaximum, validation:MinLength,
+// validation:MaxLength, validation:MinItems, validation:MaxItems,
+// validation:MinProperties, validation:MaxProperties, validation:Format,
+// validation:Enum (values separated by ;), validation:Pattern (must be the
+// last marker as the expression may contain commas), validation:Required,
+// validation:Optional, validation:XPreserveUnknownFields,
+// validation:XIntOrString, default, listType, listMapKey and
+// mapType. The +optional and +required markers are accepted as optional
+// and required, and the +kubebuilder: prefix of comment markers is dropped.
+const MarkerTag = "kubebuilder"
+
+var (
+	timeType         = reflect.TypeOf(metaV1.Time{})
+	microTimeType    = reflect.TypeOf(metaV1.MicroTime{})
+	durationType     = reflect.TypeOf(metaV1.Duration{})
+	objectMetaType   = reflect.TypeOf(metaV1.ObjectMeta{})
+	quantityType     = reflect.TypeOf(resource.Quantity{})
+	intOrStringType  = reflect.TypeOf(intstr.IntOrString{})
+	rawExtensionType = reflect.TypeOf(runtime.RawExtension{})
+	jsonType         = reflect.TypeOf(apiextV1.JSON{})
+)
+
+// SchemaFrom generates an OpenAPI v3 schema, suitable for a structural
+// CustomResourceDefinition, by reflecting over the json tags of obj.
+// Fields without omitempty are required unless marked optional (see MarkerTag).
+// An error is returned for unsupported or recursive types and for malformed markers.
+func SchemaFrom(obj any) (apiextV1.JSONSchemaProps, error) {
+	return schemaFrom(obj, nil)
+}
+
+func schemaFrom(obj any, markers Markers) (apiextV1.JSONSchemaProps, error) {
+	t := reflect.TypeOf(obj)
+	if t == nil {
+		return apiextV1.JSONSchemaProps{}, fmt.Errorf("apiextensions: cannot generate schema from nil")
+	}
+	g := generator{visiting: map[reflect.Type]bool{}, markers: markers}
+	return g.schemaOf(t)
+}
+
+type generator struct {
+	visiting map[reflect.Type]bool
+	markers  Markers
+}
+
+func (g generator) schemaOf(t reflect.Type) (apiextV1.JSONSchemaProps, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType, microTimeType:
+		return apiextV1.JSONSchemaProps{Type: "string", Format: "date-time"}, nil
+	case durationType:
+		return apiextV1.JSONSchemaProps{Type: "string"}, nil
+	case objectMetaType:
+		return apiextV1.JSONSchemaProps{Type: "object"}, nil
+	case quantityType:
+		return apiextV1.JSONSchemaProps{
+			AnyOf:        []apiextV1.JSONSchemaProps{{Type: "integer"}, {Type: "string"}},
+			Pattern:      `^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$`,
+			XIntOrString: true,
+		}, nil
+	case intOrStringType:
+		return apiextV1.JSONSchemaProps{
+			AnyOf:        []apiextV1.JSONSchemaProps{{Type: "integer"}, {Type: "string"}},
+			XIntOrString: true,
+		}, nil
+	case rawExtensionType:
+		return apiextV1.JSONSchemaProps{Type: "object", XPreserveUnknownFields: boolPtr(true)}, nil
+	case jsonType:
+		return apiextV1.JSONSchemaProps{XPreserveUnknownFields: boolPtr(true)}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return apiextV1.JSONSchemaProps{Type: "boolean"}, nil
+	case reflect.Int32, reflect.Uint16, reflect.Int16, reflect.Int8, reflect.Uint8:
+		return apiextV1.JSONSchemaProps{Type: "integer", Format: "int32"}, nil
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return apiextV1.JSONSchemaProps{Type: "integer", Format: "int64"}, nil
+	case reflect.Float32, reflect.Float64:
+		return apiextV1.JSONSchemaProps{Type: "number"}, nil
+	case reflect.String:
+		return apiextV1.JSONSchemaProps{Type: "string"}, nil
+	case reflect.Interface:
+		return apiextV1.JSONSchemaProps{XPreserveUnknownFields: boolPtr(true)}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return apiextV1.JSONSchemaProps{Type: "string", Format: "byte"}, nil
+		}
+		items, err := g.schemaOf(t.Elem())
+		if err != nil {
+			return apiextV1.JSONSchemaProps{}, err
+		}
+		return apiextV1.JSONSchemaProps{Type: "array", Items: &apiextV1.JSONSchemaPropsOrArray{Schema: &items}}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return apiextV1.JSONSchemaProps{}, fmt.Errorf("apiextensions: unsupported map key type %s", t.Key())
+		}
+		vals, err := g.schemaOf(t.Elem())
+		if err != nil {
+			return apiextV1.JSONSchemaProps{}, err
+		}
+		return apiextV1.JSONSchemaProps{
+			Type:                 "object",
+			AdditionalProperties: &apiextV1.JSONSchemaPropsOrBool{Allows: true, Schema: &vals},
+		}, nil
+	case reflect.Struct:
+		return g.structSchema(t)
+	}
+	return apiextV1.JSONSchemaProps{}, fmt.Errorf("apiextensions: unsupported type %s", t)
+}
+
+func (g generator) structSchema(t reflect.Type) (apiextV1.JSONSchemaProps, error) {
+	if g.visiting[t] {
+		return apiextV1.JSONSchemaProps{}, fmt.Errorf("apiextensions: recursive type %s cannot be expressed in a structural schema", t)
+	}
+	g.visiting[t] = true
+	defer delete(g.visiting, t)
+
+	schema := apiextV1.JSONSchemaProps{Type: "object", Properties: map[string]apiextV1.JSONSchemaProps{}}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		// embedded structs without a json name, or marked inline, are flattened into the parent
+		inline := strings.Contains(opts, "inline")
+		if inline || (field.Anonymous && name == "") {
+			embedded, err := g.schemaOf(field.Type)
+			if err != nil {
+				return apiextV1.JSONSchemaProps{}, err
+			}
+			for propName, prop := range embedded.Properties {
+				schema.Properties[propName] = prop
+			}
+			schema.Required = append(schema.Required, embedded.Required...)
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		prop, err := g.schemaOf(field.Type)
+		if err != nil {
+			return apiextV1.JSONSchemaProps{}, fmt.Errorf("%s.%s: %w", t.Name(), field.Name, err)
+		}
+		required := !strings.Contains(opts, "omitempty")
+		for _, marker := range g.markers[t.Name()+"."+field.Name] {
+			if err := applyMarker(&prop, &required, marker); err != nil {
+				return apiextV1.JSONSchemaProps{}, fmt.Errorf("%s.%s: %w", t.Name(), field.Name, err)
+			}
+		}
+		if err := applyMarkers(&prop, &required, field.Tag.Get(MarkerTag)); err != nil {
+			return apiextV1.JSONSchemaProps{}, fmt.Errorf("%s.%s: %w", t.Name(), field.Name, err)
+		}
+		schema.Properties[name] = prop
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	if len(schema.Properties) == 0 {
+		schema.Properties = nil
+	}
+	return schema, nil
+}
+
+// applyMarkers applies the comma separated markers of tag
+func applyMarkers(prop *apiextV1.JSONSchemaProps, required *bool, tag string) error {
+	for tag != "" {
+		var marker string
+		if strings.HasPrefix(tag, "validation:Pattern=") {
+			marker, tag = tag, ""
+		} else {
+			marker, tag, _ = strings.Cut(tag, ",")
+		}
+		if err := applyMarker(prop, required, marker); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyMarker(prop *apiextV1.JSONSchemaProps, required *bool, marker string) error {
+	key, val, _ := strings.Cut(strings.TrimSpace(marker), "=")
+
+	var err error
+	switch key {
+	case "":
+	case "optional", "validation:Optional":
+		*required = false
+	case "required", "validation:Required":
+		*required = true
+	case "validation:Minimum":
+		prop.Minimum, err = floatPtr(val)
+	case "validation:Maximum":
+		prop.Maximum, err = floatPtr(val)
+	case "validation:ExclusiveMinimum":
+		prop.ExclusiveMinimum, err = boolValue(val)
+	case "validation:ExclusiveMaximum":
+		prop.ExclusiveMaximum, err = boolValue(val)
+	case "validation:MinLength":
+		prop.MinLength, err = int64Ptr(val)
+	case "validation:MaxLength":
+		prop.MaxLength, err = int64Ptr(val)
+	case "validation:MinItems":
+		prop.MinItems, err = int64Ptr(val)
+	case "validation:MaxItems":
+		prop.MaxItems, err = int64Ptr(val)
+	case "validation:MinProperties":
+		prop.MinProperties, err = int64Ptr(val)
+	case "validation:MaxProperties":
+		prop.MaxProperties, err = int64Ptr(val)
+	case "validation:Format":
+		prop.Format = val
+	case "validation:Pattern":
+		prop.Pattern = val
+	case "validation:Enum":
+		for _, v := range strings.Split(val, ";") {
+			prop.Enum = append(prop.Enum, jsonValue(v))
+		}
+	case "validation:XPreserveUnknownFields":
+		prop.XPreserveUnknownFields = boolPtr(true)
+	case "validation:XIntOrString":
+		prop.XIntOrString = true
+	case "default":
+		def := jsonValue(val)
+		prop.Default = &def
+	case "listType":
+		prop.XListType = &val
+	case "listMapKey":
+		prop.XListMapKeys = append(prop.XListMapKeys, val)
+	case "mapType":
+		prop.XMapType = &val
+	default:
+		return fmt.Errorf("apiextensions: unknown marker %q", key)
+	}
+	if err != nil {
+		return fmt.Errorf("apiextensions: marker %s: %w", key, err)
+	}
+	return nil
+}
+
+// jsonValue returns val as raw JSON when valid, otherwise as a JSON string
+func jsonValue(val string) apiextV1.JSON {
+	if json.Valid([]byte(val)) {
+		return apiextV1.JSON{Raw: []byte(val)}
+	}
+	raw, _ := json.Marshal(val)
+	return apiextV1.JSON{Raw: raw}
+}
+
+func floatPtr(val string) (*float64, error) {
+	f, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func int64Ptr(val string) (*int64, error) {
+	i, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
+
+func boolValue(val string) (bool, error) {
+	if val == "" {
+		return true, nil
+	}
+	return strconv.ParseBool(val)
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,178 @@
+package apiextensions
+
+import (
+	"reflect"
+	"testing"
+
+	apiextV1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+type cronTabSpec struct {
+	Schedule string            `json:"schedule" kubebuilder:"validation:MinLength=1"`
+	Image    string            `json:"image,omitempty" kubebuilder:"validation:Pattern=^[a-z]+(:[a-z0-9,]+)?$"`
+	Replicas *int32            `json:"replicas,omitempty" kubebuilder:"validation:Minimum=1,validation:Maximum=10,default=1"`
+	Policy   string            `json:"policy" kubebuilder:"optional,validation:Enum=Allow;Forbid;Replace,default=Allow"`
+	Tags     []string          `json:"tags,omitempty" kubebuilder:"listType=set,validation:MaxItems=5"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Data     []byte            `json:"data,omitempty"`
+	Ignored  string            `json:"-"`
+	internal string
+}
+
+type cronTabStatus struct {
+	LastRun *metaV1.Time `json:"lastRun,omitempty"`
+}
+
+type cronTab struct {
+	metaV1.TypeMeta   `json:",inline"`
+	metaV1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              cronTabSpec   `json:"spec"`
+	Status            cronTabStatus `json:"status,omitempty"`
+}
+
+type recursive struct {
+	Children []recursive `json:"children"`
+}
+
+func TestSchemaFrom(t *testing.T) {
+	str := apiextV1.JSONSchemaProps{Type: "string"}
+	tests := map[string]struct {
+		obj      any
+		expected apiextV1.JSONSchemaProps
+	}{
+		"scalars": {
+			obj: struct {
+				B bool    `json:"b"`
+				I int     `json:"i"`
+				S int32   `json:"s"`
+				F float64 `json:"f"`
+				N string
+			}{},
+			expected: apiextV1.JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]apiextV1.JSONSchemaProps{
+					"b": {Type: "boolean"},
+					"i": {Type: "integer", Format: "int64"},
+					"s": {Type: "integer", Format: "int32"},
+					"f": {Type: "number"},
+					"N": str,
+				},
+				Required: []string{"b", "i", "s", "f", "N"},
+			},
+		},
+		"special types": {
+			obj: &struct {
+				Port     intstr.IntOrString   `json:"port"`
+				Any      apiextV1.JSON        `json:"any,omitempty"`
+				Duration metaV1.Duration      `json:"duration,omitempty"`
+				Embedded struct{ A string }   `json:"embedded,omitempty"`
+				Memory   *resource.Quantity   `json:"memory,omitempty"`
+				Ports    []intstr.IntOrString `json:"ports,omitempty"`
+			}{},
+			expected: apiextV1.JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]apiextV1.JSONSchemaProps{
+					"port":     {AnyOf: []apiextV1.JSONSchemaProps{{Type: "integer"}, {Type: "string"}}, XIntOrString: true},
+					"any":      {XPreserveUnknownFields: boolPtr(true)},
+					"duration": str,
+					"embedded": {Type: "object", Properties: map[string]apiextV1.JSONSchemaProps{"A": str}, Required: []string{"A"}},
+					"memory": {
+						AnyOf:        []apiextV1.JSONSchemaProps{{Type: "integer"}, {Type: "string"}},
+						Pattern:      `^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$`,
+						XIntOrString: true,
+					},
+					"ports": {
+						Type: "array",
+						Items: &apiextV1.JSONSchemaPropsOrArray{Schema: &apiextV1.JSONSchemaProps{
+							AnyOf: []apiextV1.JSONSchemaProps{{Type: "integer"}, {Type: "string"}}, XIntOrString: true,
+						}},
+					},
+				},
+				Required: []string{"port"},
+			},
+		},
+		"custom resource with markers": {
+			obj: cronTab{},
+			expected: apiextV1.JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]apiextV1.JSONSchemaProps{
+					"apiVersion": str,
+					"kind":       str,
+					"metadata":   {Type: "object"},
+					"spec": {
+						Type: "object",
+						Properties: map[string]apiextV1.JSONSchemaProps{
+							"schedule": {Type: "string", MinLength: int64Value(1)},
+							"image":    {Type: "string", Pattern: "^[a-z]+(:[a-z0-9,]+)?$"},
+							"replicas": {Type: "integer", Format: "int32", Minimum: float64Value(1), Maximum: float64Value(10), Default: &apiextV1.JSON{Raw: []byte("1")}},
+							"policy": {
+								Type:    "string",
+								Enum:    []apiextV1.JSON{{Raw: []byte(`"Allow"`)}, {Raw: []byte(`"Forbid"`)}, {Raw: []byte(`"Replace"`)}},
+								Default: &apiextV1.JSON{Raw: []byte(`"Allow"`)},
+							},
+							"tags": {
+								Type:      "array",
+								Items:     &apiextV1.JSONSchemaPropsOrArray{Schema: &str},
+								XListType: strPtr("set"),
+								MaxItems:  int64Value(5),
+							},
+							"labels": {Type: "object", AdditionalProperties: &apiextV1.JSONSchemaPropsOrBool{Allows: true, Schema: &str}},
+							"data":   {Type: "string", Format: "byte"},
+						},
+						Required: []string{"schedule"},
+					},
+					"status": {
+						Type:       "object",
+						Properties: map[string]apiextV1.JSONSchemaProps{"lastRun": {Type: "string", Format: "date-time"}},
+					},
+				},
+				Required: []string{"spec"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			schema, err := SchemaFrom(test.obj)
+			if err != nil {
+				t.Fatalf("failed to generate schema: %s", err)
+			}
+			if !reflect.DeepEqual(schema, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", schema, test.expected)
+			}
+		})
+	}
+}
+
+func TestSchemaFromInvalid(t *testing.T) {
+	tests := map[string]any{
+		"nil":          nil,
+		"channel":      make(chan int),
+		"int map keys": map[int]string{},
+		"recursive":    recursive{},
+		"unknown marker": struct {
+			A string `kubebuilder:"validation:Minimun=1"`
+		}{},
+		"bad minimum": struct {
+			A int `kubebuilder:"validation:Minimum=one"`
+		}{},
+	}
+	for name, obj := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := SchemaFrom(obj); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
+
+func int64Value(i int64) *int64 {
+	return &i
+}
+
+func float64Value(f float64) *float64 {
+	return &f
+}
@@ -0,0 +1,114 @@
+package apiextensions
+
+import (
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	apiextV1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// VersionBuilder provides a way to build values of type apiextV1.CustomResourceDefinitionVersion
+type VersionBuilder map[string]interface{}
+
+// Version starts a new served API version (i.e. v1beta1) of the resource
+func Version(name string) VersionBuilder {
+	return VersionBuilder{"name": name, "served": true, "storage": false}
+}
+
+// VersionFor starts a new served API version using the OpenAPI schema
+// generated from obj (see SchemaFrom)
+func VersionFor(name string, obj any) (VersionBuilder, error) {
+	schema, err := SchemaFrom(obj)
+	if err != nil {
+		return VersionBuilder{}, err
+	}
+	return Version(name).Schema(schema), nil
+}
+
+// U returns the unstructured value of the builder
+func (b VersionBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b VersionBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b VersionBuilder) T() (apiextV1.CustomResourceDefinitionVersion, error) {
+	var version apiextV1.CustomResourceDefinitionVersion
+	if err := unstruct.FromUnstructured(b, &version); err != nil {
+		return apiextV1.CustomResourceDefinitionVersion{}, err
+	}
+	return version, nil
+}
+
+// Served sets whether the version is served by the API server
+func (b VersionBuilder) Served(served bool) VersionBuilder {
+	b["served"] = served
+	return b
+}
+
+// Storage sets whether the version is used to persist objects
+func (b VersionBuilder) Storage(storage bool) VersionBuilder {
+	b["storage"] = storage
+	return b
+}
+
+// Deprecated marks the version as deprecated with an optional warning
+// returned to API clients
+func (b VersionBuilder) Deprecated(warning string) VersionBuilder {
+	b["deprecated"] = true
+	if warning != "" {
+		b["deprecationWarning"] = warning
+	}
+	return b
+}
+
+// Schema sets the OpenAPI v3 schema used to validate and prune the resource
+func (b VersionBuilder) Schema(schema apiextV1.JSONSchemaProps) VersionBuilder {
+	props, _ := runtime.DefaultUnstructuredConverter.ToUnstructured(&schema)
+	b["schema"] = map[string]interface{}{"openAPIV3Schema": props}
+	return b
+}
+
+// StatusSubresource enables the /status subresource
+func (b VersionBuilder) StatusSubresource() VersionBuilder {
+	b.getSubresources()["status"] = map[string]interface{}{}
+	return b
+}
+
+// ScaleSubresource enables the /scale subresource using the JSON paths
+// of the replica fields and, optionally, of the label selector
+func (b VersionBuilder) ScaleSubresource(specReplicasPath, statusReplicasPath, labelSelectorPath string) VersionBuilder {
+	scale := map[string]interface{}{
+		"specReplicasPath":   specReplicasPath,
+		"statusReplicasPath": statusReplicasPath,
+	}
+	if labelSelectorPath != "" {
+		scale["labelSelectorPath"] = labelSelectorPath
+	}
+	b.getSubresources()["scale"] = scale
+	return b
+}
+
+// AddPrinterColumn adds a column shown by kubectl get, where colType is an
+// OpenAPI type (i.e. string, integer, date) and jsonPath is evaluated against the object
+func (b VersionBuilder) AddPrinterColumn(name, colType, jsonPath string) VersionBuilder {
+	cols, _ := b["additionalPrinterColumns"].([]interface{})
+	b["additionalPrinterColumns"] = append(cols, map[string]interface{}{
+		"name":     name,
+		"type":     colType,
+		"jsonPath": jsonPath,
+	})
+	return b
+}
+
+func (b VersionBuilder) getSubresources() map[string]interface{} {
+	sub, ok := b["subresources"].(map[string]interface{})
+	if !ok {
+		sub = map[string]interface{}{}
+		b["subresources"] = sub
+	}
+	return sub
+}
@@ -0,0 +1,95 @@
+package apiextensions
+
+import (
+	"reflect"
+	"testing"
+
+	apiextV1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestVersionTyped(t *testing.T) {
+	schema := apiextV1.JSONSchemaProps{
+		Type:       "object",
+		Properties: map[string]apiextV1.JSONSchemaProps{"spec": {Type: "object"}},
+	}
+	tests := map[string]struct {
+		builder  VersionBuilder
+		expected apiextV1.CustomResourceDefinitionVersion
+	}{
+		"served only": {
+			builder:  Version("v1"),
+			expected: apiextV1.CustomResourceDefinitionVersion{Name: "v1", Served: true},
+		},
+		"storage with schema": {
+			builder: Version("v1").Storage(true).Schema(schema),
+			expected: apiextV1.CustomResourceDefinitionVersion{
+				Name: "v1", Served: true, Storage: true,
+				Schema: &apiextV1.CustomResourceValidation{OpenAPIV3Schema: &schema},
+			},
+		},
+		"not served deprecated": {
+			builder:  Version("v1alpha1").Served(false).Deprecated(""),
+			expected: apiextV1.CustomResourceDefinitionVersion{Name: "v1alpha1", Deprecated: true},
+		},
+		"subresources and columns": {
+			builder: Version("v1").Storage(true).
+				StatusSubresource().
+				ScaleSubresource(".spec.replicas", ".status.replicas", ".status.selector").
+				AddPrinterColumn("Replicas", "integer", ".spec.replicas").
+				AddPrinterColumn("Age", "date", ".metadata.creationTimestamp"),
+			expected: apiextV1.CustomResourceDefinitionVersion{
+				Name: "v1", Served: true, Storage: true,
+				Subresources: &apiextV1.CustomResourceSubresources{
+					Status: &apiextV1.CustomResourceSubresourceStatus{},
+					Scale: &apiextV1.CustomResourceSubresourceScale{
+						SpecReplicasPath:   ".spec.replicas",
+						StatusReplicasPath: ".status.replicas",
+						LabelSelectorPath:  strPtr(".status.selector"),
+					},
+				},
+				AdditionalPrinterColumns: []apiextV1.CustomResourceColumnDefinition{
+					{Name: "Replicas", Type: "integer", JSONPath: ".spec.replicas"},
+					{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			version, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(version, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", version, test.expected)
+			}
+		})
+	}
+}
+
+func TestVersionFor(t *testing.T) {
+	type spec struct {
+		Schedule string `json:"schedule"`
+	}
+	version, err := VersionFor("v1", spec{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	typed, err := version.T()
+	if err != nil {
+		t.Fatalf("failed to convert to typed value: %s", err)
+	}
+	expected := &apiextV1.JSONSchemaProps{
+		Type:       "object",
+		Properties: map[string]apiextV1.JSONSchemaProps{"schedule": {Type: "string"}},
+		Required:   []string{"schedule"},
+	}
+	if !reflect.DeepEqual(typed.Schema.OpenAPIV3Schema, expected) {
+		t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", typed.Schema.OpenAPIV3Schema, expected)
+	}
+
+	if _, err := VersionFor("v1", make(chan int)); err == nil {
+		t.Error("expecting error, got none")
+	}
+}
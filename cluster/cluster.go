@@ -0,0 +1,54 @@
+// Package cluster contains helpers to group the cluster infrastructure
+// objects (priority classes, runtime classes and leases) used by workloads
+package cluster
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vladimirvivien/kob/lease"
+	"github.com/vladimirvivien/kob/pod"
+	"github.com/vladimirvivien/kob/priorityclass"
+	"github.com/vladimirvivien/kob/runtimeclass"
+)
+
+// builtinPriorityClasses are created by the API server and need not be part of a bundle
+var builtinPriorityClasses = map[string]bool{
+	"system-cluster-critical": true,
+	"system-node-critical":    true,
+}
+
+// Bundle holds the builders of cluster infrastructure objects
+type Bundle struct {
+	PriorityClasses []priorityclass.Builder
+	RuntimeClasses  []runtimeclass.Builder
+	Leases          []lease.Builder
+}
+
+// Check returns an error for each pod spec referencing, by PriorityClassName
+// or RuntimeClassName, a class not defined in the bundle. The built-in
+// system-cluster-critical and system-node-critical priority classes are always defined.
+func (b Bundle) Check(specs ...pod.SpecBuilder) error {
+	priorities := map[string]bool{}
+	for name := range builtinPriorityClasses {
+		priorities[name] = true
+	}
+	for _, class := range b.PriorityClasses {
+		priorities[class.Name()] = true
+	}
+	runtimes := map[string]bool{}
+	for _, class := range b.RuntimeClasses {
+		runtimes[class.Name()] = true
+	}
+
+	var errs []error
+	for _, spec := range specs {
+		if name, _ := spec["priorityClassName"].(string); name != "" && !priorities[name] {
+			errs = append(errs, fmt.Errorf("cluster: pod spec references undefined priority class %q", name))
+		}
+		if name, _ := spec["runtimeClassName"].(string); name != "" && !runtimes[name] {
+			errs = append(errs, fmt.Errorf("cluster: pod spec references undefined runtime class %q", name))
+		}
+	}
+	return errors.Join(errs...)
+}
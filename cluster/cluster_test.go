@@ -0,0 +1,53 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/vladimirvivien/kob/container"
+	"github.com/vladimirvivien/kob/objmeta"
+	"github.com/vladimirvivien/kob/pod"
+	"github.com/vladimirvivien/kob/priorityclass"
+	"github.com/vladimirvivien/kob/runtimeclass"
+)
+
+func TestBundleCheck(t *testing.T) {
+	bundle := Bundle{
+		PriorityClasses: []priorityclass.Builder{priorityclass.Object(objmeta.Name("high-priority"), 1000)},
+		RuntimeClasses:  []runtimeclass.Builder{runtimeclass.Object(objmeta.Name("gvisor"), "runsc")},
+	}
+	tests := map[string]struct {
+		specs []pod.SpecBuilder
+		valid bool
+	}{
+		"no references": {
+			specs: []pod.SpecBuilder{pod.Spec(container.Name("web"))},
+			valid: true,
+		},
+		"defined classes": {
+			specs: []pod.SpecBuilder{pod.Spec(container.Name("web")).PriorityClassName("high-priority").RuntimeClassName("gvisor")},
+			valid: true,
+		},
+		"builtin priority class": {
+			specs: []pod.SpecBuilder{pod.Spec(container.Name("agent")).PriorityClassName("system-node-critical")},
+			valid: true,
+		},
+		"undefined priority class": {
+			specs: []pod.SpecBuilder{pod.Spec(container.Name("web")), pod.Spec(container.Name("batch")).PriorityClassName("low-priority")},
+		},
+		"undefined runtime class": {
+			specs: []pod.SpecBuilder{pod.Spec(container.Name("web")).RuntimeClassName("kata")},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := bundle.Check(test.specs...)
+			if test.valid && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if !test.valid && err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/vladimirvivien/kob/internal/scheme"
+)
+
+// kind of a builder type
+type kind int
+
+const (
+	// generated builders are declared by the generator, as struct builders
+	generated kind = iota
+	// structBuilder types wrap the typed value in their obj field (i.e.
+	// container.Builder), and may record the first error building it in their
+	// err field
+	structBuilder
+	// mapBuilder types are unstructured maps (i.e. pod.SpecBuilder)
+	mapBuilder
+)
+
+// decls are the declarations of a package written by hand, which the
+// generated code complements
+type decls struct {
+	pkg     string
+	types   map[string]kind
+	funcs   map[string]bool
+	methods map[string]map[string]bool   // receiver type -> method names
+	setters map[string]map[string]bool   // receiver type -> fields set by hand
+	results map[string]map[string]string // receiver type -> method name -> result type
+	errs    map[string]bool              // struct types with an err field
+}
+
+// has reports whether the method of the builder type, or the function when
+// recv is empty, is declared
+func (d decls) has(recv, name string) bool {
+	if recv == "" {
+		return d.funcs[name]
+	}
+	return d.methods[recv][name]
+}
+
+// sets reports whether a method of the builder type declared by hand, whatever
+// its name, sets the field of the built object to one of its parameters
+// (i.e. Commands sets the Command field of the container)
+func (d decls) sets(recv, field string) bool {
+	return d.setters[recv][field]
+}
+
+// parseDecls parses the declarations of the package in dir, ignoring tests
+// and the file written by the generator
+func parseDecls(dir, output string) (decls, error) {
+	d := decls{
+		types:   map[string]kind{},
+		funcs:   map[string]bool{},
+		methods: map[string]map[string]bool{},
+		setters: map[string]map[string]bool{},
+		results: map[string]map[string]string{},
+		errs:    map[string]bool{},
+	}
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info fs.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go") && info.Name() != filepath.Base(output)
+	}, 0)
+	if err != nil {
+		return decls{}, err
+	}
+	if len(pkgs) != 1 {
+		return decls{}, fmt.Errorf("kob-builder-gen: expecting one package in %s, found %d", dir, len(pkgs))
+	}
+	for name, pkg := range pkgs {
+		d.pkg = name
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				d.add(decl)
+			}
+		}
+	}
+	return d, nil
+}
+
+func (d decls) add(decl ast.Decl) {
+	switch decl := decl.(type) {
+	case *ast.FuncDecl:
+		if decl.Recv == nil {
+			d.funcs[decl.Name.Name] = true
+			return
+		}
+		recv := decl.Recv.List[0].Type
+		if star, ok := recv.(*ast.StarExpr); ok {
+			recv = star.X
+		}
+		if ident, ok := recv.(*ast.Ident); ok {
+			if d.methods[ident.Name] == nil {
+				d.methods[ident.Name] = map[string]bool{}
+			}
+			d.methods[ident.Name][decl.Name.Name] = true
+			d.addSetter(ident.Name, decl)
+			d.addResult(ident.Name, decl)
+		}
+	case *ast.GenDecl:
+		for _, spec := range decl.Specs {
+			spec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			switch typ := spec.Type.(type) {
+			case *ast.MapType:
+				d.types[spec.Name.Name] = mapBuilder
+			case *ast.StructType:
+				for _, field := range typ.Fields.List {
+					for _, name := range field.Names {
+						switch name.Name {
+						case "obj":
+							d.types[spec.Name.Name] = structBuilder
+						case "err":
+							d.errs[spec.Name.Name] = true
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// addResult records the type of the method's result, when it returns a
+// single value of a type of the package (i.e. a setter returning its builder)
+func (d decls) addResult(recv string, decl *ast.FuncDecl) {
+	results := decl.Type.Results
+	if results == nil || len(results.List) != 1 || len(results.List[0].Names) > 1 {
+		return
+	}
+	if ident, ok := results.List[0].Type.(*ast.Ident); ok {
+		if d.results[recv] == nil {
+			d.results[recv] = map[string]string{}
+		}
+		d.results[recv][decl.Name.Name] = ident.Name
+	}
+}
+
+// addSetter records the field of the built object the method of the struct
+// builder sets to one of its parameters, with b.obj.Field = param
+func (d decls) addSetter(recv string, decl *ast.FuncDecl) {
+	if len(decl.Recv.List[0].Names) == 0 || decl.Body == nil {
+		return
+	}
+	params := map[string]bool{}
+	for _, field := range decl.Type.Params.List {
+		for _, name := range field.Names {
+			params[name.Name] = true
+		}
+	}
+	for _, stmt := range decl.Body.List {
+		assign, ok := stmt.(*ast.AssignStmt)
+		if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+			continue
+		}
+		val, ok := assign.Rhs[0].(*ast.Ident)
+		if !ok || !params[val.Name] {
+			continue
+		}
+		field, ok := assign.Lhs[0].(*ast.SelectorExpr)
+		if !ok {
+			continue
+		}
+		obj, ok := field.X.(*ast.SelectorExpr)
+		if !ok || obj.Sel.Name != "obj" {
+			continue
+		}
+		if x, ok := obj.X.(*ast.Ident); !ok || x.Name != decl.Recv.List[0].Names[0].Name {
+			continue
+		}
+		if d.setters[recv] == nil {
+			d.setters[recv] = map[string]bool{}
+		}
+		d.setters[recv][field.Sel.Name] = true
+	}
+}
+
+// apiTypes returns the struct types of the API objects known to kob, and of
+// their fields, by package path and name (i.e. k8s.io/api/core/v1.Container)
+func apiTypes() map[string]reflect.Type {
+	types := map[string]reflect.Type{}
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Map:
+			walk(t.Elem())
+			return
+		case reflect.Struct:
+		default:
+			return
+		}
+		key := t.PkgPath() + "." + t.Name()
+		if _, seen := types[key]; seen || t.Name() == "" {
+			return
+		}
+		types[key] = t
+		for i := 0; i < t.NumField(); i++ {
+			walk(t.Field(i).Type)
+		}
+	}
+	for _, t := range scheme.Scheme.AllKnownTypes() {
+		walk(t)
+	}
+	return types
+}
@@ -0,0 +1,445 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/vladimirvivien/kob/internal/gosrc"
+)
+
+const unstructPath = "github.com/vladimirvivien/kob/internal/unstruct"
+
+// reserved are the methods of builders, which are not generated as setters
+var reserved = map[string]bool{"U": true, "T": true, "setField": true, "addField": true, "mergeField": true}
+
+// skipped are the fields of API objects built by other builders (i.e. objmeta)
+// or not built at all
+var skipped = map[string]bool{"TypeMeta": true, "ObjectMeta": true, "ListMeta": true, "Status": true}
+
+// target is a builder the generator writes setters for
+type target struct {
+	builder string       // name of the builder type
+	typ     reflect.Type // type of the values built
+	kind    kind
+}
+
+// field is a field of a built type, possibly promoted from an embedded struct
+type field struct {
+	name string // Go field name
+	key  string // JSON key
+	typ  reflect.Type
+}
+
+type generator struct {
+	decls   decls
+	imports *gosrc.Imports
+	targets map[reflect.Type]target
+	body    bytes.Buffer
+
+	// methods generated by builder type, and helpers used by map builders
+	methods map[string]map[string]bool
+	helpers map[string]map[string]bool
+	// setters written by builder type, in order
+	written map[string][]setter
+}
+
+// setter is a generated setter, which may be forwarded to
+type setter struct {
+	name, doc, params string
+}
+
+// Generate returns the source of the builders of the specs, each naming an
+// API type of apiPkg and its builder type (i.e. Container=Builder), and of the
+// forwarding setters of the forwards, each naming a builder declared by hand
+// and the builder of one of its fields (i.e. Builder=SpecBuilder). Setters are
+// generated for the fields of the type, except those whose setter is declared
+// by hand in d, under the name of the field or another name (i.e. Commands for
+// the Command field), which keep their hand-written behavior.
+func Generate(d decls, apiPkg string, specs, forwards []string) ([]byte, error) {
+	types := apiTypes()
+	var reservedNames []string
+	for name := range d.funcs {
+		reservedNames = append(reservedNames, name)
+	}
+	for name := range d.types {
+		reservedNames = append(reservedNames, name)
+	}
+	g := &generator{
+		decls:   d,
+		imports: gosrc.NewImports(reservedNames...),
+		targets: map[reflect.Type]target{},
+		methods: map[string]map[string]bool{},
+		helpers: map[string]map[string]bool{},
+		written: map[string][]setter{},
+	}
+
+	var targets []target
+	for _, spec := range specs {
+		typeName, builder, ok := strings.Cut(spec, "=")
+		if !ok || typeName == "" || builder == "" {
+			return nil, fmt.Errorf("kob-builder-gen: invalid builder %q, expecting Type=Builder", spec)
+		}
+		typ, ok := types[apiPkg+"."+typeName]
+		if !ok {
+			return nil, fmt.Errorf("kob-builder-gen: unknown type %s.%s", apiPkg, typeName)
+		}
+		t := target{builder: builder, typ: typ, kind: generated}
+		if k, declared := d.types[builder]; declared {
+			t.kind = k
+		} else if d.has("", builder) {
+			return nil, fmt.Errorf("kob-builder-gen: %s is declared and is not a builder type", builder)
+		}
+		g.targets[typ] = t
+		targets = append(targets, t)
+	}
+	for _, t := range targets {
+		g.builder(t)
+	}
+	for _, fwd := range forwards {
+		if err := g.forward(fwd); err != nil {
+			return nil, err
+		}
+	}
+	for _, t := range targets {
+		g.mapHelpers(t)
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by kob-builder-gen. DO NOT EDIT.\n\npackage %s\n\n", d.pkg)
+	if decl := g.imports.Decl(); decl != "" {
+		fmt.Fprintf(&src, "%s\n", decl)
+	}
+	src.Write(g.body.Bytes())
+	out, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("kob-builder-gen: formatting generated code: %w\n%s", err, src.Bytes())
+	}
+	return out, nil
+}
+
+// method writes the method of the builder, unless it is declared by hand. As
+// setters are named after their field, the setter of a field set by a method
+// declared by hand under another name is not written either.
+func (g *generator) method(t target, name, doc, params, body string) {
+	if reserved[name] || g.decls.has(t.builder, name) || g.decls.sets(t.builder, name) || g.methods[t.builder][name] {
+		return
+	}
+	if g.methods[t.builder] == nil {
+		g.methods[t.builder] = map[string]bool{}
+	}
+	g.methods[t.builder][name] = true
+	g.written[t.builder] = append(g.written[t.builder], setter{name: name, doc: doc, params: params})
+	fmt.Fprintf(&g.body, "// %s %s\nfunc (b %s) %s(%s) %s {\n%s\n}\n\n", name, doc, t.builder, name, params, t.builder, body)
+}
+
+// forward writes the setters of the builder declared by hand, forwarding to
+// the generated setters of the builder of one of its fields (i.e. Builder=SpecBuilder).
+// The builder declares the method applying a setter to the field, named after
+// the field's builder (i.e. withSpec(func(SpecBuilder) SpecBuilder) Builder),
+// and forwards the setters of the field's builder declared by hand itself.
+func (g *generator) forward(spec string) error {
+	builder, to, ok := strings.Cut(spec, "=")
+	if !ok || builder == "" || to == "" {
+		return fmt.Errorf("kob-builder-gen: invalid forward %q, expecting Builder=FieldBuilder", spec)
+	}
+	k, declared := g.decls.types[builder]
+	if !declared {
+		return fmt.Errorf("kob-builder-gen: forwarding builder %s is not declared", builder)
+	}
+	field := strings.TrimSuffix(to, "Builder")
+	with := "with" + field
+	if !g.decls.has(builder, with) {
+		return fmt.Errorf("kob-builder-gen: %s does not declare %s, applying the setters of %s", builder, with, to)
+	}
+
+	var missing []string
+	for name, result := range g.decls.results[to] {
+		if result == to && isExported(name) && !g.decls.has(builder, name) {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("kob-builder-gen: %s does not forward the setters of %s declared by hand: %s", builder, to, strings.Join(missing, ", "))
+	}
+
+	t := target{builder: builder, kind: k}
+	arg := strings.ToLower(field)
+	for _, s := range g.written[to] {
+		param, typ, _ := strings.Cut(s.params, " ")
+		if strings.HasPrefix(typ, "...") {
+			param += "..."
+		}
+		doc := strings.Replace(s.doc, " field", " field of the "+arg, 1)
+		g.method(t, s.name, doc, s.params,
+			"return b."+with+"(func("+arg+" "+to+") "+to+" { return "+arg+"."+s.name+"("+param+") })")
+	}
+	return nil
+}
+
+func isExported(name string) bool {
+	return name != "" && strings.ToUpper(name[:1]) == name[:1]
+}
+
+// builder writes the declaration, when not declared by hand, and the setters of the builder
+func (g *generator) builder(t target) {
+	typ := g.imports.TypeName(t.typ)
+	if t.kind == generated {
+		from := strings.TrimSuffix(t.builder, "Builder") + "From"
+		fmt.Fprintf(&g.body, "// %s provides a way to build values of type %s\ntype %s struct {\nobj %s\n}\n\n", t.builder, typ, t.builder, typ)
+		if !g.decls.has("", from) {
+			fmt.Fprintf(&g.body, "// %s creates a new builder using the provided object\nfunc %s(obj %s) %s {\nreturn %s{obj: obj}\n}\n\n", from, from, typ, t.builder, t.builder)
+		}
+		fmt.Fprintf(&g.body, `// U returns an unstructured value of builder's object
+func (b %s) U() (map[string]any, error) {
+u, err := %s.DefaultUnstructuredConverter.ToUnstructured(&b.obj)
+if err != nil {
+return nil, err
+}
+return %s.Prune(u), nil
+}
+
+`, t.builder, g.imports.Name("k8s.io/apimachinery/pkg/runtime"), g.imports.Name(unstructPath))
+		fmt.Fprintf(&g.body, "// T returns a typed value of builder's object\nfunc (b %s) T() %s {\nreturn b.obj\n}\n\n", t.builder, typ)
+	}
+	for _, f := range fields(t.typ) {
+		g.setters(t, f)
+	}
+}
+
+// fields returns the fields of the struct type t with a setter, including the
+// fields promoted from embedded structs
+func fields(t reflect.Type) []field {
+	var fs []field
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() || skipped[f.Name] || strings.HasPrefix(f.Name, "Deprecated") {
+			continue
+		}
+		key, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			fs = append(fs, fields(f.Type)...)
+			continue
+		}
+		if key == "-" || key == "" {
+			continue
+		}
+		fs = append(fs, field{name: f.Name, key: key, typ: f.Type})
+	}
+	return fs
+}
+
+// setters writes the setters of the field: a setter for values, an acceptor
+// for fields with a builder, an Add setter for slices and a Merge setter for maps
+func (g *generator) setters(t target, f field) {
+	typ := f.typ
+	elem := typ
+	if typ.Kind() == reflect.Pointer || typ.Kind() == reflect.Slice {
+		elem = typ.Elem()
+	}
+	nested, hasBuilder := g.targets[elem]
+	if elem.Kind() == reflect.Pointer {
+		nested, hasBuilder = g.targets[elem.Elem()]
+	}
+	// the error building the value of a map builder is recorded in the err
+	// field of struct builders, without one the field only gets value setters
+	if hasBuilder && nested.kind == mapBuilder && t.kind != mapBuilder && !g.decls.errs[t.builder] {
+		hasBuilder = false
+	}
+
+	switch {
+	case typ.Kind() == reflect.Slice && typ.Elem().Kind() != reflect.Uint8:
+		g.sliceSetters(t, f, nested, hasBuilder)
+	case typ.Kind() == reflect.Map:
+		g.mapSetters(t, f)
+	case hasBuilder:
+		body := g.set(t, f, "v")
+		if t.kind != mapBuilder {
+			stmt, val := g.built(nested, typ.Kind() == reflect.Pointer)
+			body = stmt + g.set(t, f, val)
+		}
+		g.method(t, f.name, "sets the "+f.key+" field to the value built by v", "v "+nested.builder, body)
+	case typ.Kind() == reflect.Pointer:
+		val := "&v"
+		if t.kind == mapBuilder {
+			val = "v"
+		}
+		g.method(t, f.name, "sets the "+f.key+" field", "v "+g.imports.TypeName(typ.Elem()), g.set(t, f, val))
+	default:
+		g.method(t, f.name, "sets the "+f.key+" field", "v "+g.imports.TypeName(typ), g.set(t, f, "v"))
+	}
+}
+
+func (g *generator) sliceSetters(t target, f field, nested target, hasBuilder bool) {
+	elemType := f.typ.Elem()
+	add := "Add" + singular(f.name)
+	if !hasBuilder {
+		param := g.imports.TypeName(elemType)
+		if t.kind == mapBuilder {
+			g.method(t, f.name, "sets the "+f.key+" field", "vals ..."+param, "return b.setField("+quote(f.key)+", vals)")
+			g.method(t, add, "adds v to the "+f.key+" field", "v "+param, g.helper(t, "addField")+"return b.addField("+quote(f.key)+", v)")
+			return
+		}
+		g.method(t, f.name, "sets the "+f.key+" field", "vals ..."+param, "b.obj."+f.name+" = vals\nreturn b")
+		g.method(t, add, "adds v to the "+f.key+" field", "v "+param, "b.obj."+f.name+" = append(b.obj."+f.name+", v)\nreturn b")
+		return
+	}
+
+	doc := "sets the " + f.key + " field to the values built by vals"
+	if t.kind == mapBuilder {
+		g.method(t, f.name, doc, "vals ..."+nested.builder, g.helper(t, "addField")+
+			"b["+quote(f.key)+"] = []interface{}{}\nfor _, v := range vals {\nb.addField("+quote(f.key)+", v)\n}\nreturn b")
+		g.method(t, add, "adds the value built by v to the "+f.key+" field", "v "+nested.builder, "return b.addField("+quote(f.key)+", v)")
+		return
+	}
+	stmt, val := g.built(nested, elemType.Kind() == reflect.Pointer)
+	g.method(t, f.name, doc, "vals ..."+nested.builder,
+		"b.obj."+f.name+" = make("+g.imports.TypeName(f.typ)+", 0, len(vals))\nfor _, v := range vals {\n"+
+			stmt+"b.obj."+f.name+" = append(b.obj."+f.name+", "+val+")\n}\nreturn b")
+	g.method(t, add, "adds the value built by v to the "+f.key+" field", "v "+nested.builder,
+		stmt+"b.obj."+f.name+" = append(b.obj."+f.name+", "+val+")\nreturn b")
+}
+
+func (g *generator) mapSetters(t target, f field) {
+	typ := g.imports.TypeName(f.typ)
+	merge := "Merge" + f.name
+	if t.kind == mapBuilder {
+		g.method(t, f.name, "sets the "+f.key+" field", "m "+typ, "return b.setField("+quote(f.key)+", m)")
+		g.method(t, merge, "adds the entries of m to the "+f.key+" field, replacing existing keys", "m "+typ,
+			g.helper(t, "mergeField")+"return b.mergeField("+quote(f.key)+", m)")
+		return
+	}
+	g.method(t, f.name, "sets the "+f.key+" field", "m "+typ,
+		"b.obj."+f.name+" = make("+typ+", len(m))\nfor k, v := range m {\nb.obj."+f.name+"[k] = v\n}\nreturn b")
+	g.method(t, merge, "adds the entries of m to the "+f.key+" field, replacing existing keys", "m "+typ,
+		"merged := make("+typ+", len(b.obj."+f.name+")+len(m))\nfor k, v := range b.obj."+f.name+" {\nmerged[k] = v\n}\n"+
+			"for k, v := range m {\nmerged[k] = v\n}\nb.obj."+f.name+" = merged\nreturn b")
+}
+
+// set returns the statements setting the field to val
+func (g *generator) set(t target, f field, val string) string {
+	if t.kind == mapBuilder {
+		return "return b.setField(" + quote(f.key) + ", " + val + ")"
+	}
+	return "b.obj." + f.name + " = " + val + "\nreturn b"
+}
+
+// built returns the statements building the value of builder v, of the
+// nested target, and the expression of the value, or of its address. The
+// error building the value of a map builder is recorded in the err field of
+// the builder, unless an error is already recorded.
+func (g *generator) built(nested target, pointer bool) (string, string) {
+	const record = "val, err := v.T()\nif err != nil {\nif b.err == nil {\nb.err = err\n}\nreturn b\n}\n"
+	switch {
+	case nested.kind == mapBuilder && pointer:
+		return record, "&val"
+	case nested.kind == mapBuilder:
+		return record, "val"
+	case pointer:
+		return "val := v.T()\n", "&val"
+	}
+	return "", "v.T()"
+}
+
+// helper records the map builder helper as used, it returns no code
+func (g *generator) helper(t target, name string) string {
+	if g.helpers[t.builder] == nil {
+		g.helpers[t.builder] = map[string]bool{}
+	}
+	g.helpers[t.builder][name] = true
+	return ""
+}
+
+// mapHelpers writes the helpers used by the setters of map builders, which
+// store values as unstructured values
+func (g *generator) mapHelpers(t target) {
+	if t.kind != mapBuilder || len(g.methods[t.builder]) == 0 {
+		return
+	}
+	unstruct := g.imports.Name(unstructPath)
+	fmt.Fprintf(&g.body, `// setField sets the key to the unstructured value of val, an error
+// converting val is recorded and returned by T
+func (b %s) setField(key string, val any) %s {
+u, err := %s.JSONValue(val)
+if err != nil {
+%s.SetErr(b, err)
+return b
+}
+b[key] = u
+return b
+}
+
+`, t.builder, t.builder, unstruct, unstruct)
+	if g.helpers[t.builder]["addField"] {
+		fmt.Fprintf(&g.body, `// addField appends the unstructured value of val to the slice at key, an
+// error converting val is recorded and returned by T
+func (b %s) addField(key string, val any) %s {
+u, err := %s.JSONValue(val)
+if err != nil {
+%s.SetErr(b, err)
+return b
+}
+slice, _ := b[key].([]interface{})
+b[key] = append(slice, u)
+return b
+}
+
+`, t.builder, t.builder, unstruct, unstruct)
+	}
+	if g.helpers[t.builder]["mergeField"] {
+		fmt.Fprintf(&g.body, `// mergeField adds the entries of the unstructured value of val to the map at
+// key, an error converting val is recorded and returned by T
+func (b %s) mergeField(key string, val any) %s {
+u, err := %s.JSONValue(val)
+if err != nil {
+%s.SetErr(b, err)
+return b
+}
+merged := map[string]interface{}{}
+if m, ok := b[key].(map[string]interface{}); ok {
+for k, v := range m {
+merged[k] = v
+}
+}
+if m, ok := u.(map[string]interface{}); ok {
+for k, v := range m {
+merged[k] = v
+}
+}
+b[key] = merged
+return b
+}
+
+`, t.builder, t.builder, unstruct, unstruct)
+	}
+}
+
+// irregular are the plural suffixes of field names not made by adding s
+var irregular = map[string]string{"Aliases": "Alias", "Statuses": "Status"}
+
+// singular returns the singular of the plural field name (i.e. Tolerations is Toleration)
+func singular(name string) string {
+	for plural, sing := range irregular {
+		if strings.HasSuffix(name, plural) {
+			return strings.TrimSuffix(name, plural) + sing
+		}
+	}
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "sses"), strings.HasSuffix(name, "shes"),
+		strings.HasSuffix(name, "ches"), strings.HasSuffix(name, "xes"):
+		return strings.TrimSuffix(name, "es")
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss"):
+		return strings.TrimSuffix(name, "s")
+	}
+	return name
+}
+
+func quote(s string) string {
+	return `"` + s + `"`
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	d, err := parseDecls(filepath.Join("testdata", "builders"), "zz_generated.builders.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := Generate(d, "k8s.io/api/core/v1", []string{"Container=Builder", "Probe=ProbeBuilder", "PodSpec=SpecBuilder"}, []string{"PodBuilder=SpecBuilder"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		code     string
+		expected bool
+	}{
+		"package":               {code: "package builders\n", expected: true},
+		"struct setter":         {code: "func (b Builder) ImagePullPolicy(v coreV1.PullPolicy) Builder {\n\tb.obj.ImagePullPolicy = v\n\treturn b\n}", expected: true},
+		"hand-written method":   {code: "func (b Builder) Image(", expected: false},
+		"field set by hand":     {code: "func (b Builder) Command(", expected: false},
+		"add setter":            {code: "func (b Builder) AddCommand(v string) Builder {\n\tb.obj.Command = append(b.obj.Command, v)", expected: true},
+		"builder acceptor":      {code: "func (b Builder) LivenessProbe(v ProbeBuilder) Builder {\n\tval := v.T()\n\tb.obj.LivenessProbe = &val", expected: true},
+		"generated builder":     {code: "type ProbeBuilder struct {\n\tobj coreV1.Probe\n}", expected: true},
+		"generated constructor": {code: "func ProbeFrom(obj coreV1.Probe) ProbeBuilder {", expected: true},
+		"promoted field":        {code: "func (b ProbeBuilder) HTTPGet(v coreV1.HTTPGetAction) ProbeBuilder {\n\tb.obj.HTTPGet = &v", expected: true},
+		"map setter":            {code: "func (b SpecBuilder) DNSPolicy(v coreV1.DNSPolicy) SpecBuilder {\n\treturn b.setField(\"dnsPolicy\", v)", expected: true},
+		"map builder slices":    {code: "func (b SpecBuilder) Containers(vals ...Builder) SpecBuilder {", expected: true},
+		"map merge setter":      {code: "func (b SpecBuilder) MergeNodeSelector(m map[string]string) SpecBuilder {\n\treturn b.mergeField(\"nodeSelector\", m)", expected: true},
+		"map helpers":           {code: "func (b SpecBuilder) addField(key string, val any) SpecBuilder {", expected: true},
+		"map helper errors":     {code: "u, err := unstruct.JSONValue(val)\n\tif err != nil {\n\t\tunstruct.SetErr(b, err)", expected: true},
+		"forwarding setter":     {code: "func (b PodBuilder) DNSPolicy(v coreV1.DNSPolicy) PodBuilder {\n\treturn b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.DNSPolicy(v) })", expected: true},
+		"forwarding variadic":   {code: "return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.Containers(vals...) })", expected: true},
+		"forwarding doc":        {code: "// DNSPolicy sets the dnsPolicy field of the spec\n", expected: true},
+		"forwarded by hand":     {code: "func (b PodBuilder) Hostname(", expected: false},
+		"deprecated field":      {code: "DeprecatedServiceAccount", expected: false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if strings.Contains(string(src), test.code) != test.expected {
+				t.Errorf("expecting generated code to contain %q: %t\n\n%s", test.code, test.expected, src)
+			}
+		})
+	}
+}
+
+func TestGenerateNestedMapBuilder(t *testing.T) {
+	d, err := parseDecls(filepath.Join("testdata", "builders"), "zz_generated.builders.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := Generate(d, "k8s.io/api/core/v1", []string{"Probe=ProbeBuilder", "LifecycleHandler=HandlerBuilder", "ExecAction=ExecBuilder"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		code     string
+		expected bool
+	}{
+		"error recorded":      {code: "func (b HandlerBuilder) Exec(v ExecBuilder) HandlerBuilder {\n\tval, err := v.T()\n\tif err != nil {\n\t\tif b.err == nil {\n\t\t\tb.err = err", expected: true},
+		"error dropped":       {code: "_ := v.T()", expected: false},
+		"value setter":        {code: "func (b ProbeBuilder) Exec(v coreV1.ExecAction) ProbeBuilder {", expected: true},
+		"no builder acceptor": {code: "func (b ProbeBuilder) Exec(v ExecBuilder)", expected: false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if strings.Contains(string(src), test.code) != test.expected {
+				t.Errorf("expecting generated code to contain %q: %t\n\n%s", test.code, test.expected, src)
+			}
+		})
+	}
+}
+
+func TestGenerateInvalid(t *testing.T) {
+	d, err := parseDecls(filepath.Join("testdata", "builders"), "zz_generated.builders.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]struct {
+		specs    []string
+		forwards []string
+	}{
+		"missing builder":            {specs: []string{"Container"}},
+		"unknown type":               {specs: []string{"Widget=WidgetBuilder"}},
+		"declared builder":           {specs: []string{"Container=NotABuilder"}},
+		"invalid forward":            {specs: []string{"PodSpec=SpecBuilder"}, forwards: []string{"PodBuilder"}},
+		"undeclared forwarding":      {specs: []string{"PodSpec=SpecBuilder"}, forwards: []string{"Pod=SpecBuilder"}},
+		"missing apply method":       {specs: []string{"Container=Builder", "PodSpec=SpecBuilder"}, forwards: []string{"Builder=SpecBuilder"}},
+		"hand-written not forwarded": {specs: []string{"PodSpec=SpecBuilder"}, forwards: []string{"TemplateBuilder=SpecBuilder"}},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Generate(d, "k8s.io/api/core/v1", test.specs, test.forwards); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
+
+func TestSingular(t *testing.T) {
+	tests := map[string]string{
+		"Tolerations":      "Toleration",
+		"Args":             "Arg",
+		"Command":          "Command",
+		"HostAliases":      "HostAlias",
+		"Addresses":        "Address",
+		"Capabilities":     "Capability",
+		"ImagePullSecrets": "ImagePullSecret",
+	}
+	for plural, expected := range tests {
+		if got := singular(plural); got != expected {
+			t.Errorf("singular of %s: expected %s, got %s", plural, expected, got)
+		}
+	}
+}
+
+// TestGeneratedUpToDate checks the generated builders of the packages of kob
+// match their go:generate directive
+func TestGeneratedUpToDate(t *testing.T) {
+	for _, dir := range []string{"container", "pod"} {
+		t.Run(dir, func(t *testing.T) {
+			dir := filepath.Join("..", "..", dir)
+			apiPkg, out, specs, forwards := directive(t, dir)
+			d, err := parseDecls(dir, out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			src, err := Generate(d, apiPkg, specs, forwards)
+			if err != nil {
+				t.Fatal(err)
+			}
+			current, err := os.ReadFile(filepath.Join(dir, out))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(src, current) {
+				t.Errorf("%s is out of date, run go generate ./%s", out, filepath.Base(dir))
+			}
+		})
+	}
+}
+
+// directive returns the arguments of the kob-builder-gen go:generate directive of the package in dir
+func directive(t *testing.T, dir string) (string, string, []string, []string) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			_, args, ok := strings.Cut(scanner.Text(), "//go:generate go run ../cmd/kob-builder-gen ")
+			if !ok {
+				continue
+			}
+			flags := flag.NewFlagSet("kob-builder-gen", flag.ContinueOnError)
+			apiPkg := flags.String("pkg", "k8s.io/api/core/v1", "")
+			out := flags.String("o", "zz_generated.builders.go", "")
+			forward := flags.String("forward", "", "")
+			if err := flags.Parse(strings.Fields(args)); err != nil {
+				t.Fatal(err)
+			}
+			f.Close()
+			return *apiPkg, *out, flags.Args(), forwards(*forward)
+		}
+		f.Close()
+	}
+	t.Fatalf("no kob-builder-gen directive in %s", dir)
+	return "", "", nil, nil
+}
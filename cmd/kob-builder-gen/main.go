@@ -0,0 +1,69 @@
+// Command kob-builder-gen generates the setters of kob builders from the
+// Kubernetes API types, by reflection. It is run with go generate in the
+// package of the builders:
+//
+//	//go:generate go run ../cmd/kob-builder-gen -pkg k8s.io/api/core/v1 Container=Builder Probe=ProbeBuilder
+//
+// Each argument names an API type of the package and its builder type. For
+// each field of the type, the builder gets a setter named after the field, an
+// Add setter for slices (i.e. AddToleration), a Merge setter for maps and,
+// for fields whose type has a builder in the arguments, an acceptor of that
+// builder (i.e. LivenessProbe(ProbeBuilder)).
+//
+// Builder types declared by hand are either structs storing the typed value in
+// their obj field (i.e. container.Builder) or unstructured maps (i.e.
+// pod.SpecBuilder), other builder types are declared by the generator. A
+// struct builder accepts a map builder, whose T method may fail, only when it
+// declares an err field recording the error; otherwise the field gets value
+// setters.
+// Methods declared by hand are not generated, so that convenience methods
+// with the name of a field live alongside the generated setters. Neither is
+// the setter of a field already set by a method declared by hand under
+// another name (i.e. b.obj.Command = cmds in Commands), so that the builder
+// has a single setter for each field.
+//
+// With -forward Builder=SpecBuilder, Builder, declared by hand, gets a setter
+// forwarding to each generated setter of SpecBuilder, the builder of one of
+// its fields, through its withSpec(func(SpecBuilder) SpecBuilder) method (i.e.
+// pod.Builder forwards to the setters of its spec).
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	apiPkg := flag.String("pkg", "k8s.io/api/core/v1", "import path of the API types")
+	out := flag.String("o", "zz_generated.builders.go", "output file")
+	forward := flag.String("forward", "", "comma-separated builders forwarding to the setters of a field's builder (i.e. Builder=SpecBuilder)")
+	flag.Parse()
+
+	if err := run(".", *out, *apiPkg, flag.Args(), forwards(*forward)); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// forwards splits the value of the forward flag
+func forwards(flag string) []string {
+	if flag == "" {
+		return nil
+	}
+	return strings.Split(flag, ",")
+}
+
+func run(dir, out, apiPkg string, specs, forwards []string) error {
+	d, err := parseDecls(dir, out)
+	if err != nil {
+		return err
+	}
+	src, err := Generate(d, apiPkg, specs, forwards)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, out), src, 0o644)
+}
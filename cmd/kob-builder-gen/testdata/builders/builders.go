@@ -0,0 +1,66 @@
+// Package builders declares the hand-written builders the generator is tested with
+package builders
+
+import (
+	coreV1 "k8s.io/api/core/v1"
+)
+
+type Builder struct {
+	obj coreV1.Container
+}
+
+func Name(name string) Builder {
+	return Builder{obj: coreV1.Container{Name: name}}
+}
+
+// Image is declared by hand, it is not generated
+func (b Builder) Image(img string) Builder {
+	b.obj.Image = img
+	return b
+}
+
+// Commands sets the command field by hand, Command is not generated
+func (b Builder) Commands(cmds ...string) Builder {
+	b.obj.Command = cmds
+	return b
+}
+
+type SpecBuilder map[string]interface{}
+
+// Hostname is declared by hand, it is forwarded by hand
+func (b SpecBuilder) Hostname(name string) SpecBuilder {
+	b["hostname"] = name
+	return b
+}
+
+// PodBuilder forwards to the setters of SpecBuilder
+type PodBuilder map[string]interface{}
+
+func (b PodBuilder) Hostname(name string) PodBuilder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.Hostname(name) })
+}
+
+func (b PodBuilder) withSpec(f func(SpecBuilder) SpecBuilder) PodBuilder {
+	spec, _ := b["spec"].(map[string]interface{})
+	b["spec"] = f(spec)
+	return b
+}
+
+// TemplateBuilder does not forward the setters of SpecBuilder declared by hand
+type TemplateBuilder map[string]interface{}
+
+func (b TemplateBuilder) withSpec(f func(SpecBuilder) SpecBuilder) TemplateBuilder {
+	return b
+}
+
+// NotABuilder is not a builder type
+func NotABuilder() {}
+
+// ExecBuilder is a map builder nested in struct builders
+type ExecBuilder map[string]interface{}
+
+// HandlerBuilder records the errors building its nested map builders
+type HandlerBuilder struct {
+	obj coreV1.LifecycleHandler
+	err error
+}
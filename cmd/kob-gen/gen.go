@@ -0,0 +1,628 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/vladimirvivien/kob/container"
+	"github.com/vladimirvivien/kob/deployment"
+	"github.com/vladimirvivien/kob/internal/gosrc"
+	"github.com/vladimirvivien/kob/internal/scheme"
+	"github.com/vladimirvivien/kob/namespace"
+	"github.com/vladimirvivien/kob/objmeta"
+	"github.com/vladimirvivien/kob/pod"
+	"github.com/vladimirvivien/kob/selector"
+	"github.com/vladimirvivien/kob/service"
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	unstructuredV1 "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const kobPath = "github.com/vladimirvivien/kob"
+
+// generator writes the Go code of objects, recording the imports it uses
+type generator struct {
+	imports *gosrc.Imports
+	usesPtr bool
+}
+
+func newGenerator() *generator {
+	return &generator{imports: gosrc.NewImports("ptr")}
+}
+
+// fork returns a copy of the generator, used to generate code that may be discarded
+func (g *generator) fork() *generator {
+	return &generator{imports: g.imports.Copy(), usesPtr: g.usesPtr}
+}
+
+// adopt keeps the imports used by the code generated by fork f
+func (g *generator) adopt(f *generator) {
+	g.imports, g.usesPtr = f.imports, f.usesPtr
+}
+
+// pkg returns the name of the kob package
+func (g *generator) pkg(name string) string {
+	return g.imports.Name(kobPath + "/" + name)
+}
+
+// Options control the generated source
+type Options struct {
+	Package string // name of the package
+	Func    string // name of the function returning the objects
+	Source  string // description of the manifests, used in the function's comment
+}
+
+// Generate reads a stream of YAML or JSON manifests from r and returns the
+// formatted source of a function returning the objects, as kob builders when
+// their fields can be set by builder setters and as typed or unstructured
+// values otherwise. The status of objects is dropped.
+func Generate(r io.Reader, opts Options) ([]byte, error) {
+	objs, err := decode(r)
+	if err != nil {
+		return nil, err
+	}
+	g := newGenerator()
+	var exprs []string
+	for _, obj := range objs {
+		doc := comment(obj)
+		exprs = append(exprs, doc+"\n"+g.object(obj))
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "package %s\n\n", opts.Package)
+	if decl := g.imports.Decl(); decl != "" {
+		fmt.Fprintf(&src, "%s\n", decl)
+	}
+	if opts.Source != "" {
+		fmt.Fprintf(&src, "// %s returns the objects of %s, generated by kob-gen\n", opts.Func, opts.Source)
+	} else {
+		fmt.Fprintf(&src, "// %s returns the objects generated by kob-gen\n", opts.Func)
+	}
+	fmt.Fprintf(&src, "func %s() []any {\nreturn []any{\n", opts.Func)
+	for _, expr := range exprs {
+		fmt.Fprintf(&src, "%s,\n", expr)
+	}
+	src.WriteString("}\n}\n")
+	if g.usesPtr {
+		src.WriteString("\nfunc ptr[T any](v T) *T {\nreturn &v\n}\n")
+	}
+	out, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("kob-gen: formatting generated code: %w", err)
+	}
+	return out, nil
+}
+
+// decode reads the objects of the manifests, as typed objects for the kinds
+// known to kob and unstructured objects otherwise
+func decode(r io.Reader) ([]runtime.Object, error) {
+	decoder := yaml.NewYAMLOrJSONDecoder(r, 4096)
+	var objs []runtime.Object
+	for i := 0; ; i++ {
+		var raw runtime.RawExtension
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return objs, nil
+			}
+			return nil, fmt.Errorf("kob-gen: document %d: %w", i, err)
+		}
+		if len(raw.Raw) == 0 || string(raw.Raw) == "null" {
+			continue
+		}
+		obj, _, err := unstructuredV1.UnstructuredJSONScheme.Decode(raw.Raw, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("kob-gen: document %d: %w", i, err)
+		}
+		var items []unstructuredV1.Unstructured
+		switch o := obj.(type) {
+		case *unstructuredV1.UnstructuredList:
+			items = o.Items
+		case *unstructuredV1.Unstructured:
+			items = []unstructuredV1.Unstructured{*o}
+		}
+		for _, item := range items {
+			typed, err := typedObject(item)
+			if err != nil {
+				return nil, fmt.Errorf("kob-gen: document %d: %s %s: %w", i, item.GetKind(), item.GetName(), err)
+			}
+			objs = append(objs, typed)
+		}
+	}
+}
+
+// typedObject converts obj to its typed value when its kind is known to kob,
+// without type meta and status
+func typedObject(obj unstructuredV1.Unstructured) (runtime.Object, error) {
+	delete(obj.Object, "status")
+	gvk := obj.GroupVersionKind()
+	if !scheme.Scheme.Recognizes(gvk) {
+		return &obj, nil
+	}
+	typed, err := scheme.Scheme.New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, typed); err != nil {
+		return nil, err
+	}
+	typed.GetObjectKind().SetGroupVersionKind(gvk)
+	return typed, nil
+}
+
+// comment returns the comment line preceding the object's code
+func comment(obj runtime.Object) string {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	name := ""
+	if o, ok := obj.(metaV1.Object); ok {
+		name = o.GetName()
+		if o.GetNamespace() != "" {
+			name = o.GetNamespace() + "/" + name
+		}
+	}
+	return "// " + strings.TrimSpace(gvk.Kind+" "+name)
+}
+
+// object returns the Go expression of obj: a chain of builder setters for
+// the kinds and fields supported by kob, a From literal or a typed literal
+// otherwise, and an unstructured builder for the kinds unknown to kob
+func (g *generator) object(obj runtime.Object) string {
+	if u, ok := obj.(*unstructuredV1.Unstructured); ok {
+		return g.pkg("unstructured") + ".FromUnstructured(" + g.literal(reflect.ValueOf(u.Object), assignable) + ")"
+	}
+	// the type meta is set by kob when writing manifests
+	obj.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{})
+
+	var build func(*generator) (string, any)
+	switch o := obj.(type) {
+	case *appsV1.Deployment:
+		build = func(g *generator) (string, any) { return g.deployment(*o) }
+	case *coreV1.Pod:
+		build = func(g *generator) (string, any) { return g.pod(*o) }
+	case *coreV1.Service:
+		build = func(g *generator) (string, any) { return g.service(*o) }
+	case *coreV1.Namespace:
+		build = func(g *generator) (string, any) { return g.namespace(*o) }
+	}
+	if build != nil {
+		f := g.fork()
+		code, builder := build(f)
+		if code != "" && builds(builder, obj) {
+			g.adopt(f)
+			return code
+		}
+	}
+
+	lit := g.literal(reflect.ValueOf(obj).Elem(), assignable)
+	switch obj.(type) {
+	case *appsV1.Deployment:
+		return g.pkg("deployment") + ".From(" + lit + ")"
+	case *coreV1.Pod:
+		return g.pkg("pod") + ".From(" + lit + ")"
+	}
+	return lit
+}
+
+// builds reports whether builder builds the typed object want
+func builds(builder any, want runtime.Object) bool {
+	got, err := scheme.ObjectOf(builder)
+	if err != nil {
+		return false
+	}
+	return equality.Semantic.DeepEqual(got, want)
+}
+
+// chain returns the method chain of the builder expression and its setters,
+// broken over lines when long
+func chain(segs ...string) string {
+	line := strings.Join(segs, ".")
+	if len(line) <= 80 && !strings.Contains(line, "\n") {
+		return line
+	}
+	return strings.Join(segs, ".\n")
+}
+
+// call returns the call of the function or method with the arguments
+func call(fn string, args ...string) string {
+	return fn + "(" + strings.Join(args, ", ") + ")"
+}
+
+func quote(s string) string {
+	return strconv.Quote(s)
+}
+
+func quoteAll(strs []string) []string {
+	var quoted []string
+	for _, s := range strs {
+		quoted = append(quoted, quote(s))
+	}
+	return quoted
+}
+
+// objectMeta returns the code and builder of meta, set by objmeta setters when
+// they support its fields and by objmeta.From otherwise
+func (g *generator) objectMeta(meta metaV1.ObjectMeta) (string, objmeta.Builder) {
+	rest := meta
+	rest.Name, rest.Namespace, rest.GenerateName = "", "", ""
+	rest.Labels, rest.Annotations, rest.Finalizers = nil, nil, nil
+	if reflect.ValueOf(rest).IsZero() {
+		segs := []string{call(g.pkg("objmeta")+".Name", quote(meta.Name))}
+		b := objmeta.Name(meta.Name)
+		if meta.Namespace != "" {
+			segs = append(segs, call("Namespace", quote(meta.Namespace)))
+			b = b.Namespace(meta.Namespace)
+		}
+		if meta.GenerateName != "" {
+			segs = append(segs, call("GenerateName", quote(meta.GenerateName)))
+			b = b.GenerateName(meta.GenerateName)
+		}
+		if len(meta.Labels) > 0 {
+			segs = append(segs, call("Labels", g.literal(reflect.ValueOf(meta.Labels), assignable)))
+			b = b.Labels(meta.Labels)
+		}
+		if len(meta.Annotations) > 0 {
+			segs = append(segs, call("Annotations", g.literal(reflect.ValueOf(meta.Annotations), assignable)))
+			b = b.Annotations(meta.Annotations)
+		}
+		if len(meta.Finalizers) > 0 {
+			segs = append(segs, call("Finalizers", quoteAll(meta.Finalizers)...))
+			b = b.Finalizers(meta.Finalizers...)
+		}
+		if b.Err() == nil && equality.Semantic.DeepEqual(b.T(), meta) {
+			return chain(segs...), b
+		}
+	}
+	return call(g.pkg("objmeta")+".From", g.literal(reflect.ValueOf(meta), assignable)), objmeta.From(meta)
+}
+
+// container returns the code and builder of c, set by container setters. The
+// fields without setters are set first, with container.From.
+func (g *generator) container(c coreV1.Container) (string, container.Builder) {
+	rest := c
+	rest.Image, rest.Args, rest.Command, rest.WorkingDir = "", nil, nil, ""
+	rest.Ports, rest.EnvFrom, rest.Env, rest.VolumeMounts = nil, nil, nil, nil
+	rest.Resources.Limits, rest.Resources.Requests = nil, nil
+	unnamed := rest
+	unnamed.Name = ""
+
+	var segs []string
+	var b container.Builder
+	switch {
+	case !reflect.ValueOf(unnamed).IsZero():
+		// fields without setters
+		segs = []string{call(g.pkg("container")+".From", g.literal(reflect.ValueOf(rest), assignable))}
+		b = container.From(rest)
+	case c.Image != "":
+		segs = []string{call(g.pkg("container")+".WithNameAndImage", quote(c.Name), quote(c.Image))}
+		b = container.WithNameAndImage(c.Name, c.Image)
+		c.Image = ""
+	default:
+		segs = []string{call(g.pkg("container")+".Name", quote(c.Name))}
+		b = container.Name(c.Name)
+	}
+	if c.Image != "" {
+		segs = append(segs, call("Image", quote(c.Image)))
+		b = b.Image(c.Image)
+	}
+	if len(c.Command) > 0 {
+		segs = append(segs, call("Commands", quoteAll(c.Command)...))
+		b = b.Commands(c.Command...)
+	}
+	if len(c.Args) > 0 {
+		segs = append(segs, call("Args", quoteAll(c.Args)...))
+		b = b.Args(c.Args...)
+	}
+	if c.WorkingDir != "" {
+		segs = append(segs, call("WorkingDir", quote(c.WorkingDir)))
+		b = b.WorkingDir(c.WorkingDir)
+	}
+	if len(c.Ports) > 0 {
+		segs = append(segs, call("Ports", g.elems(reflect.ValueOf(c.Ports))...))
+		b = b.Ports(c.Ports...)
+	}
+	if len(c.EnvFrom) > 0 {
+		segs = append(segs, call("EnvFromSources", g.elems(reflect.ValueOf(c.EnvFrom))...))
+		b = b.EnvFromSources(c.EnvFrom...)
+	}
+	if len(c.Env) > 0 {
+		segs = append(segs, call("EnvVars", g.elems(reflect.ValueOf(c.Env))...))
+		b = b.EnvVars(c.Env...)
+	}
+	if len(c.Resources.Limits) > 0 {
+		segs = append(segs, call("ResourceLimits", g.literal(reflect.ValueOf(c.Resources.Limits), assignable)))
+		b = b.ResourceLimits(c.Resources.Limits)
+	}
+	if len(c.Resources.Requests) > 0 {
+		segs = append(segs, call("ResourceRequests", g.literal(reflect.ValueOf(c.Resources.Requests), assignable)))
+		b = b.ResourceRequests(c.Resources.Requests)
+	}
+	if len(c.VolumeMounts) > 0 {
+		segs = append(segs, call("VolumeMounts", g.elems(reflect.ValueOf(c.VolumeMounts))...))
+		b = b.VolumeMounts(c.VolumeMounts...)
+	}
+	return chain(segs...), b
+}
+
+// containers returns the code and builders of the containers
+func (g *generator) containers(cs []coreV1.Container) ([]string, []container.Builder) {
+	var codes []string
+	var builders []container.Builder
+	for _, c := range cs {
+		code, b := g.container(c)
+		codes = append(codes, code)
+		builders = append(builders, b)
+	}
+	return codes, builders
+}
+
+// elems returns the literals of the elements of slice, as variadic arguments
+func (g *generator) elems(slice reflect.Value) []string {
+	var lits []string
+	for i := 0; i < slice.Len(); i++ {
+		lits = append(lits, g.literal(slice.Index(i), assignable))
+	}
+	return lits
+}
+
+// podSpec returns the setters of the pod spec and applies them to b. The
+// fields without setters are set first, with pod.SpecFrom.
+func (g *generator) podSpec(spec coreV1.PodSpec, b pod.Builder) ([]string, pod.Builder) {
+	rest := spec
+	rest.Containers, rest.InitContainers, rest.RestartPolicy = nil, nil, ""
+	rest.PriorityClassName, rest.RuntimeClassName, rest.NodeSelector = "", nil, nil
+	rest.Affinity, rest.Tolerations, rest.ServiceAccountName, rest.TerminationGracePeriodSeconds = nil, nil, "", nil
+
+	var segs []string
+	codes, containers := g.containers(spec.Containers)
+	if reflect.ValueOf(rest).IsZero() {
+		segs = append(segs, call("Spec", codes...))
+		b = b.Spec(containers...)
+	} else {
+		segs = append(segs, call("WithSpec", call(g.pkg("pod")+".SpecFrom", g.literal(reflect.ValueOf(rest), assignable))))
+		b = b.WithSpec(pod.SpecFrom(rest))
+		if len(codes) > 0 {
+			segs = append(segs, call("SetContainers", codes...))
+			b = b.SetContainers(containers...)
+		}
+	}
+	if len(spec.InitContainers) > 0 {
+		codes, containers := g.containers(spec.InitContainers)
+		segs = append(segs, call("InitContainers", codes...))
+		b = b.InitContainers(containers...)
+	}
+	if spec.RestartPolicy != "" {
+		segs = append(segs, call("RestartPolicy", quote(string(spec.RestartPolicy))))
+		b = b.RestartPolicy(spec.RestartPolicy)
+	}
+	if spec.ServiceAccountName != "" {
+		segs = append(segs, call("ServiceAccountName", quote(spec.ServiceAccountName)))
+		b = b.ServiceAccountName(spec.ServiceAccountName)
+	}
+	if spec.PriorityClassName != "" {
+		segs = append(segs, call("PriorityClassName", quote(spec.PriorityClassName)))
+		b = b.PriorityClassName(spec.PriorityClassName)
+	}
+	if spec.RuntimeClassName != nil {
+		segs = append(segs, call("RuntimeClassName", quote(*spec.RuntimeClassName)))
+		b = b.RuntimeClassName(*spec.RuntimeClassName)
+	}
+	if len(spec.NodeSelector) > 0 {
+		segs = append(segs, call("NodeSelector", g.literal(reflect.ValueOf(spec.NodeSelector), assignable)))
+		b = b.NodeSelector(spec.NodeSelector)
+	}
+	if spec.Affinity != nil {
+		segs = append(segs, call("Affinity", g.literal(reflect.ValueOf(*spec.Affinity), assignable)))
+		b = b.Affinity(*spec.Affinity)
+	}
+	if len(spec.Tolerations) > 0 {
+		segs = append(segs, call("Tolerations", g.elems(reflect.ValueOf(spec.Tolerations))...))
+		b = b.Tolerations(spec.Tolerations...)
+	}
+	if spec.TerminationGracePeriodSeconds != nil {
+		segs = append(segs, call("TerminationGracePeriodSeconds", strconv.FormatInt(*spec.TerminationGracePeriodSeconds, 10)))
+		b = b.TerminationGracePeriodSeconds(*spec.TerminationGracePeriodSeconds)
+	}
+	return segs, b
+}
+
+func (g *generator) pod(obj coreV1.Pod) (string, any) {
+	rest := obj
+	rest.ObjectMeta, rest.Spec = metaV1.ObjectMeta{}, coreV1.PodSpec{}
+	if !reflect.ValueOf(rest).IsZero() {
+		return "", nil
+	}
+	meta, metaBuilder := g.objectMeta(obj.ObjectMeta)
+	b := pod.Object(metaBuilder)
+	segs := []string{call(g.pkg("pod")+".Object", meta)}
+	specSegs, b := g.podSpec(obj.Spec, b)
+	return chain(append(segs, specSegs...)...), b
+}
+
+func (g *generator) deployment(obj appsV1.Deployment) (string, any) {
+	rest := obj
+	rest.ObjectMeta = metaV1.ObjectMeta{}
+	rest.Spec.Replicas, rest.Spec.Strategy, rest.Spec.Template, rest.Spec.Selector = nil, appsV1.DeploymentStrategy{}, coreV1.PodTemplateSpec{}, nil
+	if !reflect.ValueOf(rest).IsZero() {
+		return "", nil
+	}
+
+	meta, metaBuilder := g.objectMeta(obj.ObjectMeta)
+	b := deployment.Object(metaBuilder)
+	segs := []string{call(g.pkg("deployment")+".Object", meta)}
+	spec := obj.Spec
+	if spec.Replicas != nil {
+		segs = append(segs, call("Replicas", strconv.Itoa(int(*spec.Replicas))))
+		b = b.Replicas(int(*spec.Replicas))
+	}
+	if !reflect.ValueOf(spec.Strategy).IsZero() {
+		code, strategy := g.strategy(spec.Strategy)
+		segs = append(segs, call("Strategy", code))
+		b = b.Strategy(strategy)
+	}
+
+	tmplMeta := reflect.ValueOf(spec.Template.ObjectMeta).IsZero()
+	specRest := spec.Template.Spec
+	specRest.Containers = nil
+	if reflect.ValueOf(specRest).IsZero() {
+		codes, containers := g.containers(spec.Template.Spec.Containers)
+		if tmplMeta {
+			segs = append(segs, call("PodSpec", codes...))
+			b = b.PodSpec(containers...)
+		} else {
+			meta, metaBuilder := g.objectMeta(spec.Template.ObjectMeta)
+			segs = append(segs, call("PodSpecWithMetadata", append([]string{meta}, codes...)...))
+			b = b.PodSpecWithMetadata(metaBuilder, containers...)
+		}
+	} else {
+		meta, metaBuilder := g.objectMeta(spec.Template.ObjectMeta)
+		specSegs, p := g.podSpec(spec.Template.Spec, pod.Object(metaBuilder))
+		segs = append(segs, call("PodTemplate", chain(append([]string{call(g.pkg("pod")+".Object", meta)}, specSegs...)...)))
+		b = b.PodTemplate(p)
+	}
+
+	if spec.Selector != nil {
+		if len(spec.Selector.MatchExpressions) == 0 {
+			segs = append(segs, call("Selector", g.literal(reflect.ValueOf(spec.Selector.MatchLabels), assignable)))
+			b = b.Selector(spec.Selector.MatchLabels)
+		} else {
+			code, sel := g.selector(*spec.Selector)
+			segs = append(segs, call("LabelSelector", code))
+			b = b.LabelSelector(sel)
+		}
+	}
+	return chain(segs...), b
+}
+
+// strategy returns the code and builder of the deployment strategy
+func (g *generator) strategy(strategy appsV1.DeploymentStrategy) (string, deployment.StrategyBuilder) {
+	pkg := g.pkg("deployment")
+	if strategy.Type == appsV1.RecreateDeploymentStrategyType && strategy.RollingUpdate == nil {
+		return pkg + ".StrategyRecreate", deployment.StrategyRecreate
+	}
+	if ru := strategy.RollingUpdate; strategy.Type == appsV1.RollingUpdateDeploymentStrategyType && ru != nil &&
+		ru.MaxUnavailable != nil && ru.MaxUnavailable.Type == intstr.String && ru.MaxSurge != nil && ru.MaxSurge.Type == intstr.String {
+		return call(pkg+".RollingUpdate", quote(ru.MaxUnavailable.StrVal), quote(ru.MaxSurge.StrVal)),
+			deployment.RollingUpdate(ru.MaxUnavailable.StrVal, ru.MaxSurge.StrVal)
+	}
+	return call(pkg+".StrategyFrom", g.literal(reflect.ValueOf(strategy), assignable)), deployment.StrategyFrom(strategy)
+}
+
+// selector returns the code and builder of the label selector
+func (g *generator) selector(sel metaV1.LabelSelector) (string, selector.Builder) {
+	pkg := g.pkg("selector")
+	var segs []string
+	b := selector.Builder{}
+	if len(sel.MatchLabels) > 0 {
+		segs = append(segs, call(pkg+".MatchLabels", g.literal(reflect.ValueOf(sel.MatchLabels), assignable)))
+		b = b.MatchLabels(sel.MatchLabels)
+	}
+	for _, expr := range sel.MatchExpressions {
+		args := append([]string{quote(expr.Key)}, quoteAll(expr.Values)...)
+		fn := string(expr.Operator)
+		if len(segs) == 0 {
+			fn = pkg + "." + fn
+		}
+		segs = append(segs, call(fn, args...))
+		switch expr.Operator {
+		case metaV1.LabelSelectorOpIn:
+			b = b.In(expr.Key, expr.Values...)
+		case metaV1.LabelSelectorOpNotIn:
+			b = b.NotIn(expr.Key, expr.Values...)
+		case metaV1.LabelSelectorOpExists:
+			b = b.Exists(expr.Key)
+		case metaV1.LabelSelectorOpDoesNotExist:
+			b = b.DoesNotExist(expr.Key)
+		}
+	}
+	return chain(segs...), b
+}
+
+func (g *generator) service(obj coreV1.Service) (string, any) {
+	rest := obj
+	rest.ObjectMeta = metaV1.ObjectMeta{}
+	rest.Spec.Type, rest.Spec.Selector, rest.Spec.Ports, rest.Spec.ClusterIP = "", nil, nil, ""
+	if !reflect.ValueOf(rest).IsZero() {
+		return "", nil
+	}
+
+	meta, metaBuilder := g.objectMeta(obj.ObjectMeta)
+	b := service.Object(metaBuilder)
+	segs := []string{call(g.pkg("service")+".Object", meta)}
+	spec := obj.Spec
+	if spec.Type != "" {
+		segs = append(segs, call("Type", quote(string(spec.Type))))
+		b = b.Type(spec.Type)
+	}
+	if spec.ClusterIP != "" {
+		segs = append(segs, call("ClusterIP", quote(spec.ClusterIP)))
+		b = b.ClusterIP(spec.ClusterIP)
+	}
+	if len(spec.Selector) > 0 {
+		segs = append(segs, call("Selector", g.literal(reflect.ValueOf(spec.Selector), assignable)))
+		b = b.Selector(spec.Selector)
+	}
+	if len(spec.Ports) > 0 {
+		var codes []string
+		var ports []service.PortBuilder
+		for _, p := range spec.Ports {
+			code, port := g.servicePort(p)
+			codes = append(codes, code)
+			ports = append(ports, port)
+		}
+		segs = append(segs, call("Ports", codes...))
+		b = b.Ports(ports...)
+	}
+	return chain(segs...), b
+}
+
+// servicePort returns the code and builder of the service port
+func (g *generator) servicePort(p coreV1.ServicePort) (string, service.PortBuilder) {
+	segs := []string{call(g.pkg("service")+".Port", strconv.Itoa(int(p.Port)))}
+	b := service.Port(p.Port)
+	if p.Name != "" {
+		segs = append(segs, call("Name", quote(p.Name)))
+		b = b.Name(p.Name)
+	}
+	if p.Protocol != "" {
+		segs = append(segs, call("Protocol", quote(string(p.Protocol))))
+		b = b.Protocol(p.Protocol)
+	}
+	if p.AppProtocol != nil {
+		segs = append(segs, call("AppProtocol", quote(*p.AppProtocol)))
+		b = b.AppProtocol(*p.AppProtocol)
+	}
+	switch {
+	case p.TargetPort.StrVal != "":
+		segs = append(segs, call("TargetPortName", quote(p.TargetPort.StrVal)))
+		b = b.TargetPortName(p.TargetPort.StrVal)
+	case p.TargetPort.IntVal != 0:
+		segs = append(segs, call("TargetPort", strconv.Itoa(int(p.TargetPort.IntVal))))
+		b = b.TargetPort(p.TargetPort.IntVal)
+	}
+	if p.NodePort != 0 {
+		segs = append(segs, call("NodePort", strconv.Itoa(int(p.NodePort))))
+		b = b.NodePort(p.NodePort)
+	}
+	return chain(segs...), b
+}
+
+func (g *generator) namespace(obj coreV1.Namespace) (string, any) {
+	rest := obj
+	rest.ObjectMeta = metaV1.ObjectMeta{}
+	if !reflect.ValueOf(rest).IsZero() {
+		return "", nil
+	}
+	meta, metaBuilder := g.objectMeta(obj.ObjectMeta)
+	return call(g.pkg("namespace")+".Object", meta), namespace.Object(metaBuilder)
+}
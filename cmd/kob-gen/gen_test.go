@@ -0,0 +1,323 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vladimirvivien/kob"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestGenerate(t *testing.T) {
+	tests := map[string]struct {
+		manifest string
+		expected []string
+	}{
+		"deployment with setters": {
+			manifest: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: shop
+spec:
+  replicas: 3
+  strategy:
+    type: Recreate
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        image: nginx
+        args: ["-g", "daemon off;"]
+`,
+			expected: []string{
+				`deployment.Object(objmeta.Name("web").Namespace("shop")).`,
+				`Replicas(3).`,
+				`Strategy(deployment.StrategyRecreate).`,
+				`PodSpecWithMetadata(objmeta.Name("").Labels(map[string]string{"app": "web"}), container.WithNameAndImage("web", "nginx").Args("-g", "daemon off;")).`,
+				`Selector(map[string]string{"app": "web"}),`,
+			},
+		},
+		"deployment fields without setters": {
+			manifest: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  minReadySeconds: 10
+  template:
+    spec:
+      containers:
+      - name: web
+`,
+			expected: []string{
+				`deployment.From(appsV1.Deployment{`,
+				`MinReadySeconds: 10,`,
+				`Spec: coreV1.PodSpec{Containers: []coreV1.Container{{Name: "web"}}},`,
+			},
+		},
+		"container fields without setters": {
+			manifest: `apiVersion: v1
+kind: Pod
+metadata:
+  name: web
+spec:
+  restartPolicy: Never
+  containers:
+  - name: web
+    image: nginx
+    imagePullPolicy: Always
+`,
+			expected: []string{
+				`pod.Object(objmeta.Name("web")).`,
+				`Spec(container.From(coreV1.Container{Name: "web", ImagePullPolicy: "Always"}).`,
+				`Image("nginx")).`,
+				`RestartPolicy("Never"),`,
+			},
+		},
+		"pod spec fields without setters": {
+			manifest: `apiVersion: v1
+kind: Pod
+metadata:
+  name: web
+spec:
+  hostNetwork: true
+  containers:
+  - name: web
+`,
+			expected: []string{
+				`WithSpec(pod.SpecFrom(coreV1.PodSpec{HostNetwork: true})).`,
+				`SetContainers(container.Name("web")),`,
+			},
+		},
+		"service": {
+			manifest: `apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  type: NodePort
+  ports:
+  - port: 80
+    targetPort: 8080
+    nodePort: 30080
+`,
+			expected: []string{
+				`service.Object(objmeta.Name("web")).`,
+				`Type("NodePort").`,
+				`Ports(service.Port(80).TargetPort(8080).NodePort(30080)),`,
+			},
+		},
+		"kind without builder setters": {
+			manifest: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cfg
+data:
+  a: "1"
+`,
+			expected: []string{
+				`// ConfigMap cfg`,
+				`coreV1.ConfigMap{`,
+				`ObjectMeta: metaV1.ObjectMeta{Name: "cfg"},`,
+				`Data:       map[string]string{"a": "1"},`,
+			},
+		},
+		"custom resource": {
+			manifest: `apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: w
+spec:
+  size: 3
+status:
+  ready: true
+`,
+			expected: []string{
+				`unstructured.FromUnstructured(map[string]any{`,
+				`"spec":       map[string]any{"size": int64(3)},`,
+			},
+		},
+		"pointers": {
+			manifest: `apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: sa
+automountServiceAccountToken: false
+`,
+			expected: []string{
+				`AutomountServiceAccountToken: ptr(false),`,
+				`func ptr[T any](v T) *T {`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			src, err := Generate(strings.NewReader(test.manifest), Options{Package: "manifests", Func: "Objects"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, expected := range test.expected {
+				if !strings.Contains(string(src), expected) {
+					t.Errorf("generated code missing %q:\n\n%s", expected, src)
+				}
+			}
+			if strings.Contains(string(src), "status") {
+				t.Errorf("generated code contains status:\n\n%s", src)
+			}
+		})
+	}
+}
+
+func TestLiteral(t *testing.T) {
+	tests := map[string]struct {
+		value    any
+		ctx      context
+		expected string
+	}{
+		"typed named string": {
+			value:    coreV1.PullAlways,
+			ctx:      typed,
+			expected: `coreV1.PullPolicy("Always")`,
+		},
+		"assignable named string": {
+			value:    coreV1.PullAlways,
+			ctx:      assignable,
+			expected: `"Always"`,
+		},
+		"typed int32": {
+			value:    int32(3),
+			ctx:      typed,
+			expected: `int32(3)`,
+		},
+		"quantity": {
+			value:    resource.MustParse("500m"),
+			ctx:      assignable,
+			expected: `resource.MustParse("500m")`,
+		},
+		"int or string": {
+			value:    intstr.FromInt(8080),
+			ctx:      assignable,
+			expected: `intstr.FromInt32(8080)`,
+		},
+		"time": {
+			value:    metaV1.Date(2023, 10, 1, 12, 30, 0, 0, time.UTC),
+			ctx:      assignable,
+			expected: `metaV1.Date(2023, 10, 1, 12, 30, 0, 0, time.UTC)`,
+		},
+		"duration": {
+			value:    metaV1.Duration{Duration: 90 * time.Second},
+			ctx:      assignable,
+			expected: `metaV1.Duration{Duration: 90 * time.Second}`,
+		},
+		"elided element types": {
+			value:    []coreV1.EnvVar{{Name: "A", Value: "1"}},
+			ctx:      assignable,
+			expected: `[]coreV1.EnvVar{{Name: "A", Value: "1"}}`,
+		},
+		"sorted map": {
+			value:    map[string]string{"b": "2", "a": "1"},
+			ctx:      assignable,
+			expected: `map[string]string{"a": "1", "b": "2"}`,
+		},
+		"unstructured numbers": {
+			value:    map[string]any{"n": int64(1), "f": 1.5},
+			ctx:      assignable,
+			expected: `map[string]any{"f": 1.5, "n": int64(1)}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := newGenerator()
+			lit := g.literal(reflect.ValueOf(test.value), test.ctx)
+			if lit != test.expected {
+				t.Errorf("literal not equal \n\n Value: %#v \n\n Expected: %s \n\n Got: %s", test.value, test.expected, lit)
+			}
+		})
+	}
+}
+
+// TestGenerateRoundTrip builds the code generated from testdata/app.yaml and
+// checks the objects it returns are written as the manifests
+func TestGenerateRoundTrip(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping build of generated code in short mode")
+	}
+	manifests, err := os.ReadFile(filepath.Join("testdata", "app.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	objs, err := decode(bytes.NewReader(manifests))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expected bytes.Buffer
+	if err := kob.YAML(&expected, toAny(objs)...); err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := Generate(bytes.NewReader(manifests), Options{Package: "main", Func: "Objects"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the generated package is built within the module, testdata is ignored by ./...
+	dir, err := os.MkdirTemp("testdata", "roundtrip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	mainSrc := `package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/vladimirvivien/kob"
+)
+
+func main() {
+	if err := kob.YAML(os.Stdout, Objects()...); err != nil {
+		log.Fatal(err)
+	}
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(mainSrc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "objects.go"), src, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := exec.Command("go", "run", "./"+filepath.ToSlash(dir)).CombinedOutput()
+	if err != nil {
+		t.Fatalf("running generated code: %s\n\n%s\n\n%s", err, out, src)
+	}
+	if string(out) != expected.String() {
+		t.Errorf("manifests not equal \n\n Expected: %s \n\n Got: %s", expected.String(), out)
+	}
+}
+
+func toAny[T any](objs []T) []any {
+	var all []any
+	for _, obj := range objs {
+		all = append(all, obj)
+	}
+	return all
+}
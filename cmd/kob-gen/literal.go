@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// context tells the literal printer how the value is used, which decides
+// whether constants need a type conversion and composite literals a type
+type context int
+
+const (
+	// typed values must carry their type (i.e. arguments of ptr)
+	typed context = iota
+	// assignable values are assigned to a field or parameter of their type
+	assignable
+	// element values are elements or keys of a composite literal, whose type can be elided
+	element
+)
+
+var (
+	quantityType    = reflect.TypeOf(resource.Quantity{})
+	intOrStringType = reflect.TypeOf(intstr.IntOrString{})
+	timeType        = reflect.TypeOf(metaV1.Time{})
+	durationType    = reflect.TypeOf(metaV1.Duration{})
+)
+
+// literal returns the Go source of value v, omitting zero struct fields
+func (g *generator) literal(v reflect.Value, ctx context) string {
+	t := v.Type()
+	switch t {
+	case quantityType:
+		q := v.Interface().(resource.Quantity)
+		return g.imports.Name(quantityType.PkgPath()) + ".MustParse(" + strconv.Quote(q.String()) + ")"
+	case intOrStringType:
+		val := v.Interface().(intstr.IntOrString)
+		pkg := g.imports.Name(intOrStringType.PkgPath())
+		if val.Type == intstr.String {
+			return pkg + ".FromString(" + strconv.Quote(val.StrVal) + ")"
+		}
+		return fmt.Sprintf("%s.FromInt32(%d)", pkg, val.IntVal)
+	case timeType:
+		tm := v.Interface().(metaV1.Time).UTC()
+		return fmt.Sprintf("%s.Date(%d, %d, %d, %d, %d, %d, %d, %s.UTC)", g.imports.Name(timeType.PkgPath()),
+			tm.Year(), tm.Month(), tm.Day(), tm.Hour(), tm.Minute(), tm.Second(), tm.Nanosecond(), g.imports.Name("time"))
+	case durationType:
+		d := v.Interface().(metaV1.Duration)
+		return fmt.Sprintf("%s{Duration: %s}", g.imports.TypeName(durationType), g.duration(int64(d.Duration)))
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return "nil"
+		}
+		if elem := t.Elem(); elem.Kind() == reflect.Struct && elem != quantityType && elem != intOrStringType && elem != timeType {
+			lit := g.literal(v.Elem(), ctx)
+			if ctx == element && strings.HasPrefix(lit, "{") {
+				return lit
+			}
+			return "&" + lit
+		}
+		g.usesPtr = true
+		return "ptr(" + g.literal(v.Elem(), typed) + ")"
+	case reflect.Struct:
+		var fields []string
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() || v.Field(i).IsZero() {
+				continue
+			}
+			fields = append(fields, f.Name+": "+g.literal(v.Field(i), assignable))
+		}
+		return g.composite(t, ctx, fields)
+	case reflect.Slice:
+		if v.IsNil() {
+			return "nil"
+		}
+		if t.Elem().Kind() == reflect.Uint8 {
+			return g.imports.TypeName(t) + "(" + strconv.Quote(string(v.Bytes())) + ")"
+		}
+		var elems []string
+		for i := 0; i < v.Len(); i++ {
+			elems = append(elems, g.literal(v.Index(i), element))
+		}
+		return g.composite(t, ctx, elems)
+	case reflect.Map:
+		if v.IsNil() {
+			return "nil"
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		var elems []string
+		for _, k := range keys {
+			elems = append(elems, g.literal(k, element)+": "+g.literal(v.MapIndex(k), element))
+		}
+		return g.composite(t, ctx, elems)
+	case reflect.Interface:
+		if v.IsNil() {
+			return "nil"
+		}
+		return g.literal(v.Elem(), typed)
+	case reflect.String:
+		return g.convert(t, ctx, strconv.Quote(v.String()), t == reflect.TypeOf(""))
+	case reflect.Bool:
+		return g.convert(t, ctx, strconv.FormatBool(v.Bool()), t == reflect.TypeOf(false))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return g.convert(t, ctx, strconv.FormatInt(v.Int(), 10), t == reflect.TypeOf(0))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return g.convert(t, ctx, strconv.FormatUint(v.Uint(), 10), false)
+	case reflect.Float32, reflect.Float64:
+		lit := strconv.FormatFloat(v.Float(), 'g', -1, 64)
+		if !strings.ContainsAny(lit, ".e") {
+			lit += ".0"
+		}
+		return g.convert(t, ctx, lit, t == reflect.TypeOf(0.0))
+	}
+	return fmt.Sprintf("%#v", v.Interface())
+}
+
+// composite returns the composite literal of type t with the elements, on
+// one line when short, eliding the type of elements of other composite literals
+func (g *generator) composite(t reflect.Type, ctx context, elems []string) string {
+	typ := g.imports.TypeName(t)
+	if ctx == element {
+		typ = ""
+	}
+	if line := strings.Join(elems, ", "); len(line) <= 60 && !strings.Contains(line, "\n") {
+		return typ + "{" + line + "}"
+	}
+	return typ + "{\n" + strings.Join(elems, ",\n") + ",\n}"
+}
+
+// convert returns the constant lit converted to type t, unless the context
+// doesn't need it or t is the constant's default type
+func (g *generator) convert(t reflect.Type, ctx context, lit string, isDefault bool) string {
+	if ctx != typed || isDefault {
+		return lit
+	}
+	return g.imports.TypeName(t) + "(" + lit + ")"
+}
+
+// duration returns the Go source of a duration of n nanoseconds
+func (g *generator) duration(n int64) string {
+	pkg := g.imports.Name("time")
+	for _, unit := range []struct {
+		name string
+		size int64
+	}{{"Hour", 3600e9}, {"Minute", 60e9}, {"Second", 1e9}, {"Millisecond", 1e6}} {
+		if n != 0 && n%unit.size == 0 {
+			return fmt.Sprintf("%d * %s.%s", n/unit.size, pkg, unit.name)
+		}
+	}
+	return fmt.Sprintf("%s.Duration(%d)", pkg, n)
+}
@@ -0,0 +1,67 @@
+// Command kob-gen generates the Go code building the objects of Kubernetes
+// manifests with kob builders, to migrate manifests to kob.
+//
+// Usage:
+//
+//	kob-gen [-package name] [-func name] [-o file] [manifest.yaml ...]
+//
+// The manifests are read from the files, or the standard input when none is
+// given, and the generated code is written to the standard output unless -o
+// is set. The generated function returns the objects as kob builders, set by
+// builder setters where kob provides them (i.e. deployment.Object(...).Replicas(3)),
+// falling back to From literals, or typed literals for kinds without builder
+// setters, for the other fields. Objects of kinds unknown to kob, such as custom
+// resources, are returned as unstructured builders. The code can be written
+// back as manifests with kob.YAML.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	pkg := flag.String("package", "manifests", "name of the generated package")
+	fn := flag.String("func", "Objects", "name of the generated function")
+	out := flag.String("o", "", "output file (default standard output)")
+	flag.Parse()
+
+	if err := run(flag.Args(), *out, Options{Package: *pkg, Func: *fn}); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(files []string, out string, opts Options) error {
+	var in io.Reader = os.Stdin
+	if len(files) > 0 {
+		var manifests bytes.Buffer
+		var names []string
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			// documents of different files are separated
+			fmt.Fprintf(&manifests, "---\n%s\n", data)
+			names = append(names, filepath.Base(file))
+		}
+		in = &manifests
+		opts.Source = strings.Join(names, ", ")
+	}
+
+	src, err := Generate(in, opts)
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		_, err := os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(out, src, 0o644)
+}
@@ -0,0 +1,117 @@
+// Package configmap contains builder types to build values of type coreV1.ConfigMap
+package configmap
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Builder provides a way to build values of type coreV1.ConfigMap
+type Builder map[string]interface{}
+
+// Object starts a new ConfigMap builder using the provided object metadata
+func Object(metadata objmeta.Builder) Builder {
+	meta, err := metadata.U()
+	return Builder(unstruct.SetErr(map[string]interface{}{"metadata": meta}, err))
+}
+
+// From creates a new builder using the provided config map as its base
+func From(obj coreV1.ConfigMap) Builder {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&obj)
+	if err != nil {
+		return Builder(unstruct.SetErr(map[string]interface{}{}, fmt.Errorf("configmap: from: %w", err)))
+	}
+	return Builder(u)
+}
+
+// U returns the unstructured value of the builder
+func (b Builder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b Builder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder. The keys of the data and
+// binary data must be valid config map keys and must not overlap.
+func (b Builder) T() (coreV1.ConfigMap, error) {
+	var cm coreV1.ConfigMap
+	if err := unstruct.FromUnstructured(b, &cm); err != nil {
+		return coreV1.ConfigMap{}, err
+	}
+	if err := naming.Validate("ConfigMap", cm.Name); err != nil {
+		return coreV1.ConfigMap{}, err
+	}
+	for key := range cm.Data {
+		if errs := validation.IsConfigMapKey(key); len(errs) > 0 {
+			return coreV1.ConfigMap{}, fmt.Errorf("configmap: invalid key %q: %s", key, strings.Join(errs, "; "))
+		}
+	}
+	for key := range cm.BinaryData {
+		if errs := validation.IsConfigMapKey(key); len(errs) > 0 {
+			return coreV1.ConfigMap{}, fmt.Errorf("configmap: invalid key %q: %s", key, strings.Join(errs, "; "))
+		}
+		if _, found := cm.Data[key]; found {
+			return coreV1.ConfigMap{}, fmt.Errorf("configmap: key %q is in both data and binary data", key)
+		}
+	}
+	return cm, nil
+}
+
+// Data sets the entries of the config map, replacing existing ones
+func (b Builder) Data(data map[string]string) Builder {
+	m := map[string]interface{}{}
+	for k, v := range data {
+		m[k] = v
+	}
+	b["data"] = m
+	return b
+}
+
+// AddData adds an entry to the config map
+func (b Builder) AddData(key, value string) Builder {
+	data, ok := b["data"].(map[string]interface{})
+	if !ok {
+		data = map[string]interface{}{}
+		b["data"] = data
+	}
+	data[key] = value
+	return b
+}
+
+// BinaryData sets the binary entries of the config map, replacing existing ones
+func (b Builder) BinaryData(data map[string][]byte) Builder {
+	m := map[string]interface{}{}
+	for k, v := range data {
+		m[k] = base64.StdEncoding.EncodeToString(v)
+	}
+	b["binaryData"] = m
+	return b
+}
+
+// AddBinaryData adds a binary entry to the config map
+func (b Builder) AddBinaryData(key string, value []byte) Builder {
+	data, ok := b["binaryData"].(map[string]interface{})
+	if !ok {
+		data = map[string]interface{}{}
+		b["binaryData"] = data
+	}
+	data[key] = base64.StdEncoding.EncodeToString(value)
+	return b
+}
+
+// Immutable sets whether the data of the config map can be updated
+func (b Builder) Immutable(immutable bool) Builder {
+	b["immutable"] = immutable
+	return b
+}
@@ -0,0 +1,110 @@
+package configmap
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/objmeta"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConfigMapUnstructured(t *testing.T) {
+	tests := map[string]struct {
+		builder  Builder
+		expected map[string]interface{}
+	}{
+		"empty": {
+			builder:  Builder{},
+			expected: map[string]interface{}{},
+		},
+		"object meta only": {
+			builder:  Object(objmeta.Name("web-config").Namespace("default")),
+			expected: map[string]interface{}{"metadata": map[string]interface{}{"name": "web-config", "namespace": "default"}},
+		},
+		"data": {
+			builder: Object(objmeta.Name("web-config")).Data(map[string]string{"mode": "fast"}).AddData("level", "debug").Immutable(true),
+			expected: map[string]interface{}{
+				"metadata":  map[string]interface{}{"name": "web-config"},
+				"data":      map[string]interface{}{"mode": "fast", "level": "debug"},
+				"immutable": true,
+			},
+		},
+		"binary data": {
+			builder: Object(objmeta.Name("web-config")).AddBinaryData("key", []byte("abc")),
+			expected: map[string]interface{}{
+				"metadata":   map[string]interface{}{"name": "web-config"},
+				"binaryData": map[string]interface{}{"key": "YWJj"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cm := test.builder
+			if !reflect.DeepEqual(cm.U(), test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", cm.U(), test.expected)
+			}
+		})
+	}
+}
+
+func TestConfigMapTyped(t *testing.T) {
+	immutable := true
+	tests := map[string]struct {
+		builder  Builder
+		expected coreV1.ConfigMap
+	}{
+		"empty": {
+			builder:  Builder{},
+			expected: coreV1.ConfigMap{},
+		},
+		"all fields": {
+			builder: Object(objmeta.Name("web-config").Namespace("default")).
+				Data(map[string]string{"mode": "fast"}).
+				BinaryData(map[string][]byte{"cert.pem": []byte("abc")}).
+				Immutable(true),
+			expected: coreV1.ConfigMap{
+				ObjectMeta: metaV1.ObjectMeta{Name: "web-config", Namespace: "default"},
+				Data:       map[string]string{"mode": "fast"},
+				BinaryData: map[string][]byte{"cert.pem": []byte("abc")},
+				Immutable:  &immutable,
+			},
+		},
+		"from typed": {
+			builder: From(coreV1.ConfigMap{ObjectMeta: metaV1.ObjectMeta{Name: "web-config"}, Data: map[string]string{"mode": "fast"}}).AddData("level", "debug"),
+			expected: coreV1.ConfigMap{
+				ObjectMeta: metaV1.ObjectMeta{Name: "web-config"},
+				Data:       map[string]string{"mode": "fast", "level": "debug"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cm, err := test.builder.T()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(cm, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", cm, test.expected)
+			}
+		})
+	}
+}
+
+func TestConfigMapInvalid(t *testing.T) {
+	tests := map[string]Builder{
+		"invalid name":    Object(objmeta.Name("Web_Config")),
+		"invalid key":     Object(objmeta.Name("web-config")).AddData("bad key", "x"),
+		"overlapping key": Object(objmeta.Name("web-config")).AddData("key", "x").AddBinaryData("key", []byte("x")),
+	}
+
+	for name, builder := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := builder.T(); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
@@ -3,12 +3,15 @@ package container
 import (
 	"strings"
 
+	"github.com/vladimirvivien/kob/internal/unstruct"
 	coreV1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/yaml"
 )
 
+//go:generate go run ../cmd/kob-builder-gen -pkg k8s.io/api/core/v1 Container=Builder ContainerPort=PortBuilder VolumeMount=VolMountBuilder Probe=ProbeBuilder SecurityContext=SecurityContextBuilder
+
 type Builder struct {
 	obj coreV1.Container
 }
@@ -48,11 +51,11 @@ func WithNameAndImage(name, image string) Builder {
 
 // U returns an unstructured value of builder's object
 func (b Builder) U() (map[string]any, error) {
-	unstruct, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&b.obj)
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&b.obj)
 	if err != nil {
 		return nil, err
 	}
-	return unstruct, nil
+	return unstruct.Prune(u), nil
 }
 
 // T returns a typed value of builder's object
@@ -135,28 +138,32 @@ func (b Builder) AddResourceLimit(name coreV1.ResourceName, qty resource.Quantit
 	return b
 }
 
-// func (b *ContainerBuilder) ResourceRequests(reqs coreV1.ResourceList) *ContainerBuilder {
-// 	b.container.Resources.Requests = reqs
-// 	return b
-// }
+// ResourceRequests sets container's resource requests
+func (b Builder) ResourceRequests(reqs coreV1.ResourceList) Builder {
+	b.obj.Resources.Requests = reqs
+	return b
+}
 
-// func (b *ContainerBuilder) AddResourceRequest(name coreV1.ResourceName, qty resource.Quantity) *ContainerBuilder {
-// 	if b.container.Resources.Requests == nil {
-// 		b.container.Resources.Requests = make(coreV1.ResourceList)
-// 	}
-// 	b.container.Resources.Requests[name] = qty
-// 	return b
-// }
+// AddResourceRequest adds a resource request for the container
+func (b Builder) AddResourceRequest(name coreV1.ResourceName, qty resource.Quantity) Builder {
+	if b.obj.Resources.Requests == nil {
+		b.obj.Resources.Requests = make(coreV1.ResourceList)
+	}
+	b.obj.Resources.Requests[name] = qty
+	return b
+}
 
-// func (b *ContainerBuilder) VolumeMounts(mounts ...coreV1.VolumeMount) *ContainerBuilder {
-// 	b.container.VolumeMounts = mounts
-// 	return b
-// }
+// VolumeMounts sets the volumes mounted into the container's filesystem
+func (b Builder) VolumeMounts(mounts ...coreV1.VolumeMount) Builder {
+	b.obj.VolumeMounts = mounts
+	return b
+}
 
-// func (b *ContainerBuilder) AddVolumeMount(mount coreV1.VolumeMount) *ContainerBuilder {
-// 	b.container.VolumeMounts = append(b.container.VolumeMounts, mount)
-// 	return b
-// }
+// AddVolumeMount adds a volume mount to the container
+func (b Builder) AddVolumeMount(mount VolMountBuilder) Builder {
+	b.obj.VolumeMounts = append(b.obj.VolumeMounts, mount.T())
+	return b
+}
 
 // func (b *ContainerBuilder) VolumeDevices(devices ...coreV1.VolumeDevice) *ContainerBuilder {
 // 	b.container.VolumeDevices = devices
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func TestContainerStructured(t *testing.T) {
@@ -40,6 +42,43 @@ func TestContainerStructured(t *testing.T) {
 			builder:  Name("simple-name").Image("simple-container").WorkingDir("workdir"),
 			expected: coreV1.Container{Name: "simple-name", Image: "simple-container", WorkingDir: "workdir"},
 		},
+		"resource requests": {
+			builder:  Name("simple-name").ResourceRequests(coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("100m")}),
+			expected: coreV1.Container{Name: "simple-name", Resources: coreV1.ResourceRequirements{Requests: coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("100m")}}},
+		},
+		"add resource request": {
+			builder:  Name("simple-name").AddResourceRequest(coreV1.ResourceMemory, resource.MustParse("64Mi")),
+			expected: coreV1.Container{Name: "simple-name", Resources: coreV1.ResourceRequirements{Requests: coreV1.ResourceList{coreV1.ResourceMemory: resource.MustParse("64Mi")}}},
+		},
+		"volume mounts": {
+			builder:  Name("simple-name").AddVolumeMount(VolumeMount("data", "/data")).AddVolumeMount(VolumeMount("config", "/etc/app").ReadOnly(true)),
+			expected: coreV1.Container{Name: "simple-name", VolumeMounts: []coreV1.VolumeMount{{Name: "data", MountPath: "/data"}, {Name: "config", MountPath: "/etc/app", ReadOnly: true}}},
+		},
+		"generated setters": {
+			builder: Name("simple-name").ImagePullPolicy(coreV1.PullAlways).AddArg("arg1").AddPort(ContainerPort(80).Name("http")).
+				AddEnvFrom(coreV1.EnvFromSource{Prefix: "APP_"}).TTY(true),
+			expected: coreV1.Container{
+				Name:            "simple-name",
+				ImagePullPolicy: coreV1.PullAlways,
+				Args:            []string{"arg1"},
+				Ports:           []coreV1.ContainerPort{{Name: "http", ContainerPort: 80}},
+				EnvFrom:         []coreV1.EnvFromSource{{Prefix: "APP_"}},
+				TTY:             true,
+			},
+		},
+		"probe and security context builders": {
+			builder: Name("simple-name").
+				LivenessProbe(ProbeBuilder{}.HTTPGet(coreV1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt(8080)}).PeriodSeconds(10)).
+				SecurityContext(SecurityContextBuilder{}.RunAsNonRoot(true).RunAsUser(1000)),
+			expected: coreV1.Container{
+				Name: "simple-name",
+				LivenessProbe: &coreV1.Probe{
+					ProbeHandler:  coreV1.ProbeHandler{HTTPGet: &coreV1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt(8080)}},
+					PeriodSeconds: 10,
+				},
+				SecurityContext: &coreV1.SecurityContext{RunAsNonRoot: boolPtr(true), RunAsUser: int64Ptr(1000)},
+			},
+		},
 		"from unstructured": {
 			builder: func() Builder {
 				b, _ := FromUnstructured(map[string]any{"name": "simple-name", "image": "simple-container"})
@@ -65,3 +104,11 @@ func TestContainerStructured(t *testing.T) {
 		})
 	}
 }
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
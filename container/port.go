@@ -1,6 +1,7 @@
 package container
 
 import (
+	"github.com/vladimirvivien/kob/internal/unstruct"
 	coreV1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -44,11 +45,11 @@ func (b PortBuilder) HostIP(ip string) PortBuilder {
 
 // U returns an unstructured value of builder's object
 func (b PortBuilder) U() (map[string]any, error) {
-	unstruct, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&b.obj)
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&b.obj)
 	if err != nil {
 		return nil, err
 	}
-	return unstruct, nil
+	return unstruct.Prune(u), nil
 }
 
 // T returns a typed value of builder's object
@@ -1,5 +1,40 @@
 package container
 
+import (
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
 type VolMountBuilder struct {
 	obj coreV1.VolumeMount
 }
+
+// VolumeMount creates a new builder mounting the named volume at path
+func VolumeMount(name, path string) VolMountBuilder {
+	return VolMountBuilder{obj: coreV1.VolumeMount{Name: name, MountPath: path}}
+}
+
+func (b VolMountBuilder) ReadOnly(ro bool) VolMountBuilder {
+	b.obj.ReadOnly = ro
+	return b
+}
+
+func (b VolMountBuilder) SubPath(path string) VolMountBuilder {
+	b.obj.SubPath = path
+	return b
+}
+
+// U returns an unstructured value of builder's object
+func (b VolMountBuilder) U() (map[string]any, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&b.obj)
+	if err != nil {
+		return nil, err
+	}
+	return unstruct.Prune(u), nil
+}
+
+// T returns a typed value of builder's object
+func (b VolMountBuilder) T() coreV1.VolumeMount {
+	return b.obj
+}
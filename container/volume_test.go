@@ -0,0 +1,37 @@
+package container
+
+import (
+	"reflect"
+	"testing"
+
+	coreV1 "k8s.io/api/core/v1"
+)
+
+func TestVolumeMount(t *testing.T) {
+	tests := map[string]struct {
+		builder  VolMountBuilder
+		expected coreV1.VolumeMount
+	}{
+		"empty mount": {
+			builder:  VolMountBuilder{},
+			expected: coreV1.VolumeMount{},
+		},
+		"name and path": {
+			builder:  VolumeMount("data", "/var/lib/data"),
+			expected: coreV1.VolumeMount{Name: "data", MountPath: "/var/lib/data"},
+		},
+		"all": {
+			builder:  VolumeMount("config", "/etc/app").ReadOnly(true).SubPath("app.yaml"),
+			expected: coreV1.VolumeMount{Name: "config", MountPath: "/etc/app", ReadOnly: true, SubPath: "app.yaml"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mount := test.builder.T()
+			if !reflect.DeepEqual(mount, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", mount, test.expected)
+			}
+		})
+	}
+}
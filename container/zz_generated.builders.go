@@ -0,0 +1,343 @@
+// Code generated by kob-builder-gen. DO NOT EDIT.
+
+package container
+
+import (
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Name sets the name field
+func (b Builder) Name(v string) Builder {
+	b.obj.Name = v
+	return b
+}
+
+// AddCommand adds v to the command field
+func (b Builder) AddCommand(v string) Builder {
+	b.obj.Command = append(b.obj.Command, v)
+	return b
+}
+
+// AddArg adds v to the args field
+func (b Builder) AddArg(v string) Builder {
+	b.obj.Args = append(b.obj.Args, v)
+	return b
+}
+
+// AddPort adds the value built by v to the ports field
+func (b Builder) AddPort(v PortBuilder) Builder {
+	b.obj.Ports = append(b.obj.Ports, v.T())
+	return b
+}
+
+// AddEnvFrom adds v to the envFrom field
+func (b Builder) AddEnvFrom(v coreV1.EnvFromSource) Builder {
+	b.obj.EnvFrom = append(b.obj.EnvFrom, v)
+	return b
+}
+
+// Resources sets the resources field
+func (b Builder) Resources(v coreV1.ResourceRequirements) Builder {
+	b.obj.Resources = v
+	return b
+}
+
+// ResizePolicy sets the resizePolicy field
+func (b Builder) ResizePolicy(vals ...coreV1.ContainerResizePolicy) Builder {
+	b.obj.ResizePolicy = vals
+	return b
+}
+
+// AddResizePolicy adds v to the resizePolicy field
+func (b Builder) AddResizePolicy(v coreV1.ContainerResizePolicy) Builder {
+	b.obj.ResizePolicy = append(b.obj.ResizePolicy, v)
+	return b
+}
+
+// RestartPolicy sets the restartPolicy field
+func (b Builder) RestartPolicy(v coreV1.ContainerRestartPolicy) Builder {
+	b.obj.RestartPolicy = &v
+	return b
+}
+
+// VolumeDevices sets the volumeDevices field
+func (b Builder) VolumeDevices(vals ...coreV1.VolumeDevice) Builder {
+	b.obj.VolumeDevices = vals
+	return b
+}
+
+// AddVolumeDevice adds v to the volumeDevices field
+func (b Builder) AddVolumeDevice(v coreV1.VolumeDevice) Builder {
+	b.obj.VolumeDevices = append(b.obj.VolumeDevices, v)
+	return b
+}
+
+// LivenessProbe sets the livenessProbe field to the value built by v
+func (b Builder) LivenessProbe(v ProbeBuilder) Builder {
+	val := v.T()
+	b.obj.LivenessProbe = &val
+	return b
+}
+
+// ReadinessProbe sets the readinessProbe field to the value built by v
+func (b Builder) ReadinessProbe(v ProbeBuilder) Builder {
+	val := v.T()
+	b.obj.ReadinessProbe = &val
+	return b
+}
+
+// StartupProbe sets the startupProbe field to the value built by v
+func (b Builder) StartupProbe(v ProbeBuilder) Builder {
+	val := v.T()
+	b.obj.StartupProbe = &val
+	return b
+}
+
+// Lifecycle sets the lifecycle field
+func (b Builder) Lifecycle(v coreV1.Lifecycle) Builder {
+	b.obj.Lifecycle = &v
+	return b
+}
+
+// TerminationMessagePath sets the terminationMessagePath field
+func (b Builder) TerminationMessagePath(v string) Builder {
+	b.obj.TerminationMessagePath = v
+	return b
+}
+
+// TerminationMessagePolicy sets the terminationMessagePolicy field
+func (b Builder) TerminationMessagePolicy(v coreV1.TerminationMessagePolicy) Builder {
+	b.obj.TerminationMessagePolicy = v
+	return b
+}
+
+// ImagePullPolicy sets the imagePullPolicy field
+func (b Builder) ImagePullPolicy(v coreV1.PullPolicy) Builder {
+	b.obj.ImagePullPolicy = v
+	return b
+}
+
+// SecurityContext sets the securityContext field to the value built by v
+func (b Builder) SecurityContext(v SecurityContextBuilder) Builder {
+	val := v.T()
+	b.obj.SecurityContext = &val
+	return b
+}
+
+// Stdin sets the stdin field
+func (b Builder) Stdin(v bool) Builder {
+	b.obj.Stdin = v
+	return b
+}
+
+// StdinOnce sets the stdinOnce field
+func (b Builder) StdinOnce(v bool) Builder {
+	b.obj.StdinOnce = v
+	return b
+}
+
+// TTY sets the tty field
+func (b Builder) TTY(v bool) Builder {
+	b.obj.TTY = v
+	return b
+}
+
+// Name sets the name field
+func (b VolMountBuilder) Name(v string) VolMountBuilder {
+	b.obj.Name = v
+	return b
+}
+
+// MountPath sets the mountPath field
+func (b VolMountBuilder) MountPath(v string) VolMountBuilder {
+	b.obj.MountPath = v
+	return b
+}
+
+// MountPropagation sets the mountPropagation field
+func (b VolMountBuilder) MountPropagation(v coreV1.MountPropagationMode) VolMountBuilder {
+	b.obj.MountPropagation = &v
+	return b
+}
+
+// SubPathExpr sets the subPathExpr field
+func (b VolMountBuilder) SubPathExpr(v string) VolMountBuilder {
+	b.obj.SubPathExpr = v
+	return b
+}
+
+// ProbeBuilder provides a way to build values of type coreV1.Probe
+type ProbeBuilder struct {
+	obj coreV1.Probe
+}
+
+// ProbeFrom creates a new builder using the provided object
+func ProbeFrom(obj coreV1.Probe) ProbeBuilder {
+	return ProbeBuilder{obj: obj}
+}
+
+// U returns an unstructured value of builder's object
+func (b ProbeBuilder) U() (map[string]any, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&b.obj)
+	if err != nil {
+		return nil, err
+	}
+	return unstruct.Prune(u), nil
+}
+
+// T returns a typed value of builder's object
+func (b ProbeBuilder) T() coreV1.Probe {
+	return b.obj
+}
+
+// Exec sets the exec field
+func (b ProbeBuilder) Exec(v coreV1.ExecAction) ProbeBuilder {
+	b.obj.Exec = &v
+	return b
+}
+
+// HTTPGet sets the httpGet field
+func (b ProbeBuilder) HTTPGet(v coreV1.HTTPGetAction) ProbeBuilder {
+	b.obj.HTTPGet = &v
+	return b
+}
+
+// TCPSocket sets the tcpSocket field
+func (b ProbeBuilder) TCPSocket(v coreV1.TCPSocketAction) ProbeBuilder {
+	b.obj.TCPSocket = &v
+	return b
+}
+
+// GRPC sets the grpc field
+func (b ProbeBuilder) GRPC(v coreV1.GRPCAction) ProbeBuilder {
+	b.obj.GRPC = &v
+	return b
+}
+
+// InitialDelaySeconds sets the initialDelaySeconds field
+func (b ProbeBuilder) InitialDelaySeconds(v int32) ProbeBuilder {
+	b.obj.InitialDelaySeconds = v
+	return b
+}
+
+// TimeoutSeconds sets the timeoutSeconds field
+func (b ProbeBuilder) TimeoutSeconds(v int32) ProbeBuilder {
+	b.obj.TimeoutSeconds = v
+	return b
+}
+
+// PeriodSeconds sets the periodSeconds field
+func (b ProbeBuilder) PeriodSeconds(v int32) ProbeBuilder {
+	b.obj.PeriodSeconds = v
+	return b
+}
+
+// SuccessThreshold sets the successThreshold field
+func (b ProbeBuilder) SuccessThreshold(v int32) ProbeBuilder {
+	b.obj.SuccessThreshold = v
+	return b
+}
+
+// FailureThreshold sets the failureThreshold field
+func (b ProbeBuilder) FailureThreshold(v int32) ProbeBuilder {
+	b.obj.FailureThreshold = v
+	return b
+}
+
+// TerminationGracePeriodSeconds sets the terminationGracePeriodSeconds field
+func (b ProbeBuilder) TerminationGracePeriodSeconds(v int64) ProbeBuilder {
+	b.obj.TerminationGracePeriodSeconds = &v
+	return b
+}
+
+// SecurityContextBuilder provides a way to build values of type coreV1.SecurityContext
+type SecurityContextBuilder struct {
+	obj coreV1.SecurityContext
+}
+
+// SecurityContextFrom creates a new builder using the provided object
+func SecurityContextFrom(obj coreV1.SecurityContext) SecurityContextBuilder {
+	return SecurityContextBuilder{obj: obj}
+}
+
+// U returns an unstructured value of builder's object
+func (b SecurityContextBuilder) U() (map[string]any, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&b.obj)
+	if err != nil {
+		return nil, err
+	}
+	return unstruct.Prune(u), nil
+}
+
+// T returns a typed value of builder's object
+func (b SecurityContextBuilder) T() coreV1.SecurityContext {
+	return b.obj
+}
+
+// Capabilities sets the capabilities field
+func (b SecurityContextBuilder) Capabilities(v coreV1.Capabilities) SecurityContextBuilder {
+	b.obj.Capabilities = &v
+	return b
+}
+
+// Privileged sets the privileged field
+func (b SecurityContextBuilder) Privileged(v bool) SecurityContextBuilder {
+	b.obj.Privileged = &v
+	return b
+}
+
+// SELinuxOptions sets the seLinuxOptions field
+func (b SecurityContextBuilder) SELinuxOptions(v coreV1.SELinuxOptions) SecurityContextBuilder {
+	b.obj.SELinuxOptions = &v
+	return b
+}
+
+// WindowsOptions sets the windowsOptions field
+func (b SecurityContextBuilder) WindowsOptions(v coreV1.WindowsSecurityContextOptions) SecurityContextBuilder {
+	b.obj.WindowsOptions = &v
+	return b
+}
+
+// RunAsUser sets the runAsUser field
+func (b SecurityContextBuilder) RunAsUser(v int64) SecurityContextBuilder {
+	b.obj.RunAsUser = &v
+	return b
+}
+
+// RunAsGroup sets the runAsGroup field
+func (b SecurityContextBuilder) RunAsGroup(v int64) SecurityContextBuilder {
+	b.obj.RunAsGroup = &v
+	return b
+}
+
+// RunAsNonRoot sets the runAsNonRoot field
+func (b SecurityContextBuilder) RunAsNonRoot(v bool) SecurityContextBuilder {
+	b.obj.RunAsNonRoot = &v
+	return b
+}
+
+// ReadOnlyRootFilesystem sets the readOnlyRootFilesystem field
+func (b SecurityContextBuilder) ReadOnlyRootFilesystem(v bool) SecurityContextBuilder {
+	b.obj.ReadOnlyRootFilesystem = &v
+	return b
+}
+
+// AllowPrivilegeEscalation sets the allowPrivilegeEscalation field
+func (b SecurityContextBuilder) AllowPrivilegeEscalation(v bool) SecurityContextBuilder {
+	b.obj.AllowPrivilegeEscalation = &v
+	return b
+}
+
+// ProcMount sets the procMount field
+func (b SecurityContextBuilder) ProcMount(v coreV1.ProcMountType) SecurityContextBuilder {
+	b.obj.ProcMount = &v
+	return b
+}
+
+// SeccompProfile sets the seccompProfile field
+func (b SecurityContextBuilder) SeccompProfile(v coreV1.SeccompProfile) SecurityContextBuilder {
+	b.obj.SeccompProfile = &v
+	return b
+}
@@ -0,0 +1,148 @@
+// Package cronjob contains builder types to build values of type batchV1.CronJob
+package cronjob
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/job"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	batchV1 "k8s.io/api/batch/v1"
+)
+
+// Builder provides a way to build values of type batchV1.CronJob
+type Builder map[string]interface{}
+
+// Object starts a new CronJob builder using the provided object metadata
+func Object(metadata objmeta.Builder) Builder {
+	meta, err := metadata.U()
+	return Builder(unstruct.SetErr(map[string]interface{}{"metadata": meta}, err))
+}
+
+// U returns the unstructured value of the builder
+func (b Builder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b Builder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder. An error is returned if the
+// name is not a valid CronJob name, the schedule is missing or is not a valid
+// cron expression, the time zone is unknown, or the spec of the job template
+// is invalid.
+func (b Builder) T() (batchV1.CronJob, error) {
+	var cj batchV1.CronJob
+	if err := unstruct.FromUnstructured(b, &cj); err != nil {
+		return batchV1.CronJob{}, err
+	}
+	if err := naming.Validate("CronJob", cj.Name); err != nil {
+		return batchV1.CronJob{}, err
+	}
+	if err := validate(cj.Spec); err != nil {
+		return batchV1.CronJob{}, err
+	}
+	// the spec of the job template is validated as the spec of a Job, its
+	// metadata is not: the jobs are named after the cron job
+	if tmpl, ok := b.getCronJobSpec()["jobTemplate"].(map[string]interface{}); ok {
+		if spec, ok := tmpl["spec"]; ok {
+			if _, err := job.Builder(map[string]interface{}{"spec": spec}).T(); err != nil {
+				return batchV1.CronJob{}, fmt.Errorf("cronjob: job template: %w", err)
+			}
+		}
+	}
+	return cj, nil
+}
+
+// Schedule sets the schedule of the cron job in standard cron format
+// (i.e. "*/5 * * * *")
+func (b Builder) Schedule(sched string) Builder {
+	return b.setSpec("schedule", sched)
+}
+
+// TimeZone sets the name of the time zone (i.e. "Etc/UTC") used to
+// interpret the schedule
+func (b Builder) TimeZone(tz string) Builder {
+	return b.setSpec("timeZone", tz)
+}
+
+// ConcurrencyPolicy sets how concurrent executions of the job are treated
+func (b Builder) ConcurrencyPolicy(pol batchV1.ConcurrencyPolicy) Builder {
+	return b.setSpec("concurrencyPolicy", pol)
+}
+
+// Suspend sets whether subsequent executions are suspended
+func (b Builder) Suspend(s bool) Builder {
+	return b.setSpec("suspend", s)
+}
+
+// SuccessfulJobsHistoryLimit sets the number of successful finished jobs to retain
+func (b Builder) SuccessfulJobsHistoryLimit(l int) Builder {
+	return b.setSpec("successfulJobsHistoryLimit", int64(l))
+}
+
+// FailedJobsHistoryLimit sets the number of failed finished jobs to retain
+func (b Builder) FailedJobsHistoryLimit(l int) Builder {
+	return b.setSpec("failedJobsHistoryLimit", int64(l))
+}
+
+// StartingDeadlineSeconds sets the deadline, in seconds, for starting the job
+// if it misses its scheduled time
+func (b Builder) StartingDeadlineSeconds(s int) Builder {
+	return b.setSpec("startingDeadlineSeconds", int64(s))
+}
+
+// JobTemplate sets the job created on each execution using a copy of the
+// metadata and spec of the provided job builder, so later changes to the job
+// builder do not affect the cron job. An error recorded on the job builder is
+// recorded on the cron job.
+func (b Builder) JobTemplate(jb job.Builder) Builder {
+	unstruct.SetErr(b, jb.Err())
+	job := unstruct.DeepCopy(unstruct.Nest(jb))
+	tmpl := map[string]interface{}{}
+	for _, key := range []string{"metadata", "spec"} {
+		if val, ok := job[key]; ok {
+			tmpl[key] = val
+		}
+	}
+	return b.setSpec("jobTemplate", tmpl)
+}
+
+func (b Builder) setSpec(key string, val interface{}) Builder {
+	spec := b.getCronJobSpec()
+	spec[key] = val
+	b["spec"] = spec
+	return b
+}
+
+func (b Builder) getCronJobSpec() map[string]interface{} {
+	specIface, ok := b["spec"]
+	if !ok {
+		specIface = map[string]interface{}{}
+	}
+	return specIface.(map[string]interface{})
+}
+
+func validate(spec batchV1.CronJobSpec) error {
+	if strings.TrimSpace(spec.Schedule) == "" {
+		return fmt.Errorf("cronjob: missing schedule")
+	}
+	if _, err := cron.ParseStandard(spec.Schedule); err != nil {
+		return fmt.Errorf("cronjob: invalid schedule %q: %w", spec.Schedule, err)
+	}
+	if spec.TimeZone != nil {
+		if strings.Contains(spec.Schedule, "TZ") {
+			return fmt.Errorf("cronjob: schedule %q cannot specify a time zone when timeZone is set", spec.Schedule)
+		}
+		if _, err := time.LoadLocation(*spec.TimeZone); err != nil {
+			return fmt.Errorf("cronjob: invalid time zone %q: %w", *spec.TimeZone, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package cronjob
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vladimirvivien/kob/container"
+	"github.com/vladimirvivien/kob/job"
+	"github.com/vladimirvivien/kob/objmeta"
+	batchV1 "k8s.io/api/batch/v1"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCronJobTyped(t *testing.T) {
+	tests := map[string]struct {
+		builder  Builder
+		expected batchV1.CronJob
+	}{
+		"object meta and schedule": {
+			builder:  Object(objmeta.Name("simple-cron").Namespace("default")).Schedule("@daily"),
+			expected: batchV1.CronJob{ObjectMeta: metaV1.ObjectMeta{Name: "simple-cron", Namespace: "default"}, Spec: batchV1.CronJobSpec{Schedule: "@daily"}},
+		},
+		"with schedule and policies": {
+			builder: Object(objmeta.Name("simple-cron")).Schedule("*/5 * * * *").TimeZone("Etc/UTC").
+				ConcurrencyPolicy(batchV1.ForbidConcurrent).Suspend(true).
+				SuccessfulJobsHistoryLimit(1).FailedJobsHistoryLimit(2).StartingDeadlineSeconds(100),
+			expected: batchV1.CronJob{
+				ObjectMeta: metaV1.ObjectMeta{Name: "simple-cron"},
+				Spec: batchV1.CronJobSpec{
+					Schedule:                   "*/5 * * * *",
+					TimeZone:                   stringPtr("Etc/UTC"),
+					ConcurrencyPolicy:          batchV1.ForbidConcurrent,
+					Suspend:                    boolPtr(true),
+					SuccessfulJobsHistoryLimit: int32Ptr(1),
+					FailedJobsHistoryLimit:     int32Ptr(2),
+					StartingDeadlineSeconds:    int64Ptr(100),
+				},
+			},
+		},
+		"with job template": {
+			builder: Object(objmeta.Name("simple-cron")).Schedule("@hourly").
+				JobTemplate(job.Object(objmeta.Name("simple-job")).BackoffLimit(2).PodSpec(container.Name("simple-container"))),
+			expected: batchV1.CronJob{
+				ObjectMeta: metaV1.ObjectMeta{Name: "simple-cron"},
+				Spec: batchV1.CronJobSpec{
+					Schedule: "@hourly",
+					JobTemplate: batchV1.JobTemplateSpec{
+						ObjectMeta: metaV1.ObjectMeta{Name: "simple-job"},
+						Spec: batchV1.JobSpec{
+							BackoffLimit: int32Ptr(2),
+							Template: coreV1.PodTemplateSpec{
+								Spec: coreV1.PodSpec{
+									Containers:    []coreV1.Container{{Name: "simple-container"}},
+									RestartPolicy: coreV1.RestartPolicyOnFailure,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"job template named by the cron job": {
+			builder: Object(objmeta.Name("simple-cron")).Schedule("@hourly").
+				JobTemplate(job.Object(objmeta.Name("Simple_Job")).PodSpec(container.Name("simple-container"))),
+			expected: batchV1.CronJob{
+				ObjectMeta: metaV1.ObjectMeta{Name: "simple-cron"},
+				Spec: batchV1.CronJobSpec{
+					Schedule: "@hourly",
+					JobTemplate: batchV1.JobTemplateSpec{
+						ObjectMeta: metaV1.ObjectMeta{Name: "Simple_Job"},
+						Spec: batchV1.JobSpec{
+							Template: coreV1.PodTemplateSpec{
+								Spec: coreV1.PodSpec{
+									Containers:    []coreV1.Container{{Name: "simple-container"}},
+									RestartPolicy: coreV1.RestartPolicyOnFailure,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cj, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(cj, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", cj, test.expected)
+			}
+		})
+	}
+}
+
+func TestCronJobTemplateCopy(t *testing.T) {
+	jb := job.Object(objmeta.Name("simple-job")).PodSpec(container.Name("simple-container")).RestartPolicy(coreV1.RestartPolicyNever)
+	cj := Object(objmeta.Name("simple-cron")).Schedule("@daily").JobTemplate(jb)
+	jb.RestartPolicy(coreV1.RestartPolicyAlways).PodSpec(container.Name("other-container"))
+
+	obj, err := cj.T()
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := obj.Spec.JobTemplate.Spec.Template.Spec
+	if spec.RestartPolicy != coreV1.RestartPolicyNever || len(spec.Containers) != 1 || spec.Containers[0].Name != "simple-container" {
+		t.Errorf("job template changed with the job builder: %#v", spec)
+	}
+}
+
+func TestCronJobInvalid(t *testing.T) {
+	tests := map[string]Builder{
+		"empty":                 Builder{},
+		"missing schedule":      Object(objmeta.Name("simple-cron")),
+		"blank schedule":        Object(objmeta.Name("simple-cron")).Schedule("  "),
+		"name too long":         Object(objmeta.Name(strings.Repeat("a", 53))).Schedule("@daily"),
+		"bad schedule":          Object(objmeta.Name("simple-cron")).Schedule("* * *"),
+		"bad schedule range":    Object(objmeta.Name("simple-cron")).Schedule("61 * * * *"),
+		"unknown time zone":     Object(objmeta.Name("simple-cron")).Schedule("@daily").TimeZone("Mars/Olympus"),
+		"time zone in schedule": Object(objmeta.Name("simple-cron")).Schedule("CRON_TZ=UTC 0 * * * *").TimeZone("Etc/UTC"),
+		"restart policy in template": Object(objmeta.Name("simple-cron")).Schedule("@daily").
+			JobTemplate(job.Object(objmeta.Name("simple-job")).PodSpec(container.Name("simple-container")).RestartPolicy(coreV1.RestartPolicyAlways)),
+		"invalid job template": Object(objmeta.Name("simple-cron")).Schedule("@daily").
+			JobTemplate(job.Object(objmeta.Name("simple-job").AddLabel("app", "invalid value"))),
+	}
+
+	for name, builder := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := builder.T(); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
@@ -0,0 +1,212 @@
+package kob
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/vladimirvivien/kob/admission"
+	"github.com/vladimirvivien/kob/apiextensions"
+	"github.com/vladimirvivien/kob/configmap"
+	"github.com/vladimirvivien/kob/cronjob"
+	"github.com/vladimirvivien/kob/deployment"
+	"github.com/vladimirvivien/kob/endpoints"
+	"github.com/vladimirvivien/kob/endpointslice"
+	"github.com/vladimirvivien/kob/gateway"
+	"github.com/vladimirvivien/kob/hpa"
+	"github.com/vladimirvivien/kob/ingress"
+	"github.com/vladimirvivien/kob/job"
+	"github.com/vladimirvivien/kob/lease"
+	"github.com/vladimirvivien/kob/limitrange"
+	"github.com/vladimirvivien/kob/namespace"
+	"github.com/vladimirvivien/kob/networkpolicy"
+	"github.com/vladimirvivien/kob/pdb"
+	"github.com/vladimirvivien/kob/pod"
+	"github.com/vladimirvivien/kob/priorityclass"
+	"github.com/vladimirvivien/kob/rbac"
+	"github.com/vladimirvivien/kob/replicaset"
+	"github.com/vladimirvivien/kob/resourcequota"
+	"github.com/vladimirvivien/kob/runtimeclass"
+	"github.com/vladimirvivien/kob/service"
+	"github.com/vladimirvivien/kob/serviceaccount"
+	"github.com/vladimirvivien/kob/storage"
+	"github.com/vladimirvivien/kob/unstructured"
+	admissionregV1 "k8s.io/api/admissionregistration/v1"
+	admissionregV1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	appsV1 "k8s.io/api/apps/v1"
+	autoscalingV2 "k8s.io/api/autoscaling/v2"
+	batchV1 "k8s.io/api/batch/v1"
+	coordinationV1 "k8s.io/api/coordination/v1"
+	coreV1 "k8s.io/api/core/v1"
+	discoveryV1 "k8s.io/api/discovery/v1"
+	networkingV1 "k8s.io/api/networking/v1"
+	nodeV1 "k8s.io/api/node/v1"
+	policyV1 "k8s.io/api/policy/v1"
+	rbacV1 "k8s.io/api/rbac/v1"
+	schedulingV1 "k8s.io/api/scheduling/v1"
+	storageV1 "k8s.io/api/storage/v1"
+	apiextV1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	unstructuredV1 "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// builders maps the kinds built by kob to a function returning their builder.
+// Objects are converted to their typed value, then passed to the From
+// constructor of their package or, for packages without one, converted back
+// to their builder. Builders do not store the apiVersion and kind (see
+// Manifest), which are removed.
+var builders = map[schema.GroupVersionKind]func(map[string]interface{}) (any, error){
+	gvk("v1", "Pod"):                   from(pod.From),
+	gvk("v1", "Service"):               from(service.From),
+	gvk("v1", "ConfigMap"):             from(configmap.From),
+	gvk("v1", "Namespace"):             typed[coreV1.Namespace, namespace.Builder],
+	gvk("v1", "ServiceAccount"):        typed[coreV1.ServiceAccount, serviceaccount.Builder],
+	gvk("v1", "ResourceQuota"):         typed[coreV1.ResourceQuota, resourcequota.Builder],
+	gvk("v1", "LimitRange"):            typed[coreV1.LimitRange, limitrange.Builder],
+	gvk("v1", "Endpoints"):             typed[coreV1.Endpoints, endpoints.Builder],
+	gvk("v1", "PersistentVolume"):      typed[coreV1.PersistentVolume, storage.VolumeBuilder],
+	gvk("v1", "PersistentVolumeClaim"): typed[coreV1.PersistentVolumeClaim, storage.ClaimBuilder],
+
+	gvk("apps/v1", "Deployment"): from(deployment.From),
+	gvk("apps/v1", "ReplicaSet"): typed[appsV1.ReplicaSet, replicaset.Builder],
+
+	gvk("batch/v1", "Job"):     typed[batchV1.Job, job.Builder],
+	gvk("batch/v1", "CronJob"): typed[batchV1.CronJob, cronjob.Builder],
+
+	gvk("autoscaling/v2", "HorizontalPodAutoscaler"): typed[autoscalingV2.HorizontalPodAutoscaler, hpa.Builder],
+
+	gvk("policy/v1", "PodDisruptionBudget"): typed[policyV1.PodDisruptionBudget, pdb.Builder],
+
+	gvk("networking.k8s.io/v1", "NetworkPolicy"): typed[networkingV1.NetworkPolicy, networkpolicy.Builder],
+	gvk("networking.k8s.io/v1", "Ingress"):       typed[networkingV1.Ingress, ingress.Builder],
+
+	gvk("rbac.authorization.k8s.io/v1", "Role"):               typed[rbacV1.Role, rbac.RoleBuilder],
+	gvk("rbac.authorization.k8s.io/v1", "ClusterRole"):        typed[rbacV1.ClusterRole, rbac.ClusterRoleBuilder],
+	gvk("rbac.authorization.k8s.io/v1", "RoleBinding"):        typed[rbacV1.RoleBinding, rbac.RoleBindingBuilder],
+	gvk("rbac.authorization.k8s.io/v1", "ClusterRoleBinding"): typed[rbacV1.ClusterRoleBinding, rbac.ClusterRoleBindingBuilder],
+
+	gvk("storage.k8s.io/v1", "StorageClass"): typed[storageV1.StorageClass, storage.ClassBuilder],
+
+	gvk("scheduling.k8s.io/v1", "PriorityClass"): typed[schedulingV1.PriorityClass, priorityclass.Builder],
+
+	gvk("node.k8s.io/v1", "RuntimeClass"): typed[nodeV1.RuntimeClass, runtimeclass.Builder],
+
+	gvk("coordination.k8s.io/v1", "Lease"): typed[coordinationV1.Lease, lease.Builder],
+
+	gvk("discovery.k8s.io/v1", "EndpointSlice"): typed[discoveryV1.EndpointSlice, endpointslice.Builder],
+
+	gvk("apiextensions.k8s.io/v1", "CustomResourceDefinition"): typed[apiextV1.CustomResourceDefinition, apiextensions.Builder],
+
+	gvk("admissionregistration.k8s.io/v1", "ValidatingWebhookConfiguration"):        typed[admissionregV1.ValidatingWebhookConfiguration, admission.ValidatingBuilder],
+	gvk("admissionregistration.k8s.io/v1", "MutatingWebhookConfiguration"):          typed[admissionregV1.MutatingWebhookConfiguration, admission.MutatingBuilder],
+	gvk("admissionregistration.k8s.io/v1beta1", "ValidatingAdmissionPolicy"):        typed[admissionregV1beta1.ValidatingAdmissionPolicy, admission.PolicyBuilder],
+	gvk("admissionregistration.k8s.io/v1beta1", "ValidatingAdmissionPolicyBinding"): typed[admissionregV1beta1.ValidatingAdmissionPolicyBinding, admission.BindingBuilder],
+}
+
+// gatewayBuilders maps the Gateway API kinds, of any version, to a function
+// returning their builder. The builders keep the apiVersion and kind.
+var gatewayBuilders = map[string]func(map[string]interface{}) any{
+	"Gateway":        as[gateway.GatewayBuilder],
+	"HTTPRoute":      as[gateway.HTTPRouteBuilder],
+	"GRPCRoute":      as[gateway.GRPCRouteBuilder],
+	"ReferenceGrant": as[gateway.ReferenceGrantBuilder],
+}
+
+// Decode reads a stream of YAML or JSON documents from r and returns a builder
+// for each object, in order. Objects of the kinds built by kob are returned as
+// their builder (i.e. deployment.Builder) and other objects as an
+// unstructured.Builder. The items of lists (kind: List) are returned as objects.
+// An error is returned for objects that cannot be converted to their typed value.
+func Decode(r io.Reader) ([]any, error) {
+	decoder := yaml.NewYAMLOrJSONDecoder(r, 4096)
+	var objs []any
+	for i := 0; ; i++ {
+		var raw runtime.RawExtension
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return objs, nil
+			}
+			return nil, fmt.Errorf("kob: document %d: %w", i, err)
+		}
+		if len(raw.Raw) == 0 || string(raw.Raw) == "null" {
+			continue
+		}
+		obj, _, err := unstructuredV1.UnstructuredJSONScheme.Decode(raw.Raw, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("kob: document %d: %w", i, err)
+		}
+		switch o := obj.(type) {
+		case *unstructuredV1.UnstructuredList:
+			for _, item := range o.Items {
+				b, err := builderOf(item)
+				if err != nil {
+					return nil, fmt.Errorf("kob: document %d: %w", i, err)
+				}
+				objs = append(objs, b)
+			}
+		case *unstructuredV1.Unstructured:
+			b, err := builderOf(*o)
+			if err != nil {
+				return nil, fmt.Errorf("kob: document %d: %w", i, err)
+			}
+			objs = append(objs, b)
+		}
+	}
+}
+
+// gvk returns the group, version and kind of the apiVersion and kind
+func gvk(apiVersion, kind string) schema.GroupVersionKind {
+	return schema.FromAPIVersionAndKind(apiVersion, kind)
+}
+
+// as returns the unstructured value u as a builder of type B
+func as[B ~map[string]interface{}](u map[string]interface{}) any {
+	return B(u)
+}
+
+// from returns a function converting unstructured values to the typed
+// object T, passed to the From constructor of the builder (i.e. pod.From)
+func from[T any, B any](constructor func(T) B) func(map[string]interface{}) (any, error) {
+	return func(u map[string]interface{}) (any, error) {
+		var obj T
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u, &obj); err != nil {
+			return nil, err
+		}
+		return constructor(obj), nil
+	}
+}
+
+// typed converts the unstructured value u to the typed object T and back to a
+// builder of type B, for the builders without a From constructor
+func typed[T any, B ~map[string]interface{}](u map[string]interface{}) (any, error) {
+	var obj T
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u, &obj); err != nil {
+		return nil, err
+	}
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&obj)
+	if err != nil {
+		return nil, err
+	}
+	return B(u), nil
+}
+
+// builderOf returns the builder of the object's kind
+func builderOf(obj unstructuredV1.Unstructured) (any, error) {
+	gvk := obj.GroupVersionKind()
+	if builder, ok := builders[gvk]; ok {
+		u := obj.Object
+		delete(u, "apiVersion")
+		delete(u, "kind")
+		b, err := builder(u)
+		if err != nil {
+			return nil, fmt.Errorf("%s %q: %w", gvk.Kind, obj.GetName(), err)
+		}
+		return b, nil
+	}
+	if builder, ok := gatewayBuilders[gvk.Kind]; ok && gvk.Group == gateway.Group {
+		return builder(obj.Object), nil
+	}
+	return unstructured.From(obj), nil
+}
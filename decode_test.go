@@ -0,0 +1,204 @@
+package kob
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vladimirvivien/kob/configmap"
+	"github.com/vladimirvivien/kob/container"
+	"github.com/vladimirvivien/kob/deployment"
+	"github.com/vladimirvivien/kob/gateway"
+	"github.com/vladimirvivien/kob/networkpolicy"
+	"github.com/vladimirvivien/kob/objmeta"
+	"github.com/vladimirvivien/kob/service"
+	"github.com/vladimirvivien/kob/unstructured"
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const manifests = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: shop
+spec:
+  replicas: 2
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        image: nginx
+---
+# comments and empty documents are skipped
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web-config
+data:
+  mode: fast
+---
+apiVersion: gateway.networking.k8s.io/v1
+kind: Gateway
+metadata:
+  name: edge
+spec:
+  gatewayClassName: nginx
+  listeners:
+  - name: http
+    protocol: HTTP
+    port: 80
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Service
+  metadata:
+    name: web
+  spec:
+    selector:
+      app: web
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: web
+spec:
+  size: 3
+`
+
+func TestDecode(t *testing.T) {
+	objs, err := Decode(strings.NewReader(manifests))
+	if err != nil {
+		t.Fatalf("failed to decode: %s", err)
+	}
+	if len(objs) != 5 {
+		t.Fatalf("expecting 5 objects, got %d", len(objs))
+	}
+
+	dep, ok := objs[0].(deployment.Builder)
+	if !ok {
+		t.Fatalf("expecting deployment.Builder, got %T", objs[0])
+	}
+	depObj, err := dep.Replicas(3).T()
+	if err != nil {
+		t.Fatalf("failed to convert to typed value: %s", err)
+	}
+	expectedDep := appsV1.Deployment{
+		ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "shop"},
+		Spec: appsV1.DeploymentSpec{
+			Replicas: int32Ptr(3),
+			Selector: &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			Template: coreV1.PodTemplateSpec{
+				ObjectMeta: metaV1.ObjectMeta{Labels: map[string]string{"app": "web"}},
+				Spec:       coreV1.PodSpec{Containers: []coreV1.Container{{Name: "web", Image: "nginx"}}},
+			},
+		},
+	}
+	if !reflect.DeepEqual(depObj, expectedDep) {
+		t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", depObj, expectedDep)
+	}
+
+	cm, ok := objs[1].(configmap.Builder)
+	if !ok {
+		t.Fatalf("expecting configmap.Builder, got %T", objs[1])
+	}
+	cmObj, err := cm.T()
+	if err != nil {
+		t.Fatalf("failed to convert to typed value: %s", err)
+	}
+	if cmObj.Name != "web-config" || cmObj.Data["mode"] != "fast" {
+		t.Errorf("unexpected config map: %#v", cmObj)
+	}
+
+	gw, ok := objs[2].(gateway.GatewayBuilder)
+	if !ok {
+		t.Fatalf("expecting gateway.GatewayBuilder, got %T", objs[2])
+	}
+	if _, err := gw.T(); err != nil {
+		t.Errorf("failed to convert to unstructured value: %s", err)
+	}
+
+	svc, ok := objs[3].(service.Builder)
+	if !ok {
+		t.Fatalf("expecting service.Builder, got %T", objs[3])
+	}
+	svcObj, err := svc.T()
+	if err != nil {
+		t.Fatalf("failed to convert to typed value: %s", err)
+	}
+	if svcObj.Name != "web" || svcObj.Spec.Selector["app"] != "web" {
+		t.Errorf("unexpected service: %#v", svcObj)
+	}
+
+	widget, ok := objs[4].(unstructured.Builder)
+	if !ok {
+		t.Fatalf("expecting unstructured.Builder, got %T", objs[4])
+	}
+	if u, err := widget.T(); err != nil || u.GetKind() != "Widget" || u.GetName() != "web" {
+		t.Errorf("unexpected object: %#v, %v", u, err)
+	}
+}
+
+func TestDecodeRoundTrip(t *testing.T) {
+	web := map[string]string{"app": "web"}
+	objs := []any{
+		deployment.Object(objmeta.Name("web").Namespace("shop")).Replicas(2).Selector(web).
+			PodSpecWithMetadata(objmeta.Name("").Labels(web), container.WithNameAndImage("web", "nginx")),
+		networkpolicy.DenyAll("shop"),
+		gateway.Gateway(objmeta.Name("edge"), "nginx").AddListener(gateway.Listener("http", gateway.HTTP, 80)),
+	}
+
+	for _, encode := range []func(*bytes.Buffer, ...any) error{
+		func(buf *bytes.Buffer, objs ...any) error { return YAML(buf, objs...) },
+		func(buf *bytes.Buffer, objs ...any) error { return JSON(buf, objs...) },
+	} {
+		var first bytes.Buffer
+		if err := encode(&first, objs...); err != nil {
+			t.Fatalf("failed to encode: %s", err)
+		}
+		decoded, err := Decode(bytes.NewReader(first.Bytes()))
+		if err != nil {
+			t.Fatalf("failed to decode: %s", err)
+		}
+		var second bytes.Buffer
+		if err := encode(&second, decoded...); err != nil {
+			t.Fatalf("failed to encode decoded objects: %s", err)
+		}
+		if first.String() != second.String() {
+			t.Errorf("manifest not equal \n\n Encoded:\n%s \n\n Expected:\n%s", second.String(), first.String())
+		}
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	tests := map[string]string{
+		"invalid yaml":  "kind: [Deployment",
+		"missing kind":  "apiVersion: v1\nmetadata:\n  name: web\n",
+		"invalid field": "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\nspec:\n  replicas: two\n",
+		"invalid item":  "apiVersion: v1\nkind: List\nitems:\n- apiVersion: v1\n  kind: ConfigMap\n  metadata:\n    name: web\n  data: [fast]\n",
+	}
+
+	for name, manifest := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Decode(strings.NewReader(manifest)); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
@@ -1,26 +1,69 @@
 package deployment
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/vladimirvivien/kob/container"
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/internal/workload"
+	"github.com/vladimirvivien/kob/naming"
 	"github.com/vladimirvivien/kob/objmeta"
 	"github.com/vladimirvivien/kob/pod"
+	"github.com/vladimirvivien/kob/selector"
 	appsV1 "k8s.io/api/apps/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
 )
 
 type Builder map[string]interface{}
 
 func Object(metadata objmeta.Builder) Builder {
-	return Builder{"metadata": metadata.U()}
+	meta, err := metadata.U()
+	return Builder(unstruct.SetErr(map[string]interface{}{"metadata": meta}, err))
+}
+
+// From creates a new builder using the provided deployment as its base
+func From(obj appsV1.Deployment) Builder {
+	u, _ := runtime.DefaultUnstructuredConverter.ToUnstructured(&obj)
+	return Builder(u)
+}
+
+// FromUnstructured creates a builder from an unstructured value, which
+// must be convertible to an appsV1.Deployment
+func FromUnstructured(unstruct map[string]any) (Builder, error) {
+	var obj appsV1.Deployment
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstruct, &obj); err != nil {
+		return Builder{}, err
+	}
+	return From(obj), nil
+}
+
+// FromString creates a builder from a valid YAML or JSON deployment manifest
+func FromString(str string) (Builder, error) {
+	var obj appsV1.Deployment
+	if err := yaml.NewYAMLOrJSONDecoder(strings.NewReader(str), 1024).Decode(&obj); err != nil {
+		return Builder{}, err
+	}
+	return From(obj), nil
 }
 
 func (b Builder) U() map[string]interface{} {
-	return b
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b Builder) Err() error {
+	return unstruct.Err(b)
 }
 
 func (b Builder) T() (appsV1.Deployment, error) {
 	var dep appsV1.Deployment
-	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(b, &dep); err != nil {
+	if err := unstruct.FromUnstructured(b, &dep); err != nil {
+		return appsV1.Deployment{}, err
+	}
+	if err := naming.Validate("Deployment", dep.Name); err != nil {
 		return appsV1.Deployment{}, err
 	}
 	return dep, nil
@@ -35,30 +78,90 @@ func (b Builder) Replicas(r int) Builder {
 
 func (b Builder) Strategy(strat StrategyBuilder) Builder {
 	spec := b.getDeploymentSpec()
-	spec["strategy"] = strat.U()
+	spec["strategy"] = unstruct.Nest(strat)
 	b["spec"] = spec
 	return b
 }
 
+// PodSpec sets the pod template of the deployment. The stable recommended
+// labels of the deployment (objmeta.StableAppLabels) are inherited by the pod
+// template and, unless a selector is set, selected (see InheritLabels).
 func (b Builder) PodSpec(containers ...container.Builder) Builder {
-	spec := b.getDeploymentSpec()
-	spec["template"] = map[string]interface{}{
-		"spec": pod.Spec(containers...).U(),
-	}
-	b["spec"] = spec
+	workload.SetTemplate(b, nil, unstruct.Nest(pod.Spec(containers...)))
+	workload.InheritLabels(b, objmeta.StableAppLabels)
 	return b
 }
 
+// PodSpecWithMetadata sets the pod template of the deployment with metadata,
+// stable recommended labels are inherited as with PodSpec
 func (b Builder) PodSpecWithMetadata(metadata objmeta.Builder, containers ...container.Builder) Builder {
-	spec := b.getDeploymentSpec()
-	spec["template"] = map[string]interface{}{
-		"metadata": metadata.U(),
-		"spec":     pod.Spec(containers...).U(),
-	}
-	b["spec"] = spec
+	meta, err := metadata.U()
+	unstruct.SetErr(b, err)
+	workload.SetTemplate(b, meta, unstruct.Nest(pod.Spec(containers...)))
+	workload.InheritLabels(b, objmeta.StableAppLabels)
 	return b
 }
 
+// InheritLabels copies the labels of the deployment with the keys (i.e.
+// objmeta.LabelVersion) into its pod template, without replacing the labels of
+// the template. When no selector is set, the pods are selected by the inherited
+// labels, a selector that is set is left unchanged as it is immutable.
+func (b Builder) InheritLabels(keys ...string) Builder {
+	workload.InheritLabels(b, keys)
+	return b
+}
+
+// Selector sets the labels of the pods managed by the deployment
+func (b Builder) Selector(labels map[string]string) Builder {
+	workload.SetSelector(b, labels)
+	return b
+}
+
+// LabelSelector sets the selector of the pods managed by the deployment,
+// supporting label expressions
+func (b Builder) LabelSelector(sel selector.Builder) Builder {
+	workload.SetLabelSelector(b, unstruct.Nest(sel))
+	return b
+}
+
+// PodTemplate sets the pod template from the pod built by p, keeping only the
+// labels and annotations of its metadata, and selects the pod's labels
+func (b Builder) PodTemplate(p pod.Builder) Builder {
+	workload.SetTemplateFromPod(b, p)
+	return b
+}
+
+// FromPod starts a new deployment builder, with the name and namespace of the
+// pod built by p, whose pods mirror it (see PodTemplate)
+func FromPod(p pod.Builder) Builder {
+	return Object(objmeta.Name(workload.Name(p)).Namespace(workload.Namespace(p))).PodTemplate(p)
+}
+
+// Pod returns a builder for a standalone pod, with the name, mirroring the
+// deployment's pod template in the deployment's namespace
+func (b Builder) Pod(name string) pod.Builder {
+	return pod.Builder(workload.Pod(b, name))
+}
+
+// PodSelector returns the selector of the pods managed by the deployment, which
+// matches the pod template labels when no selector is set. An error is
+// returned if the deployment is invalid or if the selector is empty, as it
+// would select every pod in the namespace.
+func (b Builder) PodSelector() (selector.Builder, error) {
+	dep, err := b.T()
+	if err != nil {
+		return nil, err
+	}
+	sel := metaV1.LabelSelector{MatchLabels: dep.Spec.Template.Labels}
+	if dep.Spec.Selector != nil {
+		sel = *dep.Spec.Selector
+	}
+	if len(sel.MatchLabels) == 0 && len(sel.MatchExpressions) == 0 {
+		return nil, fmt.Errorf("deployment: %s: empty pod selector", dep.Name)
+	}
+	return selector.From(sel), nil
+}
+
 func replicas(r int) *int32 {
 	rep := int32(r)
 	return &rep
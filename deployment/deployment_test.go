@@ -6,9 +6,14 @@ import (
 
 	"github.com/vladimirvivien/kob/container"
 	"github.com/vladimirvivien/kob/objmeta"
+	"github.com/vladimirvivien/kob/pod"
+	"github.com/vladimirvivien/kob/selector"
 	appsV1 "k8s.io/api/apps/v1"
 	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func TestDeploymentUnstructured(t *testing.T) {
@@ -131,6 +136,18 @@ func TestDeploymentTyped(t *testing.T) {
 				},
 			},
 		},
+		"with label selector": {
+			builder: Object(objmeta.Name("simple-dep")).LabelSelector(selector.MatchLabels(map[string]string{"app": "web"}).In("track", "stable", "canary")),
+			expected: appsV1.Deployment{
+				ObjectMeta: metaV1.ObjectMeta{Name: "simple-dep"},
+				Spec: appsV1.DeploymentSpec{
+					Selector: &metaV1.LabelSelector{
+						MatchLabels:      map[string]string{"app": "web"},
+						MatchExpressions: []metaV1.LabelSelectorRequirement{{Key: "track", Operator: metaV1.LabelSelectorOpIn, Values: []string{"stable", "canary"}}},
+					},
+				},
+			},
+		},
 		"with podspec and pod metadata": {
 			builder: Object(objmeta.Name("simple-dep")).Replicas(3).Strategy(StrategyDefault).PodSpecWithMetadata(objmeta.Name("dep-pods"), container.Name("simple-container")),
 			expected: appsV1.Deployment{
@@ -165,3 +182,344 @@ func TestDeploymentTyped(t *testing.T) {
 		})
 	}
 }
+
+func TestDeploymentFromPod(t *testing.T) {
+	p := pod.Object(objmeta.Name("web").Namespace("shop").Labels(map[string]string{"app": "web"}).Annotations(map[string]string{"team": "a"})).
+		Spec(container.WithNameAndImage("web", "nginx")).
+		RestartPolicy(coreV1.RestartPolicyAlways)
+
+	dep, err := FromPod(p).Replicas(2).T()
+	if err != nil {
+		t.Fatalf("failed to convert to typed value: %s", err)
+	}
+	expected := appsV1.Deployment{
+		ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "shop"},
+		Spec: appsV1.DeploymentSpec{
+			Replicas: replicas(2),
+			Selector: &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			Template: coreV1.PodTemplateSpec{
+				ObjectMeta: metaV1.ObjectMeta{Labels: map[string]string{"app": "web"}, Annotations: map[string]string{"team": "a"}},
+				Spec: coreV1.PodSpec{
+					Containers:    []coreV1.Container{{Name: "web", Image: "nginx"}},
+					RestartPolicy: coreV1.RestartPolicyAlways,
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(dep, expected) {
+		t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", dep, expected)
+	}
+}
+
+func TestDeploymentFromPodWithoutLabels(t *testing.T) {
+	dep := FromPod(pod.Object(objmeta.Name("web")).Spec(container.Name("web")))
+	if _, err := dep.T(); err == nil {
+		t.Error("expecting error, got none")
+	}
+}
+
+func TestDeploymentPod(t *testing.T) {
+	dep := Object(objmeta.Name("web").Namespace("shop")).
+		Selector(map[string]string{"app": "web"}).
+		PodSpecWithMetadata(objmeta.Name("").Labels(map[string]string{"app": "web"}), container.WithNameAndImage("web", "nginx"))
+
+	standalone := dep.Pod("web-test").AddContainer(container.Name("debug"))
+	p, err := standalone.T()
+	if err != nil {
+		t.Fatalf("failed to convert to typed value: %s", err)
+	}
+	expected := coreV1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "web-test", Namespace: "shop", Labels: map[string]string{"app": "web"}},
+		Spec:       coreV1.PodSpec{Containers: []coreV1.Container{{Name: "web", Image: "nginx"}, {Name: "debug"}}},
+	}
+	if !reflect.DeepEqual(p, expected) {
+		t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", p, expected)
+	}
+
+	// the standalone pod must not share its spec with the deployment's template
+	obj, _ := dep.T()
+	if len(obj.Spec.Template.Spec.Containers) != 1 {
+		t.Errorf("deployment template modified by standalone pod: %#v", obj.Spec.Template.Spec.Containers)
+	}
+}
+
+func TestDeploymentAppLabels(t *testing.T) {
+	app := objmeta.Name("shop-web").App("web", "shop-web", "1.2.0", "frontend", "shop", "kob")
+	stable := map[string]string{
+		objmeta.LabelName:      "web",
+		objmeta.LabelInstance:  "shop-web",
+		objmeta.LabelComponent: "frontend",
+		objmeta.LabelPartOf:    "shop",
+	}
+	tests := map[string]struct {
+		builder  Builder
+		expected appsV1.Deployment
+	}{
+		"podspec": {
+			builder: Object(app).PodSpec(container.Name("web")),
+			expected: appsV1.Deployment{
+				ObjectMeta: app.T(),
+				Spec: appsV1.DeploymentSpec{
+					Selector: &metaV1.LabelSelector{MatchLabels: stable},
+					Template: coreV1.PodTemplateSpec{
+						ObjectMeta: metaV1.ObjectMeta{Labels: stable},
+						Spec:       coreV1.PodSpec{Containers: []coreV1.Container{{Name: "web"}}},
+					},
+				},
+			},
+		},
+		"podspec inheriting version": {
+			builder: Object(app).PodSpec(container.Name("web")).InheritLabels(objmeta.LabelVersion, objmeta.LabelManagedBy),
+			expected: appsV1.Deployment{
+				ObjectMeta: app.T(),
+				Spec: appsV1.DeploymentSpec{
+					Selector: &metaV1.LabelSelector{MatchLabels: stable},
+					Template: coreV1.PodTemplateSpec{
+						ObjectMeta: metaV1.ObjectMeta{Labels: app.T().Labels},
+						Spec:       coreV1.PodSpec{Containers: []coreV1.Container{{Name: "web"}}},
+					},
+				},
+			},
+		},
+		"inherited subset": {
+			builder: Object(app).InheritLabels(objmeta.LabelName).PodSpec(container.Name("web")),
+			expected: appsV1.Deployment{
+				ObjectMeta: app.T(),
+				Spec: appsV1.DeploymentSpec{
+					Selector: &metaV1.LabelSelector{MatchLabels: map[string]string{objmeta.LabelName: "web"}},
+					Template: coreV1.PodTemplateSpec{
+						ObjectMeta: metaV1.ObjectMeta{Labels: stable},
+						Spec:       coreV1.PodSpec{Containers: []coreV1.Container{{Name: "web"}}},
+					},
+				},
+			},
+		},
+		"podspec with metadata and selector": {
+			builder: Object(app).Selector(map[string]string{"tier": "web"}).
+				PodSpecWithMetadata(objmeta.Name("").Labels(map[string]string{"tier": "web", objmeta.LabelComponent: "api"}), container.Name("web")),
+			expected: appsV1.Deployment{
+				ObjectMeta: app.T(),
+				Spec: appsV1.DeploymentSpec{
+					Selector: &metaV1.LabelSelector{MatchLabels: map[string]string{"tier": "web"}},
+					Template: coreV1.PodTemplateSpec{
+						ObjectMeta: metaV1.ObjectMeta{Labels: map[string]string{
+							"tier":                 "web",
+							objmeta.LabelName:      "web",
+							objmeta.LabelInstance:  "shop-web",
+							objmeta.LabelComponent: "api",
+							objmeta.LabelPartOf:    "shop",
+						}},
+						Spec: coreV1.PodSpec{Containers: []coreV1.Container{{Name: "web"}}},
+					},
+				},
+			},
+		},
+		"existing deployment": {
+			builder: From(appsV1.Deployment{
+				ObjectMeta: app.T(),
+				Spec:       appsV1.DeploymentSpec{Selector: &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+			}).PodSpecWithMetadata(objmeta.Name("").Labels(map[string]string{"app": "web"}), container.Name("web")),
+			expected: appsV1.Deployment{
+				ObjectMeta: app.T(),
+				Spec: appsV1.DeploymentSpec{
+					Selector: &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+					Template: coreV1.PodTemplateSpec{
+						ObjectMeta: metaV1.ObjectMeta{Labels: map[string]string{
+							"app":                  "web",
+							objmeta.LabelName:      "web",
+							objmeta.LabelInstance:  "shop-web",
+							objmeta.LabelComponent: "frontend",
+							objmeta.LabelPartOf:    "shop",
+						}},
+						Spec: coreV1.PodSpec{Containers: []coreV1.Container{{Name: "web"}}},
+					},
+				},
+			},
+		},
+		"from pod": {
+			builder: FromPod(pod.Object(objmeta.Name("web").App("web", "", "1.2.0", "", "", "")).Spec(container.Name("web"))),
+			expected: appsV1.Deployment{
+				ObjectMeta: metaV1.ObjectMeta{Name: "web"},
+				Spec: appsV1.DeploymentSpec{
+					Selector: &metaV1.LabelSelector{MatchLabels: map[string]string{objmeta.LabelName: "web"}},
+					Template: coreV1.PodTemplateSpec{
+						ObjectMeta: metaV1.ObjectMeta{Labels: map[string]string{objmeta.LabelName: "web", objmeta.LabelVersion: "1.2.0"}},
+						Spec:       coreV1.PodSpec{Containers: []coreV1.Container{{Name: "web"}}},
+					},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dep, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(dep, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", dep, test.expected)
+			}
+		})
+	}
+}
+
+func TestDeploymentFrom(t *testing.T) {
+	unavailable := intstr.FromInt(1)
+	full := appsV1.Deployment{
+		TypeMeta: metaV1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:        "web",
+			Namespace:   "shop",
+			UID:         "dep-uid",
+			Generation:  3,
+			Labels:      map[string]string{"app": "web"},
+			Annotations: map[string]string{"deployment.kubernetes.io/revision": "3"},
+		},
+		Spec: appsV1.DeploymentSpec{
+			Replicas: replicas(3),
+			Selector: &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			Template: coreV1.PodTemplateSpec{
+				ObjectMeta: metaV1.ObjectMeta{Labels: map[string]string{"app": "web"}},
+				Spec: coreV1.PodSpec{
+					Containers: []coreV1.Container{{
+						Name:      "web",
+						Image:     "nginx",
+						Resources: coreV1.ResourceRequirements{Requests: coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("250m")}},
+					}},
+					RestartPolicy: coreV1.RestartPolicyAlways,
+				},
+			},
+			Strategy:                appsV1.DeploymentStrategy{Type: appsV1.RollingUpdateDeploymentStrategyType, RollingUpdate: &appsV1.RollingUpdateDeployment{MaxUnavailable: &unavailable}},
+			MinReadySeconds:         5,
+			RevisionHistoryLimit:    replicas(10),
+			ProgressDeadlineSeconds: replicas(600),
+		},
+		Status: appsV1.DeploymentStatus{ObservedGeneration: 3, Replicas: 3, ReadyReplicas: 3, AvailableReplicas: 3},
+	}
+	fromUnstructured, err := FromUnstructured(map[string]any{"metadata": map[string]any{"name": "web"}, "spec": map[string]any{"replicas": int64(2)}})
+	if err != nil {
+		t.Fatalf("failed to create builder: %s", err)
+	}
+	fromString, err := FromString(`{"metadata": {"name": "web"}, "spec": {"replicas": 2}}`)
+	if err != nil {
+		t.Fatalf("failed to create builder: %s", err)
+	}
+
+	tests := map[string]struct {
+		builder  Builder
+		expected appsV1.Deployment
+	}{
+		"from empty": {
+			builder:  From(appsV1.Deployment{}),
+			expected: appsV1.Deployment{},
+		},
+		"from full": {
+			builder:  From(full),
+			expected: full,
+		},
+		"from unstructured and modify": {
+			builder:  fromUnstructured.Replicas(4),
+			expected: appsV1.Deployment{ObjectMeta: metaV1.ObjectMeta{Name: "web"}, Spec: appsV1.DeploymentSpec{Replicas: replicas(4)}},
+		},
+		"from string and modify": {
+			builder: fromString.Strategy(StrategyDefault),
+			expected: appsV1.Deployment{
+				ObjectMeta: metaV1.ObjectMeta{Name: "web"},
+				Spec:       appsV1.DeploymentSpec{Replicas: replicas(2), Strategy: appsV1.DeploymentStrategy{Type: appsV1.RecreateDeploymentStrategyType}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dep, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(dep, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", dep, test.expected)
+			}
+		})
+	}
+
+	if _, err := FromString(`{"spec": {"replicas": "two"}}`); err == nil {
+		t.Error("expecting error, got none")
+	}
+}
+
+func TestDeploymentMetadataError(t *testing.T) {
+	tests := map[string]Builder{
+		"object metadata":   Object(objmeta.Name("web").Namespace("prod").AddLabel("bad key!", "x")),
+		"template metadata": Object(objmeta.Name("web")).PodSpecWithMetadata(objmeta.Name("").AddLabel("bad key!", "x")),
+	}
+
+	for name, builder := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := builder.T(); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+
+	// the metadata is kept along with the error, which is returned by Err
+	// and kept out of the unstructured value
+	dep := Object(objmeta.Name("web").Namespace("prod").AddLabel("bad key!", "x")).PodSpec(container.Name("web"))
+	if err := dep.Err(); err == nil {
+		t.Error("expecting error, got none")
+	}
+	u := dep.U()
+	meta, _ := u["metadata"].(map[string]interface{})
+	if meta["name"] != "web" || meta["namespace"] != "prod" {
+		t.Errorf("metadata not kept with the error: %#v", meta)
+	}
+	if copied := runtime.DeepCopyJSON(u); !reflect.DeepEqual(copied, u) {
+		t.Errorf("object not equal \n\n Copy: %#v \n\n Expected: %#v", copied, u)
+	}
+}
+
+func TestDeploymentPodSelector(t *testing.T) {
+	tests := map[string]struct {
+		builder  Builder
+		expected metaV1.LabelSelector
+	}{
+		"template labels": {
+			builder:  Object(objmeta.Name("web")).PodSpecWithMetadata(objmeta.Name("").Labels(map[string]string{"app": "web"})),
+			expected: metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		},
+		"selector expressions": {
+			builder: Object(objmeta.Name("web")).LabelSelector(selector.MatchLabels(map[string]string{"app": "web"}).NotIn("track", "canary")),
+			expected: metaV1.LabelSelector{
+				MatchLabels:      map[string]string{"app": "web"},
+				MatchExpressions: []metaV1.LabelSelectorRequirement{{Key: "track", Operator: metaV1.LabelSelectorOpNotIn, Values: []string{"canary"}}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			sel, err := test.builder.PodSelector()
+			if err != nil {
+				t.Fatal(err)
+			}
+			typed, err := sel.T()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(typed, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", typed, test.expected)
+			}
+		})
+	}
+
+	for name, builder := range map[string]Builder{
+		"empty selector": Object(objmeta.Name("web")),
+		"invalid":        Object(objmeta.Name("My_App")).Selector(map[string]string{"app": "web"}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := builder.PodSelector(); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
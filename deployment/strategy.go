@@ -1,9 +1,13 @@
 package deployment
 
 import (
+	"strings"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
 	appsV1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/yaml"
 )
 
 var (
@@ -25,13 +29,43 @@ func RollingUpdate(maxUnavailable, maxSurge string) StrategyBuilder {
 	}
 }
 
+// StrategyFrom creates a new strategy builder using the provided strategy as its base
+func StrategyFrom(obj appsV1.DeploymentStrategy) StrategyBuilder {
+	u, _ := runtime.DefaultUnstructuredConverter.ToUnstructured(&obj)
+	return StrategyBuilder(u)
+}
+
+// StrategyFromUnstructured creates a strategy builder from an unstructured value,
+// which must be convertible to an appsV1.DeploymentStrategy
+func StrategyFromUnstructured(unstruct map[string]any) (StrategyBuilder, error) {
+	var obj appsV1.DeploymentStrategy
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstruct, &obj); err != nil {
+		return StrategyBuilder{}, err
+	}
+	return StrategyFrom(obj), nil
+}
+
+// StrategyFromString creates a strategy builder from a valid YAML or JSON strategy fragment
+func StrategyFromString(str string) (StrategyBuilder, error) {
+	var obj appsV1.DeploymentStrategy
+	if err := yaml.NewYAMLOrJSONDecoder(strings.NewReader(str), 1024).Decode(&obj); err != nil {
+		return StrategyBuilder{}, err
+	}
+	return StrategyFrom(obj), nil
+}
+
 func (b StrategyBuilder) U() map[string]interface{} {
-	return b
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b StrategyBuilder) Err() error {
+	return unstruct.Err(b)
 }
 
 func (b StrategyBuilder) T() (appsV1.DeploymentStrategy, error) {
 	var strat appsV1.DeploymentStrategy
-	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(b, &strat); err != nil {
+	if err := unstruct.FromUnstructured(b, &strat); err != nil {
 		return appsV1.DeploymentStrategy{}, err
 	}
 	return strat, nil
@@ -94,3 +94,45 @@ func TestStrategyStructured(t *testing.T) {
 		})
 	}
 }
+
+func TestStrategyFrom(t *testing.T) {
+	unavailable, surge := intstr.FromString("25%"), intstr.FromInt(1)
+	full := appsV1.DeploymentStrategy{
+		Type:          appsV1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: &appsV1.RollingUpdateDeployment{MaxUnavailable: &unavailable, MaxSurge: &surge},
+	}
+	fromString, err := StrategyFromString("type: RollingUpdate\nrollingUpdate:\n  maxUnavailable: 25%\n  maxSurge: 1\n")
+	if err != nil {
+		t.Fatalf("failed to create builder: %s", err)
+	}
+	fromUnstructured, err := StrategyFromUnstructured(map[string]any{"type": "Recreate"})
+	if err != nil {
+		t.Fatalf("failed to create builder: %s", err)
+	}
+
+	tests := map[string]struct {
+		builder  StrategyBuilder
+		expected appsV1.DeploymentStrategy
+	}{
+		"from empty":        {builder: StrategyFrom(appsV1.DeploymentStrategy{}), expected: appsV1.DeploymentStrategy{}},
+		"from full":         {builder: StrategyFrom(full), expected: full},
+		"from string":       {builder: fromString, expected: full},
+		"from unstructured": {builder: fromUnstructured, expected: appsV1.DeploymentStrategy{Type: appsV1.RecreateDeploymentStrategyType}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			strat, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(strat, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", strat, test.expected)
+			}
+		})
+	}
+
+	if _, err := StrategyFromUnstructured(map[string]any{"rollingUpdate": "invalid"}); err == nil {
+		t.Error("expecting error, got none")
+	}
+}
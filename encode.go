@@ -0,0 +1,83 @@
+// Package kob provides functions to encode and decode the objects built with
+// the kob builder packages as Kubernetes manifests
+package kob
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/vladimirvivien/kob/internal/scheme"
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// Manifest returns the unstructured manifest of obj, which is either a kob
+// builder or a typed API object. The apiVersion and kind are filled in from
+// the scheme, and null values and empty fields (i.e. resources: {}) are
+// removed. Empty values required by the API (such as a podSelector matching
+// all pods) or set explicitly (such as an emptyDir volume) are kept.
+func Manifest(obj any) (map[string]interface{}, error) {
+	typed, err := scheme.ObjectOf(obj)
+	if err != nil {
+		return nil, err
+	}
+	gvk, err := scheme.GVK(typed)
+	if err != nil {
+		return nil, err
+	}
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(typed)
+	if err != nil {
+		return nil, err
+	}
+	unstruct.PruneEmpty(u, typed)
+	u["apiVersion"], u["kind"] = gvk.GroupVersion().String(), gvk.Kind
+	return u, nil
+}
+
+// YAML writes the manifests of objs to w as a multi-document YAML stream,
+// with keys in a stable (sorted) order
+func YAML(w io.Writer, objs ...any) error {
+	for i, obj := range objs {
+		u, err := Manifest(obj)
+		if err != nil {
+			return fmt.Errorf("kob: object %d: %w", i, err)
+		}
+		data, err := yaml.Marshal(u)
+		if err != nil {
+			return fmt.Errorf("kob: object %d: %w", i, err)
+		}
+		if i > 0 {
+			if _, err := io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// JSON writes the manifests of objs to w as a stream of indented JSON
+// documents, with keys in a stable (sorted) order
+func JSON(w io.Writer, objs ...any) error {
+	for i, obj := range objs {
+		u, err := Manifest(obj)
+		if err != nil {
+			return fmt.Errorf("kob: object %d: %w", i, err)
+		}
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(u); err != nil {
+			return fmt.Errorf("kob: object %d: %w", i, err)
+		}
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
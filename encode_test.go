@@ -0,0 +1,192 @@
+package kob
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vladimirvivien/kob/container"
+	"github.com/vladimirvivien/kob/deployment"
+	"github.com/vladimirvivien/kob/gateway"
+	"github.com/vladimirvivien/kob/networkpolicy"
+	"github.com/vladimirvivien/kob/objmeta"
+	"github.com/vladimirvivien/kob/pod"
+	"github.com/vladimirvivien/kob/service"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// TestYAMLGolden checks the manifests of typical objects, which must be free of
+// the null and empty fields rendered for typed values, against testdata
+func TestYAMLGolden(t *testing.T) {
+	web := map[string]string{"app": "web"}
+	tests := map[string]any{
+		"deployment.yaml": deployment.Object(objmeta.Name("web").Namespace("shop")).Replicas(3).
+			PodTemplate(pod.Object(objmeta.Name("web").Labels(web)).
+				WithSpec(pod.Spec(container.WithNameAndImage("web", "nginx:1.25").
+					Ports(coreV1.ContainerPort{Name: "http", ContainerPort: 8080}).
+					AddEnv("MODE", "production").
+					AddVolumeMount(container.VolumeMount("cache", "/var/cache/nginx"))).
+					AddVolume(pod.Volume("cache").EmptyDir()))),
+		"service.yaml": service.Object(objmeta.Name("web").Namespace("shop")).Selector(web).
+			Ports(service.Port(80).Name("http"), service.Port(443).Name("https").TargetPort(8443)),
+	}
+
+	for name, obj := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := YAML(&buf, obj); err != nil {
+				t.Fatalf("failed to encode: %s", err)
+			}
+			golden := filepath.Join("testdata", name)
+			if *update {
+				if err := os.WriteFile(golden, buf.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != string(expected) {
+				t.Errorf("manifest not equal \n\n Encoded:\n%s \n\n Expected:\n%s", buf.String(), expected)
+			}
+		})
+	}
+}
+
+func TestYAML(t *testing.T) {
+	web := map[string]string{"app": "web"}
+	tests := map[string]struct {
+		objs     []any
+		expected string
+	}{
+		"deployment": {
+			objs: []any{deployment.Object(objmeta.Name("web").Namespace("shop")).Replicas(2).Selector(web).
+				PodSpecWithMetadata(objmeta.Name("").Labels(web), container.WithNameAndImage("web", "nginx"))},
+			expected: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: shop
+spec:
+  replicas: 2
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - image: nginx
+        name: web
+`,
+		},
+		"multiple documents keep empty pod selector": {
+			objs: []any{networkpolicy.DenyAll("shop"), &coreV1.ConfigMap{ObjectMeta: metaV1.ObjectMeta{Name: "cfg"}, Data: map[string]string{"b": "2", "a": "1"}}},
+			expected: `apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: default-deny-all
+  namespace: shop
+spec:
+  podSelector: {}
+  policyTypes:
+  - Ingress
+  - Egress
+---
+apiVersion: v1
+data:
+  a: "1"
+  b: "2"
+kind: ConfigMap
+metadata:
+  name: cfg
+`,
+		},
+		"unstructured": {
+			objs: []any{gateway.Gateway(objmeta.Name("edge"), "nginx").AddListener(gateway.Listener("http", gateway.HTTP, 80))},
+			expected: `apiVersion: gateway.networking.k8s.io/v1
+kind: Gateway
+metadata:
+  name: edge
+spec:
+  gatewayClassName: nginx
+  listeners:
+  - name: http
+    port: 80
+    protocol: HTTP
+`,
+		},
+		"none": {},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := YAML(&buf, test.objs...); err != nil {
+				t.Fatalf("failed to encode: %s", err)
+			}
+			if buf.String() != test.expected {
+				t.Errorf("manifest not equal \n\n Encoded:\n%s \n\n Expected:\n%s", buf.String(), test.expected)
+			}
+		})
+	}
+}
+
+func TestJSON(t *testing.T) {
+	svc := service.Object(objmeta.Name("web")).Selector(map[string]string{"app": "web"})
+	expected := `{
+  "apiVersion": "v1",
+  "kind": "Service",
+  "metadata": {
+    "name": "web"
+  },
+  "spec": {
+    "selector": {
+      "app": "web"
+    }
+  }
+}
+{
+  "apiVersion": "v1",
+  "kind": "Namespace",
+  "metadata": {
+    "name": "shop"
+  }
+}
+`
+	var buf bytes.Buffer
+	if err := JSON(&buf, svc, coreV1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "shop"}}); err != nil {
+		t.Fatalf("failed to encode: %s", err)
+	}
+	if buf.String() != expected {
+		t.Errorf("manifest not equal \n\n Encoded:\n%s \n\n Expected:\n%s", buf.String(), expected)
+	}
+}
+
+func TestEncodeInvalid(t *testing.T) {
+	tests := map[string]any{
+		"invalid builder": service.Object(objmeta.Name("My_Service")),
+		"invalid label":   deployment.Object(objmeta.Name("web").Namespace("prod").AddLabel("bad key!", "x")),
+		"not an object":   objmeta.Name("web"),
+		"nil":             nil,
+	}
+
+	for name, obj := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := YAML(&bytes.Buffer{}, obj); err == nil {
+				t.Error("expecting error, got none")
+			}
+			if err := JSON(&bytes.Buffer{}, obj); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+// Package endpoints contains builder types to build values of type coreV1.Endpoints.
+// Prefer the endpointslice package, which supersedes the Endpoints API.
+package endpoints
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	coreV1 "k8s.io/api/core/v1"
+)
+
+// Builder provides a way to build values of type coreV1.Endpoints
+type Builder map[string]interface{}
+
+// Object starts a new Endpoints builder using the provided object metadata,
+// whose name must match the name of the service
+func Object(metadata objmeta.Builder) Builder {
+	meta, err := metadata.U()
+	return Builder(unstruct.SetErr(map[string]interface{}{"metadata": meta}, err))
+}
+
+// U returns the unstructured value of the builder
+func (b Builder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b Builder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder. An error is returned if an address is not an IP address.
+func (b Builder) T() (coreV1.Endpoints, error) {
+	var endpoints coreV1.Endpoints
+	if err := unstruct.FromUnstructured(b, &endpoints); err != nil {
+		return coreV1.Endpoints{}, err
+	}
+	if err := naming.Validate("Endpoints", endpoints.Name); err != nil {
+		return coreV1.Endpoints{}, err
+	}
+	for _, subset := range endpoints.Subsets {
+		for _, addr := range append(subset.Addresses, subset.NotReadyAddresses...) {
+			if net.ParseIP(addr.IP) == nil {
+				return coreV1.Endpoints{}, fmt.Errorf("endpoints: %s address %q is not an IP address", endpoints.Name, addr.IP)
+			}
+		}
+	}
+	return endpoints, nil
+}
+
+// Subsets sets the groups of addresses sharing the same ports
+func (b Builder) Subsets(subsets ...SubsetBuilder) Builder {
+	var slice []interface{}
+	for _, s := range subsets {
+		slice = append(slice, unstruct.Nest(s))
+	}
+	b["subsets"] = slice
+	return b
+}
+
+// SubsetBuilder provides a way to build values of type coreV1.EndpointSubset
+type SubsetBuilder map[string]interface{}
+
+// Subset starts a new subset with the ready IP addresses
+func Subset(ips ...string) SubsetBuilder {
+	return SubsetBuilder{"addresses": addresses(ips)}
+}
+
+// U returns the unstructured value of the builder
+func (b SubsetBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b SubsetBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b SubsetBuilder) T() (coreV1.EndpointSubset, error) {
+	var subset coreV1.EndpointSubset
+	if err := unstruct.FromUnstructured(b, &subset); err != nil {
+		return coreV1.EndpointSubset{}, err
+	}
+	return subset, nil
+}
+
+// NotReadyAddresses sets the IP addresses that are not ready to receive traffic
+func (b SubsetBuilder) NotReadyAddresses(ips ...string) SubsetBuilder {
+	b["notReadyAddresses"] = addresses(ips)
+	return b
+}
+
+// Ports sets the ports exposed by the subset's addresses
+func (b SubsetBuilder) Ports(ports ...PortBuilder) SubsetBuilder {
+	var slice []interface{}
+	for _, p := range ports {
+		slice = append(slice, unstruct.Nest(p))
+	}
+	b["ports"] = slice
+	return b
+}
+
+// PortBuilder provides a way to build values of type coreV1.EndpointPort
+type PortBuilder map[string]interface{}
+
+// Port starts a new port builder with the port number of the addresses
+func Port(port int32) PortBuilder {
+	return PortBuilder{"port": int64(port)}
+}
+
+// U returns the unstructured value of the builder
+func (b PortBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b PortBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// Name sets the name of the port, which must match the name of the service port
+func (b PortBuilder) Name(name string) PortBuilder {
+	b["name"] = name
+	return b
+}
+
+// Protocol sets the IP protocol of the port (TCP, UDP, or SCTP)
+func (b PortBuilder) Protocol(proto coreV1.Protocol) PortBuilder {
+	b["protocol"] = string(proto)
+	return b
+}
+
+// AppProtocol sets the application protocol of the port (i.e. http, postgresql)
+func (b PortBuilder) AppProtocol(proto string) PortBuilder {
+	b["appProtocol"] = proto
+	return b
+}
+
+func addresses(ips []string) []interface{} {
+	var slice []interface{}
+	for _, ip := range ips {
+		slice = append(slice, map[string]interface{}{"ip": ip})
+	}
+	return slice
+}
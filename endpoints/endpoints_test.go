@@ -0,0 +1,59 @@
+package endpoints
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/objmeta"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEndpointsTyped(t *testing.T) {
+	pg := "postgresql"
+	tests := map[string]struct {
+		builder  Builder
+		expected coreV1.Endpoints
+	}{
+		"empty": {
+			builder:  Builder{},
+			expected: coreV1.Endpoints{},
+		},
+		"object meta only": {
+			builder:  Object(objmeta.Name("db")),
+			expected: coreV1.Endpoints{ObjectMeta: metaV1.ObjectMeta{Name: "db"}},
+		},
+		"subsets": {
+			builder: Object(objmeta.Name("db").Namespace("shop")).Subsets(
+				Subset("10.0.0.10", "10.0.0.11").NotReadyAddresses("10.0.0.12").
+					Ports(Port(5432).Name("postgres").Protocol(coreV1.ProtocolTCP).AppProtocol("postgresql")),
+			),
+			expected: coreV1.Endpoints{
+				ObjectMeta: metaV1.ObjectMeta{Name: "db", Namespace: "shop"},
+				Subsets: []coreV1.EndpointSubset{{
+					Addresses:         []coreV1.EndpointAddress{{IP: "10.0.0.10"}, {IP: "10.0.0.11"}},
+					NotReadyAddresses: []coreV1.EndpointAddress{{IP: "10.0.0.12"}},
+					Ports:             []coreV1.EndpointPort{{Name: "postgres", Port: 5432, Protocol: coreV1.ProtocolTCP, AppProtocol: &pg}},
+				}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			endpoints, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(endpoints, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", endpoints, test.expected)
+			}
+		})
+	}
+}
+
+func TestEndpointsInvalid(t *testing.T) {
+	if _, err := Object(objmeta.Name("db")).Subsets(Subset("db.example.com")).T(); err == nil {
+		t.Error("expecting error, got none")
+	}
+}
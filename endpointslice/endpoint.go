@@ -0,0 +1,129 @@
+package endpointslice
+
+import (
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	coreV1 "k8s.io/api/core/v1"
+	discoveryV1 "k8s.io/api/discovery/v1"
+)
+
+// EndpointBuilder provides a way to build values of type discoveryV1.Endpoint
+type EndpointBuilder map[string]interface{}
+
+// Endpoint starts a new endpoint with the addresses of a single backend
+// (consumers only use the first address)
+func Endpoint(addrs ...string) EndpointBuilder {
+	var slice []interface{}
+	for _, addr := range addrs {
+		slice = append(slice, addr)
+	}
+	return EndpointBuilder{"addresses": slice}
+}
+
+// U returns the unstructured value of the builder
+func (b EndpointBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b EndpointBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b EndpointBuilder) T() (discoveryV1.Endpoint, error) {
+	var endpoint discoveryV1.Endpoint
+	if err := unstruct.FromUnstructured(b, &endpoint); err != nil {
+		return discoveryV1.Endpoint{}, err
+	}
+	return endpoint, nil
+}
+
+// Ready sets whether the endpoint is ready to receive traffic
+func (b EndpointBuilder) Ready(ready bool) EndpointBuilder {
+	b.getConditions()["ready"] = ready
+	return b
+}
+
+// Serving sets whether the endpoint is able to receive traffic, even while terminating
+func (b EndpointBuilder) Serving(serving bool) EndpointBuilder {
+	b.getConditions()["serving"] = serving
+	return b
+}
+
+// Terminating sets whether the endpoint is terminating
+func (b EndpointBuilder) Terminating(terminating bool) EndpointBuilder {
+	b.getConditions()["terminating"] = terminating
+	return b
+}
+
+// Hostname sets the DNS label of the endpoint
+func (b EndpointBuilder) Hostname(host string) EndpointBuilder {
+	b["hostname"] = host
+	return b
+}
+
+// Zone sets the zone the endpoint is in, used for topology aware routing
+func (b EndpointBuilder) Zone(zone string) EndpointBuilder {
+	b["zone"] = zone
+	return b
+}
+
+// NodeName sets the node hosting the endpoint
+func (b EndpointBuilder) NodeName(node string) EndpointBuilder {
+	b["nodeName"] = node
+	return b
+}
+
+func (b EndpointBuilder) getConditions() map[string]interface{} {
+	conds, ok := b["conditions"].(map[string]interface{})
+	if !ok {
+		conds = map[string]interface{}{}
+		b["conditions"] = conds
+	}
+	return conds
+}
+
+// PortBuilder provides a way to build values of type discoveryV1.EndpointPort
+type PortBuilder map[string]interface{}
+
+// Port starts a new port builder with the port number of the endpoints
+func Port(port int32) PortBuilder {
+	return PortBuilder{"port": int64(port)}
+}
+
+// U returns the unstructured value of the builder
+func (b PortBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b PortBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b PortBuilder) T() (discoveryV1.EndpointPort, error) {
+	var port discoveryV1.EndpointPort
+	if err := unstruct.FromUnstructured(b, &port); err != nil {
+		return discoveryV1.EndpointPort{}, err
+	}
+	return port, nil
+}
+
+// Name sets the name of the port, which must match the name of the service port
+func (b PortBuilder) Name(name string) PortBuilder {
+	b["name"] = name
+	return b
+}
+
+// Protocol sets the IP protocol of the port (TCP, UDP, or SCTP)
+func (b PortBuilder) Protocol(proto coreV1.Protocol) PortBuilder {
+	b["protocol"] = string(proto)
+	return b
+}
+
+// AppProtocol sets the application protocol of the port (i.e. http, postgresql)
+func (b PortBuilder) AppProtocol(proto string) PortBuilder {
+	b["appProtocol"] = proto
+	return b
+}
@@ -0,0 +1,129 @@
+// Package endpointslice contains builder types to build values of type discoveryV1.EndpointSlice
+package endpointslice
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	discoveryV1 "k8s.io/api/discovery/v1"
+)
+
+// ManagedBy is the endpointslice.kubernetes.io/managed-by label value set by
+// For, so that the cluster's endpoint slice controller leaves the slices alone
+const ManagedBy = "kob"
+
+// Builder provides a way to build values of type discoveryV1.EndpointSlice
+type Builder map[string]interface{}
+
+// Object starts a new EndpointSlice builder using the provided object metadata
+// and the type of the endpoint addresses (IPv4, IPv6 or FQDN)
+func Object(metadata objmeta.Builder, addressType discoveryV1.AddressType) Builder {
+	meta, err := metadata.U()
+	return Builder(unstruct.SetErr(map[string]interface{}{"metadata": meta, "addressType": string(addressType)}, err))
+}
+
+// For starts a new EndpointSlice builder for the named service, setting the
+// kubernetes.io/service-name and endpointslice.kubernetes.io/managed-by labels
+func For(metadata objmeta.Builder, serviceName string, addressType discoveryV1.AddressType) Builder {
+	return Object(metadata, addressType).
+		setLabel(discoveryV1.LabelServiceName, serviceName).
+		setLabel(discoveryV1.LabelManagedBy, ManagedBy)
+}
+
+// U returns the unstructured value of the builder
+func (b Builder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b Builder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder. An error is returned if an
+// endpoint has no addresses or an address does not match the slice's address type.
+func (b Builder) T() (discoveryV1.EndpointSlice, error) {
+	var slice discoveryV1.EndpointSlice
+	if err := unstruct.FromUnstructured(b, &slice); err != nil {
+		return discoveryV1.EndpointSlice{}, err
+	}
+	if err := naming.Validate("EndpointSlice", slice.Name); err != nil {
+		return discoveryV1.EndpointSlice{}, err
+	}
+	if err := validate(slice); err != nil {
+		return discoveryV1.EndpointSlice{}, err
+	}
+	return slice, nil
+}
+
+// Endpoints sets the endpoints of the slice
+func (b Builder) Endpoints(endpoints ...EndpointBuilder) Builder {
+	var slice []interface{}
+	for _, e := range endpoints {
+		slice = append(slice, unstruct.Nest(e))
+	}
+	b["endpoints"] = slice
+	return b
+}
+
+// AddEndpoint adds an endpoint to the slice
+func (b Builder) AddEndpoint(endpoint EndpointBuilder) Builder {
+	endpoints, _ := b["endpoints"].([]interface{})
+	b["endpoints"] = append(endpoints, unstruct.Nest(endpoint))
+	return b
+}
+
+// Ports sets the ports exposed by all endpoints of the slice
+func (b Builder) Ports(ports ...PortBuilder) Builder {
+	var slice []interface{}
+	for _, p := range ports {
+		slice = append(slice, unstruct.Nest(p))
+	}
+	b["ports"] = slice
+	return b
+}
+
+func (b Builder) setLabel(key, val string) Builder {
+	meta, ok := b["metadata"].(map[string]interface{})
+	if !ok {
+		meta = map[string]interface{}{}
+		b["metadata"] = meta
+	}
+	labels, ok := meta["labels"].(map[string]interface{})
+	if !ok {
+		labels = map[string]interface{}{}
+		meta["labels"] = labels
+	}
+	labels[key] = val
+	return b
+}
+
+// AddressType returns the address type (IPv4, IPv6 or FQDN) of addr
+func AddressType(addr string) discoveryV1.AddressType {
+	ip := net.ParseIP(addr)
+	switch {
+	case ip == nil:
+		return discoveryV1.AddressTypeFQDN
+	case ip.To4() != nil:
+		return discoveryV1.AddressTypeIPv4
+	default:
+		return discoveryV1.AddressTypeIPv6
+	}
+}
+
+func validate(slice discoveryV1.EndpointSlice) error {
+	for _, endpoint := range slice.Endpoints {
+		if len(endpoint.Addresses) == 0 {
+			return fmt.Errorf("endpointslice: %s endpoint must have at least one address", slice.Name)
+		}
+		for _, addr := range endpoint.Addresses {
+			if addrType := AddressType(addr); addrType != slice.AddressType {
+				return fmt.Errorf("endpointslice: %s address %s is %s, expecting %s", slice.Name, addr, addrType, slice.AddressType)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package endpointslice
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/objmeta"
+	coreV1 "k8s.io/api/core/v1"
+	discoveryV1 "k8s.io/api/discovery/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEndpointSliceTyped(t *testing.T) {
+	tcp, pg := coreV1.ProtocolTCP, "postgresql"
+	tests := map[string]struct {
+		builder  Builder
+		expected discoveryV1.EndpointSlice
+	}{
+		"empty": {
+			builder:  Builder{},
+			expected: discoveryV1.EndpointSlice{},
+		},
+		"object meta only": {
+			builder:  Object(objmeta.Name("db-1"), discoveryV1.AddressTypeIPv4),
+			expected: discoveryV1.EndpointSlice{ObjectMeta: metaV1.ObjectMeta{Name: "db-1"}, AddressType: discoveryV1.AddressTypeIPv4},
+		},
+		"for service with endpoints and ports": {
+			builder: For(objmeta.Name("db-1").Namespace("shop"), "db", discoveryV1.AddressTypeIPv4).
+				Endpoints(Endpoint("10.0.0.10").Ready(true).Zone("us-east-1a")).
+				AddEndpoint(Endpoint("10.0.0.11").Ready(false).Serving(true).Terminating(true).Hostname("db-1").NodeName("node-1")).
+				Ports(Port(5432).Name("postgres").Protocol(coreV1.ProtocolTCP).AppProtocol("postgresql")),
+			expected: discoveryV1.EndpointSlice{
+				ObjectMeta: metaV1.ObjectMeta{
+					Name:      "db-1",
+					Namespace: "shop",
+					Labels:    map[string]string{"kubernetes.io/service-name": "db", "endpointslice.kubernetes.io/managed-by": "kob"},
+				},
+				AddressType: discoveryV1.AddressTypeIPv4,
+				Endpoints: []discoveryV1.Endpoint{
+					{Addresses: []string{"10.0.0.10"}, Conditions: discoveryV1.EndpointConditions{Ready: boolPtr(true)}, Zone: strPtr("us-east-1a")},
+					{
+						Addresses:  []string{"10.0.0.11"},
+						Conditions: discoveryV1.EndpointConditions{Ready: boolPtr(false), Serving: boolPtr(true), Terminating: boolPtr(true)},
+						Hostname:   strPtr("db-1"),
+						NodeName:   strPtr("node-1"),
+					},
+				},
+				Ports: []discoveryV1.EndpointPort{{Name: strPtr("postgres"), Protocol: &tcp, Port: int32Ptr(5432), AppProtocol: &pg}},
+			},
+		},
+		"fqdn": {
+			builder: Object(objmeta.Name("api"), discoveryV1.AddressTypeFQDN).Endpoints(Endpoint("api.example.com")),
+			expected: discoveryV1.EndpointSlice{
+				ObjectMeta:  metaV1.ObjectMeta{Name: "api"},
+				AddressType: discoveryV1.AddressTypeFQDN,
+				Endpoints:   []discoveryV1.Endpoint{{Addresses: []string{"api.example.com"}}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			slice, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(slice, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", slice, test.expected)
+			}
+		})
+	}
+}
+
+func TestEndpointSliceInvalid(t *testing.T) {
+	tests := map[string]Builder{
+		"no addresses":     Object(objmeta.Name("db"), discoveryV1.AddressTypeIPv4).Endpoints(Endpoint()),
+		"ipv6 in ipv4":     Object(objmeta.Name("db"), discoveryV1.AddressTypeIPv4).Endpoints(Endpoint("fd00::1")),
+		"hostname in ipv4": Object(objmeta.Name("db"), discoveryV1.AddressTypeIPv4).Endpoints(Endpoint("db.example.com")),
+	}
+	for name, builder := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := builder.T(); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
+
+func TestAddressType(t *testing.T) {
+	tests := map[string]discoveryV1.AddressType{
+		"10.0.0.1":        discoveryV1.AddressTypeIPv4,
+		"fd00::1":         discoveryV1.AddressTypeIPv6,
+		"db.example.com":  discoveryV1.AddressTypeFQDN,
+		"::ffff:10.0.0.1": discoveryV1.AddressTypeIPv4,
+	}
+	for addr, expected := range tests {
+		if addrType := AddressType(addr); addrType != expected {
+			t.Errorf("address %s: expecting %s, got %s", addr, expected, addrType)
+		}
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func strPtr(s string) *string {
+	return &s
+}
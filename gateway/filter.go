@@ -0,0 +1,114 @@
+package gateway
+
+import (
+	"sort"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/service"
+)
+
+// FilterBuilder provides a way to build route filters, which modify requests and responses
+type FilterBuilder map[string]interface{}
+
+// SetRequestHeaders starts a filter overwriting request headers
+func SetRequestHeaders(headers map[string]string) FilterBuilder {
+	return headerModifier("RequestHeaderModifier", "set", headers)
+}
+
+// AddRequestHeaders starts a filter appending to request headers
+func AddRequestHeaders(headers map[string]string) FilterBuilder {
+	return headerModifier("RequestHeaderModifier", "add", headers)
+}
+
+// RemoveRequestHeaders starts a filter removing request headers
+func RemoveRequestHeaders(names ...string) FilterBuilder {
+	return FilterBuilder{
+		"type":                  "RequestHeaderModifier",
+		"requestHeaderModifier": map[string]interface{}{"remove": stringSlice(names)},
+	}
+}
+
+// SetResponseHeaders starts a filter overwriting response headers
+func SetResponseHeaders(headers map[string]string) FilterBuilder {
+	return headerModifier("ResponseHeaderModifier", "set", headers)
+}
+
+// Redirect starts a filter redirecting requests to the scheme and hostname
+// (left unchanged when empty) with the status code (301 or 302)
+func Redirect(scheme, hostname string, statusCode int) FilterBuilder {
+	redirect := map[string]interface{}{"statusCode": int64(statusCode)}
+	if scheme != "" {
+		redirect["scheme"] = scheme
+	}
+	if hostname != "" {
+		redirect["hostname"] = hostname
+	}
+	return FilterBuilder{"type": "RequestRedirect", "requestRedirect": redirect}
+}
+
+// HTTPSRedirect starts a filter permanently redirecting requests to https
+func HTTPSRedirect() FilterBuilder {
+	return Redirect("https", "", 301)
+}
+
+// RewritePathPrefix starts a filter replacing the matched path prefix before forwarding requests
+func RewritePathPrefix(prefix string) FilterBuilder {
+	return FilterBuilder{
+		"type": "URLRewrite",
+		"urlRewrite": map[string]interface{}{
+			"path": map[string]interface{}{"type": "ReplacePrefixMatch", "replacePrefixMatch": prefix},
+		},
+	}
+}
+
+// RewriteHostname starts a filter replacing the Host header before forwarding requests
+func RewriteHostname(hostname string) FilterBuilder {
+	return FilterBuilder{"type": "URLRewrite", "urlRewrite": map[string]interface{}{"hostname": hostname}}
+}
+
+// Mirror starts a filter sending a copy of requests to the first port of the
+// service built by svc, ignoring its responses
+func Mirror(svc service.Builder) FilterBuilder {
+	ref := BackendRef(svc)
+	return FilterBuilder{"type": "RequestMirror", "requestMirror": map[string]interface{}{"backendRef": unstruct.Nest(ref)}}
+}
+
+// U returns the unstructured value of the builder
+func (b FilterBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b FilterBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+func headerModifier(filterType, op string, headers map[string]string) FilterBuilder {
+	key := "requestHeaderModifier"
+	if filterType == "ResponseHeaderModifier" {
+		key = "responseHeaderModifier"
+	}
+	return FilterBuilder{"type": filterType, key: map[string]interface{}{op: headerSlice(headers)}}
+}
+
+// headerSlice returns the headers as name/value pairs sorted by name
+func headerSlice(headers map[string]string) []interface{} {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var slice []interface{}
+	for _, name := range names {
+		slice = append(slice, map[string]interface{}{"name": name, "value": headers[name]})
+	}
+	return slice
+}
+
+func filterSlice(filters []FilterBuilder) []interface{} {
+	var slice []interface{}
+	for _, f := range filters {
+		slice = append(slice, unstruct.Nest(f))
+	}
+	return slice
+}
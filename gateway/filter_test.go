@@ -0,0 +1,68 @@
+package gateway
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/objmeta"
+	"github.com/vladimirvivien/kob/service"
+)
+
+func TestFilterUnstructured(t *testing.T) {
+	tests := map[string]struct {
+		builder  FilterBuilder
+		expected map[string]interface{}
+	}{
+		"add request headers sorted": {
+			builder: AddRequestHeaders(map[string]string{"x-b": "2", "x-a": "1"}),
+			expected: map[string]interface{}{
+				"type": "RequestHeaderModifier",
+				"requestHeaderModifier": map[string]interface{}{"add": []interface{}{
+					map[string]interface{}{"name": "x-a", "value": "1"},
+					map[string]interface{}{"name": "x-b", "value": "2"},
+				}},
+			},
+		},
+		"remove request headers": {
+			builder: RemoveRequestHeaders("x-debug"),
+			expected: map[string]interface{}{
+				"type":                  "RequestHeaderModifier",
+				"requestHeaderModifier": map[string]interface{}{"remove": []interface{}{"x-debug"}},
+			},
+		},
+		"set response headers": {
+			builder: SetResponseHeaders(map[string]string{"cache-control": "no-store"}),
+			expected: map[string]interface{}{
+				"type":                   "ResponseHeaderModifier",
+				"responseHeaderModifier": map[string]interface{}{"set": []interface{}{map[string]interface{}{"name": "cache-control", "value": "no-store"}}},
+			},
+		},
+		"https redirect": {
+			builder:  HTTPSRedirect(),
+			expected: map[string]interface{}{"type": "RequestRedirect", "requestRedirect": map[string]interface{}{"scheme": "https", "statusCode": int64(301)}},
+		},
+		"hostname redirect": {
+			builder:  Redirect("", "www.example.com", 302),
+			expected: map[string]interface{}{"type": "RequestRedirect", "requestRedirect": map[string]interface{}{"hostname": "www.example.com", "statusCode": int64(302)}},
+		},
+		"rewrite hostname": {
+			builder:  RewriteHostname("internal.example.com"),
+			expected: map[string]interface{}{"type": "URLRewrite", "urlRewrite": map[string]interface{}{"hostname": "internal.example.com"}},
+		},
+		"mirror": {
+			builder: Mirror(service.Object(objmeta.Name("shadow")).Ports(service.Port(8080))),
+			expected: map[string]interface{}{
+				"type":          "RequestMirror",
+				"requestMirror": map[string]interface{}{"backendRef": map[string]interface{}{"name": "shadow", "port": int64(8080)}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if !reflect.DeepEqual(test.builder.U(), test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", test.builder.U(), test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+// Package gateway contains builder types to build Gateway API objects
+// (gateway.networking.k8s.io). Objects are emitted as unstructured values so
+// that the Gateway API module is not a dependency.
+package gateway
+
+import (
+	"fmt"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	unstructuredV1 "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// Group is the API group of Gateway API objects
+	Group = "gateway.networking.k8s.io"
+	// Version is the API version of Gateway, HTTPRoute and GRPCRoute objects
+	Version = Group + "/v1"
+	// BetaVersion is the API version of ReferenceGrant objects
+	BetaVersion = Group + "/v1beta1"
+)
+
+// Protocol is the network protocol of a listener
+type Protocol string
+
+const (
+	HTTP  Protocol = "HTTP"
+	HTTPS Protocol = "HTTPS"
+	TLS   Protocol = "TLS"
+	TCP   Protocol = "TCP"
+	UDP   Protocol = "UDP"
+)
+
+// GatewayBuilder provides a way to build Gateway objects
+type GatewayBuilder map[string]interface{}
+
+// Gateway starts a new Gateway builder using the provided object metadata
+// and the name of its GatewayClass
+func Gateway(metadata objmeta.Builder, className string) GatewayBuilder {
+	meta, err := metadata.U()
+	return GatewayBuilder(unstruct.SetErr(map[string]interface{}{
+		"apiVersion": Version,
+		"kind":       "Gateway",
+		"metadata":   meta,
+		"spec":       map[string]interface{}{"gatewayClassName": className},
+	}, err))
+}
+
+// U returns the unstructured value of the builder
+func (b GatewayBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b GatewayBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the value of the builder as an unstructured object. An error is
+// returned if the gateway has no class name, no listeners or duplicate listener names.
+func (b GatewayBuilder) T() (unstructuredV1.Unstructured, error) {
+	spec := getSpec(b)
+	if className, _ := spec["gatewayClassName"].(string); className == "" {
+		return unstructuredV1.Unstructured{}, fmt.Errorf("gateway: gateway must set a class name")
+	}
+	listeners, _ := spec["listeners"].([]interface{})
+	if len(listeners) == 0 {
+		return unstructuredV1.Unstructured{}, fmt.Errorf("gateway: gateway must have at least one listener")
+	}
+	names := map[string]bool{}
+	for _, l := range listeners {
+		name, _ := l.(map[string]interface{})["name"].(string)
+		if names[name] {
+			return unstructuredV1.Unstructured{}, fmt.Errorf("gateway: duplicate listener name %q", name)
+		}
+		names[name] = true
+	}
+	return toUnstructured(b)
+}
+
+// Listeners sets the listeners of the gateway
+func (b GatewayBuilder) Listeners(listeners ...ListenerBuilder) GatewayBuilder {
+	var slice []interface{}
+	for _, l := range listeners {
+		slice = append(slice, unstruct.Nest(l))
+	}
+	setSpec(b, "listeners", slice)
+	return b
+}
+
+// AddListener adds a listener to the gateway
+func (b GatewayBuilder) AddListener(listener ListenerBuilder) GatewayBuilder {
+	appendSpec(b, "listeners", unstruct.Nest(listener))
+	return b
+}
+
+// Addresses requests the IP addresses of the gateway
+func (b GatewayBuilder) Addresses(ips ...string) GatewayBuilder {
+	var slice []interface{}
+	for _, ip := range ips {
+		slice = append(slice, map[string]interface{}{"type": "IPAddress", "value": ip})
+	}
+	setSpec(b, "addresses", slice)
+	return b
+}
+
+// ListenerBuilder provides a way to build gateway listeners
+type ListenerBuilder map[string]interface{}
+
+// Listener starts a new listener accepting the protocol on the port
+func Listener(name string, protocol Protocol, port int32) ListenerBuilder {
+	return ListenerBuilder{"name": name, "protocol": string(protocol), "port": int64(port)}
+}
+
+// U returns the unstructured value of the builder
+func (b ListenerBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b ListenerBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// Hostname restricts the listener to requests for the hostname (i.e. *.example.com)
+func (b ListenerBuilder) Hostname(host string) ListenerBuilder {
+	b["hostname"] = host
+	return b
+}
+
+// TerminateTLS terminates TLS at the gateway using the certificates stored in the secrets
+func (b ListenerBuilder) TerminateTLS(secretNames ...string) ListenerBuilder {
+	var refs []interface{}
+	for _, name := range secretNames {
+		refs = append(refs, map[string]interface{}{"kind": "Secret", "name": name})
+	}
+	b["tls"] = map[string]interface{}{"mode": "Terminate", "certificateRefs": refs}
+	return b
+}
+
+// PassthroughTLS forwards TLS connections to the backends without terminating them
+func (b ListenerBuilder) PassthroughTLS() ListenerBuilder {
+	b["tls"] = map[string]interface{}{"mode": "Passthrough"}
+	return b
+}
+
+// AllowRoutesFrom sets which namespaces may attach routes to the listener (All, Same or Selector)
+func (b ListenerBuilder) AllowRoutesFrom(from string) ListenerBuilder {
+	b.getAllowedRoutes()["namespaces"] = map[string]interface{}{"from": from}
+	return b
+}
+
+// AllowRoutesFromNamespaces allows routes from namespaces with the labels to attach to the listener
+func (b ListenerBuilder) AllowRoutesFromNamespaces(labels map[string]string) ListenerBuilder {
+	b.getAllowedRoutes()["namespaces"] = map[string]interface{}{
+		"from":     "Selector",
+		"selector": map[string]interface{}{"matchLabels": stringMap(labels)},
+	}
+	return b
+}
+
+// AllowRouteKinds restricts the kinds of routes (i.e. HTTPRoute) that may attach to the listener
+func (b ListenerBuilder) AllowRouteKinds(kinds ...string) ListenerBuilder {
+	var slice []interface{}
+	for _, kind := range kinds {
+		slice = append(slice, map[string]interface{}{"group": Group, "kind": kind})
+	}
+	b.getAllowedRoutes()["kinds"] = slice
+	return b
+}
+
+func (b ListenerBuilder) getAllowedRoutes() map[string]interface{} {
+	allowed, ok := b["allowedRoutes"].(map[string]interface{})
+	if !ok {
+		allowed = map[string]interface{}{}
+		b["allowedRoutes"] = allowed
+	}
+	return allowed
+}
+
+// toUnstructured returns obj as an unstructured object, checking its name
+// and the error recorded while building it
+func toUnstructured(obj map[string]interface{}) (unstructuredV1.Unstructured, error) {
+	if err := unstruct.Err(obj); err != nil {
+		return unstructuredV1.Unstructured{}, err
+	}
+	u := unstructuredV1.Unstructured{Object: obj}
+	if err := naming.Validate(u.GetKind(), u.GetName()); err != nil {
+		return unstructuredV1.Unstructured{}, err
+	}
+	return u, nil
+}
+
+func stringMap(m map[string]string) map[string]interface{} {
+	u := map[string]interface{}{}
+	for k, v := range m {
+		u[k] = v
+	}
+	return u
+}
+
+func stringSlice(strs []string) []interface{} {
+	var slice []interface{}
+	for _, s := range strs {
+		slice = append(slice, s)
+	}
+	return slice
+}
@@ -0,0 +1,97 @@
+package gateway
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/objmeta"
+)
+
+func TestGatewayUnstructured(t *testing.T) {
+	tests := map[string]struct {
+		builder  GatewayBuilder
+		expected map[string]interface{}
+	}{
+		"http listener": {
+			builder: Gateway(objmeta.Name("web").Namespace("infra"), "istio").Listeners(Listener("http", HTTP, 80)),
+			expected: map[string]interface{}{
+				"apiVersion": "gateway.networking.k8s.io/v1",
+				"kind":       "Gateway",
+				"metadata":   map[string]interface{}{"name": "web", "namespace": "infra"},
+				"spec": map[string]interface{}{
+					"gatewayClassName": "istio",
+					"listeners":        []interface{}{map[string]interface{}{"name": "http", "protocol": "HTTP", "port": int64(80)}},
+				},
+			},
+		},
+		"https listener with allowed routes": {
+			builder: Gateway(objmeta.Name("web"), "istio").
+				AddListener(Listener("https", HTTPS, 443).
+					Hostname("*.example.com").
+					TerminateTLS("wildcard-cert").
+					AllowRoutesFromNamespaces(map[string]string{"gateway": "web"}).
+					AllowRouteKinds("HTTPRoute")).
+				AddListener(Listener("tls", TLS, 8443).PassthroughTLS().AllowRoutesFrom("All")).
+				Addresses("10.0.0.1"),
+			expected: map[string]interface{}{
+				"apiVersion": "gateway.networking.k8s.io/v1",
+				"kind":       "Gateway",
+				"metadata":   map[string]interface{}{"name": "web"},
+				"spec": map[string]interface{}{
+					"gatewayClassName": "istio",
+					"listeners": []interface{}{
+						map[string]interface{}{
+							"name": "https", "protocol": "HTTPS", "port": int64(443),
+							"hostname": "*.example.com",
+							"tls": map[string]interface{}{
+								"mode":            "Terminate",
+								"certificateRefs": []interface{}{map[string]interface{}{"kind": "Secret", "name": "wildcard-cert"}},
+							},
+							"allowedRoutes": map[string]interface{}{
+								"namespaces": map[string]interface{}{
+									"from":     "Selector",
+									"selector": map[string]interface{}{"matchLabels": map[string]interface{}{"gateway": "web"}},
+								},
+								"kinds": []interface{}{map[string]interface{}{"group": Group, "kind": "HTTPRoute"}},
+							},
+						},
+						map[string]interface{}{
+							"name": "tls", "protocol": "TLS", "port": int64(8443),
+							"tls":           map[string]interface{}{"mode": "Passthrough"},
+							"allowedRoutes": map[string]interface{}{"namespaces": map[string]interface{}{"from": "All"}},
+						},
+					},
+					"addresses": []interface{}{map[string]interface{}{"type": "IPAddress", "value": "10.0.0.1"}},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			obj, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(obj.Object, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", obj.Object, test.expected)
+			}
+		})
+	}
+}
+
+func TestGatewayInvalid(t *testing.T) {
+	tests := map[string]GatewayBuilder{
+		"no class":           Gateway(objmeta.Name("web"), "").Listeners(Listener("http", HTTP, 80)),
+		"no listeners":       Gateway(objmeta.Name("web"), "istio"),
+		"duplicate listener": Gateway(objmeta.Name("web"), "istio").Listeners(Listener("http", HTTP, 80), Listener("http", HTTP, 8080)),
+		"invalid label":      Gateway(objmeta.Name("web").AddLabel("bad key!", "x"), "istio").Listeners(Listener("http", HTTP, 80)),
+	}
+	for name, builder := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := builder.T(); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
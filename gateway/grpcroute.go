@@ -0,0 +1,150 @@
+package gateway
+
+import (
+	"fmt"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/objmeta"
+	unstructuredV1 "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// GRPCRouteBuilder provides a way to build GRPCRoute objects
+type GRPCRouteBuilder map[string]interface{}
+
+// GRPCRoute starts a new GRPCRoute builder using the provided object metadata
+func GRPCRoute(metadata objmeta.Builder) GRPCRouteBuilder {
+	meta, err := metadata.U()
+	return GRPCRouteBuilder(unstruct.SetErr(map[string]interface{}{"apiVersion": Version, "kind": "GRPCRoute", "metadata": meta}, err))
+}
+
+// U returns the unstructured value of the builder
+func (b GRPCRouteBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b GRPCRouteBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the value of the builder as an unstructured object. An error is
+// returned if the route has no parent, if a method match sets neither a
+// service nor a method or if a backend is missing its port.
+func (b GRPCRouteBuilder) T() (unstructuredV1.Unstructured, error) {
+	spec := getSpec(b)
+	if err := validateRoute(spec); err != nil {
+		return unstructuredV1.Unstructured{}, err
+	}
+	rules, _ := spec["rules"].([]interface{})
+	for _, r := range rules {
+		matches, _ := r.(map[string]interface{})["matches"].([]interface{})
+		for _, m := range matches {
+			method, ok := m.(map[string]interface{})["method"].(map[string]interface{})
+			if ok && method["service"] == nil && method["method"] == nil {
+				return unstructuredV1.Unstructured{}, fmt.Errorf("gateway: grpc method match must set a service or method")
+			}
+		}
+	}
+	return toUnstructured(b)
+}
+
+// ParentRefs sets the gateways the route attaches to
+func (b GRPCRouteBuilder) ParentRefs(refs ...ParentRefBuilder) GRPCRouteBuilder {
+	setSpec(b, "parentRefs", parentRefs(refs))
+	return b
+}
+
+// Hostnames restricts the route to requests for the hostnames
+func (b GRPCRouteBuilder) Hostnames(hosts ...string) GRPCRouteBuilder {
+	setSpec(b, "hostnames", stringSlice(hosts))
+	return b
+}
+
+// Rules sets the rules of the route
+func (b GRPCRouteBuilder) Rules(rules ...GRPCRuleBuilder) GRPCRouteBuilder {
+	var slice []interface{}
+	for _, r := range rules {
+		slice = append(slice, unstruct.Nest(r))
+	}
+	setSpec(b, "rules", slice)
+	return b
+}
+
+// AddRule adds a rule to the route
+func (b GRPCRouteBuilder) AddRule(rule GRPCRuleBuilder) GRPCRouteBuilder {
+	appendSpec(b, "rules", unstruct.Nest(rule))
+	return b
+}
+
+// GRPCRuleBuilder provides a way to build GRPCRoute rules
+type GRPCRuleBuilder map[string]interface{}
+
+// GRPCRule starts a new GRPCRoute rule
+func GRPCRule() GRPCRuleBuilder {
+	return GRPCRuleBuilder{}
+}
+
+// U returns the unstructured value of the builder
+func (b GRPCRuleBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b GRPCRuleBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// Matches sets the conditions, any of which selects requests for the rule
+func (b GRPCRuleBuilder) Matches(matches ...GRPCMatchBuilder) GRPCRuleBuilder {
+	var slice []interface{}
+	for _, m := range matches {
+		slice = append(slice, unstruct.Nest(m))
+	}
+	b["matches"] = slice
+	return b
+}
+
+// Filters sets the filters applied to requests matched by the rule (header modifiers and mirrors)
+func (b GRPCRuleBuilder) Filters(filters ...FilterBuilder) GRPCRuleBuilder {
+	b["filters"] = filterSlice(filters)
+	return b
+}
+
+// Backends sets the backends receiving requests matched by the rule, split by weight
+func (b GRPCRuleBuilder) Backends(refs ...BackendRefBuilder) GRPCRuleBuilder {
+	b["backendRefs"] = backendRefs(refs)
+	return b
+}
+
+// GRPCMatchBuilder provides a way to build GRPCRoute request matches
+type GRPCMatchBuilder map[string]interface{}
+
+// Method starts a new match for the gRPC service (i.e. helloworld.Greeter)
+// and method (i.e. SayHello). An empty service or method matches all.
+func Method(service, method string) GRPCMatchBuilder {
+	match := map[string]interface{}{"type": "Exact"}
+	if service != "" {
+		match["service"] = service
+	}
+	if method != "" {
+		match["method"] = method
+	}
+	return GRPCMatchBuilder{"method": match}
+}
+
+// U returns the unstructured value of the builder
+func (b GRPCMatchBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b GRPCMatchBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// Header restricts the match to requests with the metadata header value
+func (b GRPCMatchBuilder) Header(name, value string) GRPCMatchBuilder {
+	headers, _ := b["headers"].([]interface{})
+	b["headers"] = append(headers, map[string]interface{}{"name": name, "value": value})
+	return b
+}
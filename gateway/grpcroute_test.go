@@ -0,0 +1,80 @@
+package gateway
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/objmeta"
+)
+
+func TestGRPCRouteUnstructured(t *testing.T) {
+	tests := map[string]struct {
+		builder  GRPCRouteBuilder
+		expected map[string]interface{}
+	}{
+		"method matches": {
+			builder: GRPCRoute(objmeta.Name("greeter")).
+				ParentRefs(ParentRef("public")).
+				Hostnames("grpc.example.com").
+				Rules(GRPCRule().
+					Matches(Method("helloworld.Greeter", "SayHello").Header("x-env", "canary"), Method("helloworld.Greeter", "")).
+					Filters(SetRequestHeaders(map[string]string{"x-route": "greeter"})).
+					Backends(ServiceRef("greeter", 50051))).
+				AddRule(GRPCRule().Backends(ServiceRef("fallback", 50051))),
+			expected: map[string]interface{}{
+				"apiVersion": "gateway.networking.k8s.io/v1",
+				"kind":       "GRPCRoute",
+				"metadata":   map[string]interface{}{"name": "greeter"},
+				"spec": map[string]interface{}{
+					"parentRefs": []interface{}{map[string]interface{}{"name": "public"}},
+					"hostnames":  []interface{}{"grpc.example.com"},
+					"rules": []interface{}{
+						map[string]interface{}{
+							"matches": []interface{}{
+								map[string]interface{}{
+									"method":  map[string]interface{}{"type": "Exact", "service": "helloworld.Greeter", "method": "SayHello"},
+									"headers": []interface{}{map[string]interface{}{"name": "x-env", "value": "canary"}},
+								},
+								map[string]interface{}{"method": map[string]interface{}{"type": "Exact", "service": "helloworld.Greeter"}},
+							},
+							"filters": []interface{}{map[string]interface{}{
+								"type":                  "RequestHeaderModifier",
+								"requestHeaderModifier": map[string]interface{}{"set": []interface{}{map[string]interface{}{"name": "x-route", "value": "greeter"}}},
+							}},
+							"backendRefs": []interface{}{map[string]interface{}{"name": "greeter", "port": int64(50051)}},
+						},
+						map[string]interface{}{
+							"backendRefs": []interface{}{map[string]interface{}{"name": "fallback", "port": int64(50051)}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			obj, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(obj.Object, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", obj.Object, test.expected)
+			}
+		})
+	}
+}
+
+func TestGRPCRouteInvalid(t *testing.T) {
+	tests := map[string]GRPCRouteBuilder{
+		"no parent":    GRPCRoute(objmeta.Name("greeter")).AddRule(GRPCRule().Backends(ServiceRef("greeter", 50051))),
+		"empty method": GRPCRoute(objmeta.Name("greeter")).ParentRefs(ParentRef("public")).AddRule(GRPCRule().Matches(Method("", ""))),
+	}
+	for name, builder := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := builder.T(); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package gateway
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/objmeta"
+	"github.com/vladimirvivien/kob/service"
+	unstructuredV1 "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// HTTPRouteBuilder provides a way to build HTTPRoute objects
+type HTTPRouteBuilder map[string]interface{}
+
+// HTTPRoute starts a new HTTPRoute builder using the provided object metadata
+func HTTPRoute(metadata objmeta.Builder) HTTPRouteBuilder {
+	meta, err := metadata.U()
+	return HTTPRouteBuilder(unstruct.SetErr(map[string]interface{}{"apiVersion": Version, "kind": "HTTPRoute", "metadata": meta}, err))
+}
+
+// U returns the unstructured value of the builder
+func (b HTTPRouteBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b HTTPRouteBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the value of the builder as an unstructured object. An error is
+// returned if the route has no parent, if a path match does not start with /
+// or if a backend is missing its port.
+func (b HTTPRouteBuilder) T() (unstructuredV1.Unstructured, error) {
+	spec := getSpec(b)
+	if err := validateRoute(spec); err != nil {
+		return unstructuredV1.Unstructured{}, err
+	}
+	rules, _ := spec["rules"].([]interface{})
+	for _, r := range rules {
+		matches, _ := r.(map[string]interface{})["matches"].([]interface{})
+		for _, m := range matches {
+			path, _ := m.(map[string]interface{})["path"].(map[string]interface{})
+			if value, ok := path["value"].(string); ok && path["type"] != "RegularExpression" && !strings.HasPrefix(value, "/") {
+				return unstructuredV1.Unstructured{}, fmt.Errorf("gateway: path %q must start with /", value)
+			}
+		}
+	}
+	return toUnstructured(b)
+}
+
+// ParentRefs sets the gateways the route attaches to
+func (b HTTPRouteBuilder) ParentRefs(refs ...ParentRefBuilder) HTTPRouteBuilder {
+	setSpec(b, "parentRefs", parentRefs(refs))
+	return b
+}
+
+// Hostnames restricts the route to requests for the hostnames
+func (b HTTPRouteBuilder) Hostnames(hosts ...string) HTTPRouteBuilder {
+	setSpec(b, "hostnames", stringSlice(hosts))
+	return b
+}
+
+// Rules sets the rules of the route
+func (b HTTPRouteBuilder) Rules(rules ...HTTPRuleBuilder) HTTPRouteBuilder {
+	var slice []interface{}
+	for _, r := range rules {
+		slice = append(slice, unstruct.Nest(r))
+	}
+	setSpec(b, "rules", slice)
+	return b
+}
+
+// AddRule adds a rule to the route
+func (b HTTPRouteBuilder) AddRule(rule HTTPRuleBuilder) HTTPRouteBuilder {
+	appendSpec(b, "rules", unstruct.Nest(rule))
+	return b
+}
+
+// Backend adds a rule sending all requests to the first port of the service built by svc
+func (b HTTPRouteBuilder) Backend(svc service.Builder) HTTPRouteBuilder {
+	return b.AddRule(HTTPRule().Backends(BackendRef(svc)))
+}
+
+// HTTPRuleBuilder provides a way to build HTTPRoute rules
+type HTTPRuleBuilder map[string]interface{}
+
+// HTTPRule starts a new HTTPRoute rule
+func HTTPRule() HTTPRuleBuilder {
+	return HTTPRuleBuilder{}
+}
+
+// U returns the unstructured value of the builder
+func (b HTTPRuleBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b HTTPRuleBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// Matches sets the conditions, any of which selects requests for the rule
+func (b HTTPRuleBuilder) Matches(matches ...HTTPMatchBuilder) HTTPRuleBuilder {
+	var slice []interface{}
+	for _, m := range matches {
+		slice = append(slice, unstruct.Nest(m))
+	}
+	b["matches"] = slice
+	return b
+}
+
+// Filters sets the filters applied to requests matched by the rule
+func (b HTTPRuleBuilder) Filters(filters ...FilterBuilder) HTTPRuleBuilder {
+	b["filters"] = filterSlice(filters)
+	return b
+}
+
+// Backends sets the backends receiving requests matched by the rule, split by weight
+func (b HTTPRuleBuilder) Backends(refs ...BackendRefBuilder) HTTPRuleBuilder {
+	b["backendRefs"] = backendRefs(refs)
+	return b
+}
+
+// HTTPMatchBuilder provides a way to build HTTPRoute request matches
+type HTTPMatchBuilder map[string]interface{}
+
+// PathPrefix starts a new match for request paths with the prefix
+func PathPrefix(prefix string) HTTPMatchBuilder {
+	return HTTPMatchBuilder{"path": map[string]interface{}{"type": "PathPrefix", "value": prefix}}
+}
+
+// PathExact starts a new match for the request path
+func PathExact(path string) HTTPMatchBuilder {
+	return HTTPMatchBuilder{"path": map[string]interface{}{"type": "Exact", "value": path}}
+}
+
+// PathRegex starts a new match for request paths matching the regular expression
+func PathRegex(expr string) HTTPMatchBuilder {
+	return HTTPMatchBuilder{"path": map[string]interface{}{"type": "RegularExpression", "value": expr}}
+}
+
+// U returns the unstructured value of the builder
+func (b HTTPMatchBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b HTTPMatchBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// Method restricts the match to the HTTP method (i.e. GET)
+func (b HTTPMatchBuilder) Method(method string) HTTPMatchBuilder {
+	b["method"] = method
+	return b
+}
+
+// Header restricts the match to requests with the header value
+func (b HTTPMatchBuilder) Header(name, value string) HTTPMatchBuilder {
+	headers, _ := b["headers"].([]interface{})
+	b["headers"] = append(headers, map[string]interface{}{"name": name, "value": value})
+	return b
+}
+
+// QueryParam restricts the match to requests with the query parameter value
+func (b HTTPMatchBuilder) QueryParam(name, value string) HTTPMatchBuilder {
+	params, _ := b["queryParams"].([]interface{})
+	b["queryParams"] = append(params, map[string]interface{}{"name": name, "value": value})
+	return b
+}
+
+// validateRoute checks that the route attaches to a gateway and that its
+// backends have a port, after the errors recorded on its backends
+func validateRoute(spec map[string]interface{}) error {
+	if err := unstruct.Err(spec); err != nil {
+		return err
+	}
+	if refs, _ := spec["parentRefs"].([]interface{}); len(refs) == 0 {
+		return fmt.Errorf("gateway: route must have at least one parent gateway")
+	}
+	rules, _ := spec["rules"].([]interface{})
+	for _, r := range rules {
+		refs, _ := r.(map[string]interface{})["backendRefs"].([]interface{})
+		for _, ref := range refs {
+			backend := ref.(map[string]interface{})
+			if _, ok := backend["port"]; !ok {
+				return fmt.Errorf("gateway: backend %v must set a port", backend["name"])
+			}
+			if weight, ok := backend["weight"].(int64); ok && weight < 0 {
+				return fmt.Errorf("gateway: backend %v weight must not be negative", backend["name"])
+			}
+		}
+	}
+	return nil
+}
+
+func setSpec(obj map[string]interface{}, key string, val interface{}) {
+	spec := getSpec(obj)
+	spec[key] = val
+	obj["spec"] = spec
+}
+
+func appendSpec(obj map[string]interface{}, key string, val interface{}) {
+	slice, _ := getSpec(obj)[key].([]interface{})
+	setSpec(obj, key, append(slice, val))
+}
+
+func getSpec(obj map[string]interface{}) map[string]interface{} {
+	spec, ok := obj["spec"].(map[string]interface{})
+	if !ok {
+		spec = map[string]interface{}{}
+	}
+	return spec
+}
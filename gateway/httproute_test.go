@@ -0,0 +1,120 @@
+package gateway
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/objmeta"
+	"github.com/vladimirvivien/kob/service"
+)
+
+func TestHTTPRouteUnstructured(t *testing.T) {
+	web := service.Object(objmeta.Name("web").Namespace("shop")).Ports(service.Port(8080).Name("http"))
+	gw := Gateway(objmeta.Name("public").Namespace("infra"), "istio")
+	tests := map[string]struct {
+		builder  HTTPRouteBuilder
+		expected map[string]interface{}
+	}{
+		"backend service": {
+			builder: HTTPRoute(objmeta.Name("web").Namespace("shop")).ParentRefs(ParentRefFor(gw)).Backend(web),
+			expected: map[string]interface{}{
+				"apiVersion": "gateway.networking.k8s.io/v1",
+				"kind":       "HTTPRoute",
+				"metadata":   map[string]interface{}{"name": "web", "namespace": "shop"},
+				"spec": map[string]interface{}{
+					"parentRefs": []interface{}{map[string]interface{}{"name": "public", "namespace": "infra"}},
+					"rules": []interface{}{map[string]interface{}{
+						"backendRefs": []interface{}{map[string]interface{}{"name": "web", "namespace": "shop", "port": int64(8080)}},
+					}},
+				},
+			},
+		},
+		"matches, filters and weighted backends": {
+			builder: HTTPRoute(objmeta.Name("web")).
+				ParentRefs(ParentRef("public").Namespace("infra").SectionName("https").Port(443)).
+				Hostnames("shop.example.com").
+				Rules(
+					HTTPRule().
+						Matches(
+							PathPrefix("/api").Method("GET").Header("x-canary", "true").QueryParam("debug", "1"),
+							PathExact("/healthz"),
+						).
+						Filters(SetRequestHeaders(map[string]string{"x-route": "web"}), RewritePathPrefix("/")).
+						Backends(ServiceRef("web-v1", 8080).Weight(90), ServiceRef("web-v2", 8080).Weight(10)),
+				).
+				AddRule(HTTPRule().Matches(PathRegex(`^/v[0-9]+/`)).Backends(ServiceRef("legacy", 80).Namespace("old"))),
+			expected: map[string]interface{}{
+				"apiVersion": "gateway.networking.k8s.io/v1",
+				"kind":       "HTTPRoute",
+				"metadata":   map[string]interface{}{"name": "web"},
+				"spec": map[string]interface{}{
+					"parentRefs": []interface{}{map[string]interface{}{"name": "public", "namespace": "infra", "sectionName": "https", "port": int64(443)}},
+					"hostnames":  []interface{}{"shop.example.com"},
+					"rules": []interface{}{
+						map[string]interface{}{
+							"matches": []interface{}{
+								map[string]interface{}{
+									"path":        map[string]interface{}{"type": "PathPrefix", "value": "/api"},
+									"method":      "GET",
+									"headers":     []interface{}{map[string]interface{}{"name": "x-canary", "value": "true"}},
+									"queryParams": []interface{}{map[string]interface{}{"name": "debug", "value": "1"}},
+								},
+								map[string]interface{}{"path": map[string]interface{}{"type": "Exact", "value": "/healthz"}},
+							},
+							"filters": []interface{}{
+								map[string]interface{}{
+									"type":                  "RequestHeaderModifier",
+									"requestHeaderModifier": map[string]interface{}{"set": []interface{}{map[string]interface{}{"name": "x-route", "value": "web"}}},
+								},
+								map[string]interface{}{
+									"type":       "URLRewrite",
+									"urlRewrite": map[string]interface{}{"path": map[string]interface{}{"type": "ReplacePrefixMatch", "replacePrefixMatch": "/"}},
+								},
+							},
+							"backendRefs": []interface{}{
+								map[string]interface{}{"name": "web-v1", "port": int64(8080), "weight": int64(90)},
+								map[string]interface{}{"name": "web-v2", "port": int64(8080), "weight": int64(10)},
+							},
+						},
+						map[string]interface{}{
+							"matches":     []interface{}{map[string]interface{}{"path": map[string]interface{}{"type": "RegularExpression", "value": `^/v[0-9]+/`}}},
+							"backendRefs": []interface{}{map[string]interface{}{"name": "legacy", "namespace": "old", "port": int64(80)}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			obj, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(obj.Object, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", obj.Object, test.expected)
+			}
+		})
+	}
+}
+
+func TestHTTPRouteInvalid(t *testing.T) {
+	route := func() HTTPRouteBuilder {
+		return HTTPRoute(objmeta.Name("web")).ParentRefs(ParentRef("public"))
+	}
+	tests := map[string]HTTPRouteBuilder{
+		"no parent":       HTTPRoute(objmeta.Name("web")).Backend(service.Object(objmeta.Name("web")).Ports(service.Port(80))),
+		"backend no port": route().Backend(service.Object(objmeta.Name("web"))),
+		"negative weight": route().AddRule(HTTPRule().Backends(ServiceRef("web", 80).Weight(-1))),
+		"relative path":   route().AddRule(HTTPRule().Matches(PathPrefix("api")).Backends(ServiceRef("web", 80))),
+		"invalid service": route().Backend(service.Object(objmeta.Name("My_Web")).Ports(service.Port(80))),
+	}
+	for name, builder := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := builder.T(); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
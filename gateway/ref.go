@@ -0,0 +1,137 @@
+package gateway
+
+import (
+	"fmt"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/service"
+)
+
+// ParentRefBuilder provides a way to build references from routes to the gateways they attach to
+type ParentRefBuilder map[string]interface{}
+
+// ParentRef starts a new reference to the named gateway
+func ParentRef(gatewayName string) ParentRefBuilder {
+	return ParentRefBuilder{"name": gatewayName}
+}
+
+// ParentRefFor starts a new reference to the gateway built by gw
+func ParentRefFor(gw GatewayBuilder) ParentRefBuilder {
+	name, namespace := nameAndNamespace(gw)
+	ref := ParentRef(name)
+	if namespace != "" {
+		ref["namespace"] = namespace
+	}
+	return ref
+}
+
+// U returns the unstructured value of the builder
+func (b ParentRefBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b ParentRefBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// Namespace sets the namespace of the gateway, when different from the route's
+func (b ParentRefBuilder) Namespace(ns string) ParentRefBuilder {
+	b["namespace"] = ns
+	return b
+}
+
+// SectionName attaches the route to the named listener only
+func (b ParentRefBuilder) SectionName(listener string) ParentRefBuilder {
+	b["sectionName"] = listener
+	return b
+}
+
+// Port attaches the route to the listeners on the port only
+func (b ParentRefBuilder) Port(port int32) ParentRefBuilder {
+	b["port"] = int64(port)
+	return b
+}
+
+// BackendRefBuilder provides a way to build references from route rules to backend services
+type BackendRefBuilder map[string]interface{}
+
+// ServiceRef starts a new reference to the named service port
+func ServiceRef(name string, port int32) BackendRefBuilder {
+	return BackendRefBuilder{"name": name, "port": int64(port)}
+}
+
+// BackendRef starts a new reference to the first port of the service built by svc,
+// an invalid service is recorded as an error returned by the route's T
+func BackendRef(svc service.Builder) BackendRefBuilder {
+	obj, err := svc.T()
+	if err != nil {
+		return BackendRefBuilder(unstruct.SetErr(map[string]interface{}{}, fmt.Errorf("gateway: backend service: %w", err)))
+	}
+	ref := BackendRefBuilder{"name": obj.Name}
+	if obj.Namespace != "" {
+		ref["namespace"] = obj.Namespace
+	}
+	if len(obj.Spec.Ports) > 0 {
+		ref["port"] = int64(obj.Spec.Ports[0].Port)
+	}
+	return ref
+}
+
+// U returns the unstructured value of the builder
+func (b BackendRefBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b BackendRefBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// Port sets the port of the backend service
+func (b BackendRefBuilder) Port(port int32) BackendRefBuilder {
+	b["port"] = int64(port)
+	return b
+}
+
+// Namespace sets the namespace of the backend, which requires a ReferenceGrant
+// when different from the route's
+func (b BackendRefBuilder) Namespace(ns string) BackendRefBuilder {
+	b["namespace"] = ns
+	return b
+}
+
+// Weight sets the proportion of requests sent to the backend, relative to the rule's other backends
+func (b BackendRefBuilder) Weight(weight int32) BackendRefBuilder {
+	b["weight"] = int64(weight)
+	return b
+}
+
+// Filters sets the filters applied to requests sent to the backend
+func (b BackendRefBuilder) Filters(filters ...FilterBuilder) BackendRefBuilder {
+	b["filters"] = filterSlice(filters)
+	return b
+}
+
+func parentRefs(refs []ParentRefBuilder) []interface{} {
+	var slice []interface{}
+	for _, r := range refs {
+		slice = append(slice, unstruct.Nest(r))
+	}
+	return slice
+}
+
+func backendRefs(refs []BackendRefBuilder) []interface{} {
+	var slice []interface{}
+	for _, r := range refs {
+		slice = append(slice, unstruct.Nest(r))
+	}
+	return slice
+}
+
+func nameAndNamespace(obj map[string]interface{}) (string, string) {
+	meta, _ := obj["metadata"].(map[string]interface{})
+	name, _ := meta["name"].(string)
+	namespace, _ := meta["namespace"].(string)
+	return name, namespace
+}
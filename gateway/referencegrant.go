@@ -0,0 +1,70 @@
+package gateway
+
+import (
+	"fmt"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/objmeta"
+	unstructuredV1 "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ReferenceGrantBuilder provides a way to build ReferenceGrant objects, which
+// allow routes in other namespaces to reference objects in the grant's namespace
+type ReferenceGrantBuilder map[string]interface{}
+
+// ReferenceGrant starts a new ReferenceGrant builder using the provided object metadata
+func ReferenceGrant(metadata objmeta.Builder) ReferenceGrantBuilder {
+	meta, err := metadata.U()
+	return ReferenceGrantBuilder(unstruct.SetErr(map[string]interface{}{"apiVersion": BetaVersion, "kind": "ReferenceGrant", "metadata": meta}, err))
+}
+
+// U returns the unstructured value of the builder
+func (b ReferenceGrantBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b ReferenceGrantBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the value of the builder as an unstructured object. An error is
+// returned if the grant has no from or to entries.
+func (b ReferenceGrantBuilder) T() (unstructuredV1.Unstructured, error) {
+	spec := getSpec(b)
+	if from, _ := spec["from"].([]interface{}); len(from) == 0 {
+		return unstructuredV1.Unstructured{}, fmt.Errorf("gateway: reference grant must have at least one from entry")
+	}
+	if to, _ := spec["to"].([]interface{}); len(to) == 0 {
+		return unstructuredV1.Unstructured{}, fmt.Errorf("gateway: reference grant must have at least one to entry")
+	}
+	return toUnstructured(b)
+}
+
+// From allows objects of the group and kind (i.e. gateway.networking.k8s.io HTTPRoute)
+// in the namespace to reference the grant's targets
+func (b ReferenceGrantBuilder) From(group, kind, namespace string) ReferenceGrantBuilder {
+	appendSpec(b, "from", map[string]interface{}{"group": group, "kind": kind, "namespace": namespace})
+	return b
+}
+
+// FromRoutes allows HTTPRoutes and GRPCRoutes in the namespace to reference the grant's targets
+func (b ReferenceGrantBuilder) FromRoutes(namespace string) ReferenceGrantBuilder {
+	return b.From(Group, "HTTPRoute", namespace).From(Group, "GRPCRoute", namespace)
+}
+
+// To allows references to objects of the group and kind (i.e. "" Service),
+// restricted to the named object when name is not empty
+func (b ReferenceGrantBuilder) To(group, kind, name string) ReferenceGrantBuilder {
+	to := map[string]interface{}{"group": group, "kind": kind}
+	if name != "" {
+		to["name"] = name
+	}
+	appendSpec(b, "to", to)
+	return b
+}
+
+// ToServices allows references to all services in the grant's namespace
+func (b ReferenceGrantBuilder) ToServices() ReferenceGrantBuilder {
+	return b.To("", "Service", "")
+}
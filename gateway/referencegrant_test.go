@@ -0,0 +1,69 @@
+package gateway
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/objmeta"
+)
+
+func TestReferenceGrantUnstructured(t *testing.T) {
+	tests := map[string]struct {
+		builder  ReferenceGrantBuilder
+		expected map[string]interface{}
+	}{
+		"routes to services": {
+			builder: ReferenceGrant(objmeta.Name("allow-shop").Namespace("backends")).FromRoutes("shop").ToServices(),
+			expected: map[string]interface{}{
+				"apiVersion": "gateway.networking.k8s.io/v1beta1",
+				"kind":       "ReferenceGrant",
+				"metadata":   map[string]interface{}{"name": "allow-shop", "namespace": "backends"},
+				"spec": map[string]interface{}{
+					"from": []interface{}{
+						map[string]interface{}{"group": Group, "kind": "HTTPRoute", "namespace": "shop"},
+						map[string]interface{}{"group": Group, "kind": "GRPCRoute", "namespace": "shop"},
+					},
+					"to": []interface{}{map[string]interface{}{"group": "", "kind": "Service"}},
+				},
+			},
+		},
+		"gateway to named secret": {
+			builder: ReferenceGrant(objmeta.Name("allow-cert")).From(Group, "Gateway", "infra").To("", "Secret", "wildcard-cert"),
+			expected: map[string]interface{}{
+				"apiVersion": "gateway.networking.k8s.io/v1beta1",
+				"kind":       "ReferenceGrant",
+				"metadata":   map[string]interface{}{"name": "allow-cert"},
+				"spec": map[string]interface{}{
+					"from": []interface{}{map[string]interface{}{"group": Group, "kind": "Gateway", "namespace": "infra"}},
+					"to":   []interface{}{map[string]interface{}{"group": "", "kind": "Secret", "name": "wildcard-cert"}},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			obj, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(obj.Object, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", obj.Object, test.expected)
+			}
+		})
+	}
+}
+
+func TestReferenceGrantInvalid(t *testing.T) {
+	tests := map[string]ReferenceGrantBuilder{
+		"no from": ReferenceGrant(objmeta.Name("grant")).ToServices(),
+		"no to":   ReferenceGrant(objmeta.Name("grant")).FromRoutes("shop"),
+	}
+	for name, builder := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := builder.T(); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package hpa
+
+import (
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	autoscalingV2 "k8s.io/api/autoscaling/v2"
+)
+
+// ScalingRulesBuilder provides a way to build values of type autoscalingV2.HPAScalingRules
+type ScalingRulesBuilder map[string]interface{}
+
+// ScalingRules starts new scaling rules with the number of seconds for which
+// past recommendations are considered while scaling
+func ScalingRules(stabilizationWindowSeconds int) ScalingRulesBuilder {
+	return ScalingRulesBuilder{"stabilizationWindowSeconds": int64(stabilizationWindowSeconds)}
+}
+
+// U returns the unstructured value of the builder
+func (b ScalingRulesBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b ScalingRulesBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b ScalingRulesBuilder) T() (autoscalingV2.HPAScalingRules, error) {
+	var rules autoscalingV2.HPAScalingRules
+	if err := unstruct.FromUnstructured(b, &rules); err != nil {
+		return autoscalingV2.HPAScalingRules{}, err
+	}
+	return rules, nil
+}
+
+// SelectPolicy sets which policy is used when several are specified (Max, Min, Disabled)
+func (b ScalingRulesBuilder) SelectPolicy(sel autoscalingV2.ScalingPolicySelect) ScalingRulesBuilder {
+	b["selectPolicy"] = sel
+	return b
+}
+
+// AddPolicy adds a policy allowing a change of value (pods or percent,
+// depending on the policy type) over the period in seconds
+func (b ScalingRulesBuilder) AddPolicy(policyType autoscalingV2.HPAScalingPolicyType, value, periodSeconds int) ScalingRulesBuilder {
+	policies, _ := b["policies"].([]interface{})
+	b["policies"] = append(policies, map[string]interface{}{
+		"type":          policyType,
+		"value":         int64(value),
+		"periodSeconds": int64(periodSeconds),
+	})
+	return b
+}
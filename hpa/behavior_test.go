@@ -0,0 +1,51 @@
+package hpa
+
+import (
+	"reflect"
+	"testing"
+
+	autoscalingV2 "k8s.io/api/autoscaling/v2"
+)
+
+func TestScalingRulesStructured(t *testing.T) {
+	zero, window := int32(0), int32(120)
+	maxPolicy := autoscalingV2.MaxChangePolicySelect
+	tests := map[string]struct {
+		builder  ScalingRulesBuilder
+		expected autoscalingV2.HPAScalingRules
+	}{
+		"empty": {
+			builder:  ScalingRulesBuilder{},
+			expected: autoscalingV2.HPAScalingRules{},
+		},
+		"window only": {
+			builder:  ScalingRules(0),
+			expected: autoscalingV2.HPAScalingRules{StabilizationWindowSeconds: &zero},
+		},
+		"with policies": {
+			builder: ScalingRules(120).SelectPolicy(autoscalingV2.MaxChangePolicySelect).
+				AddPolicy(autoscalingV2.PercentScalingPolicy, 100, 15).
+				AddPolicy(autoscalingV2.PodsScalingPolicy, 4, 15),
+			expected: autoscalingV2.HPAScalingRules{
+				StabilizationWindowSeconds: &window,
+				SelectPolicy:               &maxPolicy,
+				Policies: []autoscalingV2.HPAScalingPolicy{
+					{Type: autoscalingV2.PercentScalingPolicy, Value: 100, PeriodSeconds: 15},
+					{Type: autoscalingV2.PodsScalingPolicy, Value: 4, PeriodSeconds: 15},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rules, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(rules, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", rules, test.expected)
+			}
+		})
+	}
+}
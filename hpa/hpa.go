@@ -0,0 +1,177 @@
+// Package hpa contains builder types to build values of type autoscalingV2.HorizontalPodAutoscaler
+package hpa
+
+import (
+	"fmt"
+
+	"github.com/vladimirvivien/kob/deployment"
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	autoscalingV2 "k8s.io/api/autoscaling/v2"
+	coreV1 "k8s.io/api/core/v1"
+)
+
+// Builder provides a way to build values of type autoscalingV2.HorizontalPodAutoscaler
+type Builder map[string]interface{}
+
+// Object starts a new HorizontalPodAutoscaler builder using the provided object metadata
+func Object(metadata objmeta.Builder) Builder {
+	meta, err := metadata.U()
+	return Builder(unstruct.SetErr(map[string]interface{}{"metadata": meta}, err))
+}
+
+// For starts a new HorizontalPodAutoscaler builder, named after the deployment
+// (see naming.Name) in the deployment's namespace, that targets the deployment
+// for scaling. An invalid deployment is recorded as an error returned by T.
+func For(dep deployment.Builder) Builder {
+	obj, err := dep.T()
+	if err != nil {
+		return Builder(unstruct.SetErr(map[string]interface{}{}, fmt.Errorf("hpa: target deployment: %w", err)))
+	}
+	return Object(objmeta.Name(naming.Name(obj.Name, "hpa")).Namespace(obj.Namespace)).ScaleTargetRef("apps/v1", "Deployment", obj.Name)
+}
+
+// U returns the unstructured value of the builder
+func (b Builder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b Builder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder. An error is returned if
+// maxReplicas is not set or is lower than minReplicas.
+func (b Builder) T() (autoscalingV2.HorizontalPodAutoscaler, error) {
+	var hpa autoscalingV2.HorizontalPodAutoscaler
+	if err := unstruct.FromUnstructured(b, &hpa); err != nil {
+		return autoscalingV2.HorizontalPodAutoscaler{}, err
+	}
+	if err := naming.Validate("HorizontalPodAutoscaler", hpa.Name); err != nil {
+		return autoscalingV2.HorizontalPodAutoscaler{}, err
+	}
+	if hpa.Spec.MaxReplicas < 1 {
+		return autoscalingV2.HorizontalPodAutoscaler{}, fmt.Errorf("hpa: maxReplicas must be set to at least 1")
+	}
+	if min := hpa.Spec.MinReplicas; min != nil && *min > hpa.Spec.MaxReplicas {
+		return autoscalingV2.HorizontalPodAutoscaler{}, fmt.Errorf("hpa: minReplicas %d is greater than maxReplicas %d", *min, hpa.Spec.MaxReplicas)
+	}
+	return hpa, nil
+}
+
+// ScaleTargetRef sets the reference to the scaled resource
+func (b Builder) ScaleTargetRef(apiVersion, kind, name string) Builder {
+	return b.setSpec("scaleTargetRef", map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"name":       name,
+	})
+}
+
+// MinReplicas sets the lower limit for the number of replicas
+func (b Builder) MinReplicas(r int) Builder {
+	return b.setSpec("minReplicas", int64(r))
+}
+
+// MaxReplicas sets the upper limit for the number of replicas
+func (b Builder) MaxReplicas(r int) Builder {
+	return b.setSpec("maxReplicas", int64(r))
+}
+
+// Metrics sets the metrics used to calculate the desired replica count
+func (b Builder) Metrics(metrics ...MetricBuilder) Builder {
+	var slice []interface{}
+	for _, m := range metrics {
+		slice = append(slice, unstruct.Nest(m))
+	}
+	return b.setSpec("metrics", slice)
+}
+
+// ScaleUp sets the scaling behavior in the up direction
+func (b Builder) ScaleUp(rules ScalingRulesBuilder) Builder {
+	return b.setBehavior("scaleUp", rules)
+}
+
+// ScaleDown sets the scaling behavior in the down direction
+func (b Builder) ScaleDown(rules ScalingRulesBuilder) Builder {
+	return b.setBehavior("scaleDown", rules)
+}
+
+// Warnings returns a list of issues found when checking the autoscaler
+// against the target deployment. Specifically, it reports containers that
+// have no request for a resource used in a utilization metric, since
+// the utilization percentage is computed against that request. A limit
+// satisfies the check, as the request defaults to the limit.
+func (b Builder) Warnings(target deployment.Builder) []string {
+	hpa, err := b.T()
+	if err != nil {
+		return []string{err.Error()}
+	}
+	dep, err := target.T()
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	var warnings []string
+	containers := dep.Spec.Template.Spec.Containers
+	for _, metric := range hpa.Spec.Metrics {
+		switch {
+		case metric.Resource != nil && metric.Resource.Target.Type == autoscalingV2.UtilizationMetricType:
+			for _, c := range containers {
+				if !hasRequest(c, metric.Resource.Name) {
+					warnings = append(warnings, missingRequest(c.Name, metric.Resource.Name))
+				}
+			}
+		case metric.ContainerResource != nil && metric.ContainerResource.Target.Type == autoscalingV2.UtilizationMetricType:
+			for _, c := range containers {
+				if c.Name != metric.ContainerResource.Container {
+					continue
+				}
+				if !hasRequest(c, metric.ContainerResource.Name) {
+					warnings = append(warnings, missingRequest(c.Name, metric.ContainerResource.Name))
+				}
+			}
+		}
+	}
+	return warnings
+}
+
+// hasRequest returns whether the container requests the resource, explicitly
+// or through a limit
+func hasRequest(c coreV1.Container, res coreV1.ResourceName) bool {
+	if _, ok := c.Resources.Requests[res]; ok {
+		return true
+	}
+	_, ok := c.Resources.Limits[res]
+	return ok
+}
+
+func missingRequest(container string, res coreV1.ResourceName) string {
+	return fmt.Sprintf("hpa: container %s has no %s request, %s utilization cannot be computed", container, res, res)
+}
+
+func (b Builder) setBehavior(key string, rules ScalingRulesBuilder) Builder {
+	behavior, ok := b.getHPASpec()["behavior"].(map[string]interface{})
+	if !ok {
+		behavior = map[string]interface{}{}
+	}
+	behavior[key] = unstruct.Nest(rules)
+	return b.setSpec("behavior", behavior)
+}
+
+func (b Builder) setSpec(key string, val interface{}) Builder {
+	spec := b.getHPASpec()
+	spec[key] = val
+	b["spec"] = spec
+	return b
+}
+
+func (b Builder) getHPASpec() map[string]interface{} {
+	specIface, ok := b["spec"]
+	if !ok {
+		specIface = map[string]interface{}{}
+	}
+	return specIface.(map[string]interface{})
+}
@@ -0,0 +1,161 @@
+package hpa
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vladimirvivien/kob/container"
+	"github.com/vladimirvivien/kob/deployment"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	autoscalingV2 "k8s.io/api/autoscaling/v2"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestHPATyped(t *testing.T) {
+	minReplicas, utilization := int32(2), int32(80)
+	window := int32(300)
+	tests := map[string]struct {
+		builder  Builder
+		expected autoscalingV2.HorizontalPodAutoscaler
+	}{
+		"max replicas only": {
+			builder: Builder{}.MaxReplicas(3),
+			expected: autoscalingV2.HorizontalPodAutoscaler{
+				Spec: autoscalingV2.HorizontalPodAutoscalerSpec{MaxReplicas: 3},
+			},
+		},
+		"object meta": {
+			builder: Object(objmeta.Name("simple-hpa").Namespace("default")).MaxReplicas(3),
+			expected: autoscalingV2.HorizontalPodAutoscaler{
+				ObjectMeta: metaV1.ObjectMeta{Name: "simple-hpa", Namespace: "default"},
+				Spec:       autoscalingV2.HorizontalPodAutoscalerSpec{MaxReplicas: 3},
+			},
+		},
+		"for deployment": {
+			builder: For(deployment.Object(objmeta.Name("web").Namespace("default"))).MinReplicas(2).MaxReplicas(10).
+				Metrics(ResourceMetric(coreV1.ResourceCPU, Utilization(80))).
+				ScaleDown(ScalingRules(300).AddPolicy(autoscalingV2.PodsScalingPolicy, 1, 60)),
+			expected: autoscalingV2.HorizontalPodAutoscaler{
+				ObjectMeta: metaV1.ObjectMeta{Name: naming.Name("web", "hpa"), Namespace: "default"},
+				Spec: autoscalingV2.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: autoscalingV2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "web"},
+					MinReplicas:    &minReplicas,
+					MaxReplicas:    10,
+					Metrics: []autoscalingV2.MetricSpec{{
+						Type: autoscalingV2.ResourceMetricSourceType,
+						Resource: &autoscalingV2.ResourceMetricSource{
+							Name:   coreV1.ResourceCPU,
+							Target: autoscalingV2.MetricTarget{Type: autoscalingV2.UtilizationMetricType, AverageUtilization: &utilization},
+						},
+					}},
+					Behavior: &autoscalingV2.HorizontalPodAutoscalerBehavior{
+						ScaleDown: &autoscalingV2.HPAScalingRules{
+							StabilizationWindowSeconds: &window,
+							Policies:                   []autoscalingV2.HPAScalingPolicy{{Type: autoscalingV2.PodsScalingPolicy, Value: 1, PeriodSeconds: 60}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			hpa, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(hpa, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", hpa, test.expected)
+			}
+		})
+	}
+}
+
+func TestHPAForLongName(t *testing.T) {
+	name := strings.Repeat("web.", 60) + "app"
+	hpa, err := For(deployment.Object(objmeta.Name(name))).MaxReplicas(3).T()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hpa.Name) > naming.MaxLength || hpa.Name != naming.Name(name, "hpa") {
+		t.Errorf("unexpected name %q", hpa.Name)
+	}
+	if hpa.Spec.ScaleTargetRef.Name != name {
+		t.Errorf("unexpected scale target %q", hpa.Spec.ScaleTargetRef.Name)
+	}
+}
+
+func TestHPAForInvalidDeployment(t *testing.T) {
+	if _, err := For(deployment.Object(objmeta.Name("My_App"))).MinReplicas(2).MaxReplicas(3).T(); err == nil {
+		t.Error("expecting error, got none")
+	}
+}
+
+func TestHPAInvalidReplicas(t *testing.T) {
+	tests := map[string]Builder{
+		"empty":                Builder{},
+		"no max replicas":      Object(objmeta.Name("web")).MinReplicas(2),
+		"zero max replicas":    Object(objmeta.Name("web")).MaxReplicas(0),
+		"min greater than max": Object(objmeta.Name("web")).MinReplicas(5).MaxReplicas(3),
+	}
+
+	for name, builder := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := builder.T(); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
+
+func TestHPAWarnings(t *testing.T) {
+	cpu := resource.MustParse("100m")
+	tests := map[string]struct {
+		builder  Builder
+		target   deployment.Builder
+		warnings int
+	}{
+		"no request for utilization": {
+			builder:  Object(objmeta.Name("web")).MaxReplicas(5).Metrics(ResourceMetric(coreV1.ResourceCPU, Utilization(80))),
+			target:   deployment.Object(objmeta.Name("web")).PodSpec(container.Name("web"), container.Name("sidecar").AddResourceRequest(coreV1.ResourceCPU, cpu)),
+			warnings: 1,
+		},
+		"requests set": {
+			builder:  Object(objmeta.Name("web")).MaxReplicas(5).Metrics(ResourceMetric(coreV1.ResourceCPU, Utilization(80))),
+			target:   deployment.Object(objmeta.Name("web")).PodSpec(container.Name("web").AddResourceRequest(coreV1.ResourceCPU, cpu)),
+			warnings: 0,
+		},
+		"average value does not need request": {
+			builder:  Object(objmeta.Name("web")).MaxReplicas(5).Metrics(ResourceMetric(coreV1.ResourceMemory, AverageValue("500Mi"))),
+			target:   deployment.Object(objmeta.Name("web")).PodSpec(container.Name("web")),
+			warnings: 0,
+		},
+		"limits set": {
+			builder:  Object(objmeta.Name("web")).MaxReplicas(5).Metrics(ResourceMetric(coreV1.ResourceCPU, Utilization(80))),
+			target:   deployment.Object(objmeta.Name("web")).PodSpec(container.Name("web").AddResourceLimit(coreV1.ResourceCPU, cpu)),
+			warnings: 0,
+		},
+		"container resource": {
+			builder: Object(objmeta.Name("web")).MaxReplicas(5).Metrics(
+				ContainerResourceMetric(coreV1.ResourceMemory, "web", Utilization(70)),
+				ContainerResourceMetric(coreV1.ResourceCPU, "web", Utilization(70)),
+			),
+			target:   deployment.Object(objmeta.Name("web")).PodSpec(container.Name("web").AddResourceRequest(coreV1.ResourceCPU, cpu), container.Name("sidecar")),
+			warnings: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			warnings := test.builder.Warnings(test.target)
+			if len(warnings) != test.warnings {
+				t.Errorf("expecting %d warnings, got %d: %v", test.warnings, len(warnings), warnings)
+			}
+		})
+	}
+}
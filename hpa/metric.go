@@ -0,0 +1,134 @@
+package hpa
+
+import (
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	autoscalingV2 "k8s.io/api/autoscaling/v2"
+	coreV1 "k8s.io/api/core/v1"
+)
+
+// TargetBuilder provides a way to build values of type autoscalingV2.MetricTarget
+type TargetBuilder map[string]interface{}
+
+// Utilization creates a target for the average resource utilization, as a
+// percentage of the requested resource, across all pods
+func Utilization(percent int32) TargetBuilder {
+	return TargetBuilder{"type": autoscalingV2.UtilizationMetricType, "averageUtilization": int64(percent)}
+}
+
+// AverageValue creates a target for the metric value (i.e. "500m") averaged across all pods
+func AverageValue(qty string) TargetBuilder {
+	return TargetBuilder{"type": autoscalingV2.AverageValueMetricType, "averageValue": qty}
+}
+
+// Value creates a target for the metric value (i.e. "10k")
+func Value(qty string) TargetBuilder {
+	return TargetBuilder{"type": autoscalingV2.ValueMetricType, "value": qty}
+}
+
+// U returns the unstructured value of the builder
+func (b TargetBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b TargetBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b TargetBuilder) T() (autoscalingV2.MetricTarget, error) {
+	var target autoscalingV2.MetricTarget
+	if err := unstruct.FromUnstructured(b, &target); err != nil {
+		return autoscalingV2.MetricTarget{}, err
+	}
+	return target, nil
+}
+
+// MetricBuilder provides a way to build values of type autoscalingV2.MetricSpec
+type MetricBuilder map[string]interface{}
+
+// ResourceMetric creates a metric for a resource (cpu, memory) of the scaled pods
+func ResourceMetric(name coreV1.ResourceName, target TargetBuilder) MetricBuilder {
+	return MetricBuilder{
+		"type":     autoscalingV2.ResourceMetricSourceType,
+		"resource": map[string]interface{}{"name": name, "target": unstruct.Nest(target)},
+	}
+}
+
+// ContainerResourceMetric creates a metric for a resource of a single container in the scaled pods
+func ContainerResourceMetric(name coreV1.ResourceName, container string, target TargetBuilder) MetricBuilder {
+	return MetricBuilder{
+		"type":              autoscalingV2.ContainerResourceMetricSourceType,
+		"containerResource": map[string]interface{}{"name": name, "container": container, "target": unstruct.Nest(target)},
+	}
+}
+
+// PodsMetric creates a metric describing each pod of the scaled resource
+// (i.e. transactions-processed-per-second)
+func PodsMetric(metricName string, target TargetBuilder) MetricBuilder {
+	return MetricBuilder{
+		"type": autoscalingV2.PodsMetricSourceType,
+		"pods": map[string]interface{}{"metric": map[string]interface{}{"name": metricName}, "target": unstruct.Nest(target)},
+	}
+}
+
+// ObjectMetric creates a metric describing a single object (i.e. hits-per-second on an Ingress)
+func ObjectMetric(apiVersion, kind, name, metricName string, target TargetBuilder) MetricBuilder {
+	return MetricBuilder{
+		"type": autoscalingV2.ObjectMetricSourceType,
+		"object": map[string]interface{}{
+			"describedObject": map[string]interface{}{"apiVersion": apiVersion, "kind": kind, "name": name},
+			"metric":          map[string]interface{}{"name": metricName},
+			"target":          unstruct.Nest(target),
+		},
+	}
+}
+
+// ExternalMetric creates a metric not associated with any Kubernetes object
+// (i.e. length of a queue in a cloud messaging service)
+func ExternalMetric(metricName string, target TargetBuilder) MetricBuilder {
+	return MetricBuilder{
+		"type":     autoscalingV2.ExternalMetricSourceType,
+		"external": map[string]interface{}{"metric": map[string]interface{}{"name": metricName}, "target": unstruct.Nest(target)},
+	}
+}
+
+// U returns the unstructured value of the builder
+func (b MetricBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b MetricBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b MetricBuilder) T() (autoscalingV2.MetricSpec, error) {
+	var metric autoscalingV2.MetricSpec
+	if err := unstruct.FromUnstructured(b, &metric); err != nil {
+		return autoscalingV2.MetricSpec{}, err
+	}
+	return metric, nil
+}
+
+// Selector narrows the pods, object or external metric using the labels.
+// It has no effect on resource metrics.
+func (b MetricBuilder) Selector(labels map[string]string) MetricBuilder {
+	var source map[string]interface{}
+	for _, key := range []string{"pods", "object", "external"} {
+		if src, ok := b[key].(map[string]interface{}); ok {
+			source = src
+		}
+	}
+	if source == nil {
+		return b
+	}
+	match := map[string]interface{}{}
+	for k, v := range labels {
+		match[k] = v
+	}
+	metric := source["metric"].(map[string]interface{})
+	metric["selector"] = map[string]interface{}{"matchLabels": match}
+	return b
+}
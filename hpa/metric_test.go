@@ -0,0 +1,77 @@
+package hpa
+
+import (
+	"reflect"
+	"testing"
+
+	autoscalingV2 "k8s.io/api/autoscaling/v2"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMetricStructured(t *testing.T) {
+	avg, val := resource.MustParse("100"), resource.MustParse("10k")
+	tests := map[string]struct {
+		builder  MetricBuilder
+		expected autoscalingV2.MetricSpec
+	}{
+		"empty": {
+			builder:  MetricBuilder{},
+			expected: autoscalingV2.MetricSpec{},
+		},
+		"pods metric": {
+			builder: PodsMetric("requests-per-second", AverageValue("100")),
+			expected: autoscalingV2.MetricSpec{
+				Type: autoscalingV2.PodsMetricSourceType,
+				Pods: &autoscalingV2.PodsMetricSource{
+					Metric: autoscalingV2.MetricIdentifier{Name: "requests-per-second"},
+					Target: autoscalingV2.MetricTarget{Type: autoscalingV2.AverageValueMetricType, AverageValue: &avg},
+				},
+			},
+		},
+		"object metric": {
+			builder: ObjectMetric("networking.k8s.io/v1", "Ingress", "web", "hits-per-second", Value("10k")),
+			expected: autoscalingV2.MetricSpec{
+				Type: autoscalingV2.ObjectMetricSourceType,
+				Object: &autoscalingV2.ObjectMetricSource{
+					DescribedObject: autoscalingV2.CrossVersionObjectReference{APIVersion: "networking.k8s.io/v1", Kind: "Ingress", Name: "web"},
+					Metric:          autoscalingV2.MetricIdentifier{Name: "hits-per-second"},
+					Target:          autoscalingV2.MetricTarget{Type: autoscalingV2.ValueMetricType, Value: &val},
+				},
+			},
+		},
+		"external metric with selector": {
+			builder: ExternalMetric("queue-length", AverageValue("100")).Selector(map[string]string{"queue": "jobs"}),
+			expected: autoscalingV2.MetricSpec{
+				Type: autoscalingV2.ExternalMetricSourceType,
+				External: &autoscalingV2.ExternalMetricSource{
+					Metric: autoscalingV2.MetricIdentifier{Name: "queue-length", Selector: &metaV1.LabelSelector{MatchLabels: map[string]string{"queue": "jobs"}}},
+					Target: autoscalingV2.MetricTarget{Type: autoscalingV2.AverageValueMetricType, AverageValue: &avg},
+				},
+			},
+		},
+		"resource metric ignores selector": {
+			builder: ResourceMetric(coreV1.ResourceMemory, AverageValue("100")).Selector(map[string]string{"queue": "jobs"}),
+			expected: autoscalingV2.MetricSpec{
+				Type: autoscalingV2.ResourceMetricSourceType,
+				Resource: &autoscalingV2.ResourceMetricSource{
+					Name:   coreV1.ResourceMemory,
+					Target: autoscalingV2.MetricTarget{Type: autoscalingV2.AverageValueMetricType, AverageValue: &avg},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			metric, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(metric, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", metric, test.expected)
+			}
+		})
+	}
+}
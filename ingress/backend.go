@@ -0,0 +1,79 @@
+package ingress
+
+import (
+	"fmt"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/service"
+	networkingV1 "k8s.io/api/networking/v1"
+)
+
+// BackendBuilder provides a way to build values of type networkingV1.IngressBackend
+type BackendBuilder map[string]interface{}
+
+// ServiceBackend starts a backend that routes to the service port number
+func ServiceBackend(name string, port int32) BackendBuilder {
+	return BackendBuilder{
+		"service": map[string]interface{}{
+			"name": name,
+			"port": map[string]interface{}{"number": int64(port)},
+		},
+	}
+}
+
+// ServiceBackendPortName starts a backend that routes to the named service port
+func ServiceBackendPortName(name, portName string) BackendBuilder {
+	return BackendBuilder{
+		"service": map[string]interface{}{
+			"name": name,
+			"port": map[string]interface{}{"name": portName},
+		},
+	}
+}
+
+// ServiceBackendFor starts a backend that routes to the first port of the
+// service built by svc. The port name is used if set, otherwise its number.
+// An invalid service is recorded as an error returned by T.
+func ServiceBackendFor(svc service.Builder) BackendBuilder {
+	obj, err := svc.T()
+	if err != nil {
+		return BackendBuilder(unstruct.SetErr(map[string]interface{}{}, fmt.Errorf("ingress: backend service: %w", err)))
+	}
+	backend := map[string]interface{}{"name": obj.Name}
+	if len(obj.Spec.Ports) > 0 {
+		port := obj.Spec.Ports[0]
+		if port.Name != "" {
+			backend["port"] = map[string]interface{}{"name": port.Name}
+		} else {
+			backend["port"] = map[string]interface{}{"number": int64(port.Port)}
+		}
+	}
+	return BackendBuilder{"service": backend}
+}
+
+// ResourceBackend starts a backend that routes to an object resource
+// in the same namespace as the ingress
+func ResourceBackend(apiGroup, kind, name string) BackendBuilder {
+	return BackendBuilder{
+		"resource": map[string]interface{}{"apiGroup": apiGroup, "kind": kind, "name": name},
+	}
+}
+
+// U returns the unstructured value of the builder
+func (b BackendBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b BackendBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b BackendBuilder) T() (networkingV1.IngressBackend, error) {
+	var backend networkingV1.IngressBackend
+	if err := unstruct.FromUnstructured(b, &backend); err != nil {
+		return networkingV1.IngressBackend{}, err
+	}
+	return backend, nil
+}
@@ -0,0 +1,56 @@
+package ingress
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/objmeta"
+	"github.com/vladimirvivien/kob/service"
+	coreV1 "k8s.io/api/core/v1"
+	networkingV1 "k8s.io/api/networking/v1"
+)
+
+func TestBackendStructured(t *testing.T) {
+	apiGroup := "storage.example.com"
+	tests := map[string]struct {
+		builder  BackendBuilder
+		expected networkingV1.IngressBackend
+	}{
+		"empty": {
+			builder:  BackendBuilder{},
+			expected: networkingV1.IngressBackend{},
+		},
+		"service port number": {
+			builder:  ServiceBackend("simple-svc", 80),
+			expected: networkingV1.IngressBackend{Service: &networkingV1.IngressServiceBackend{Name: "simple-svc", Port: networkingV1.ServiceBackendPort{Number: 80}}},
+		},
+		"service port name": {
+			builder:  ServiceBackendPortName("simple-svc", "http"),
+			expected: networkingV1.IngressBackend{Service: &networkingV1.IngressServiceBackend{Name: "simple-svc", Port: networkingV1.ServiceBackendPort{Name: "http"}}},
+		},
+		"for service with port number": {
+			builder:  ServiceBackendFor(service.Object(objmeta.Name("simple-svc")).Ports(service.Port(8080), service.Port(9090))),
+			expected: networkingV1.IngressBackend{Service: &networkingV1.IngressServiceBackend{Name: "simple-svc", Port: networkingV1.ServiceBackendPort{Number: 8080}}},
+		},
+		"for service with port name": {
+			builder:  ServiceBackendFor(service.Object(objmeta.Name("simple-svc")).Ports(service.Port(8080).Name("web"))),
+			expected: networkingV1.IngressBackend{Service: &networkingV1.IngressServiceBackend{Name: "simple-svc", Port: networkingV1.ServiceBackendPort{Name: "web"}}},
+		},
+		"resource": {
+			builder:  ResourceBackend("storage.example.com", "Bucket", "static-assets"),
+			expected: networkingV1.IngressBackend{Resource: &coreV1.TypedLocalObjectReference{APIGroup: &apiGroup, Kind: "Bucket", Name: "static-assets"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			backend, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(backend, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", backend, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Package ingress contains builder types to build values of type networkingV1.Ingress
+package ingress
+
+import (
+	"fmt"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	networkingV1 "k8s.io/api/networking/v1"
+)
+
+// Builder provides a way to build values of type networkingV1.Ingress
+type Builder map[string]interface{}
+
+// Object starts a new Ingress builder using the provided object metadata
+func Object(metadata objmeta.Builder) Builder {
+	meta, err := metadata.U()
+	return Builder(unstruct.SetErr(map[string]interface{}{"metadata": meta}, err))
+}
+
+// U returns the unstructured value of the builder
+func (b Builder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b Builder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder. An error is returned if
+// a service backend is missing its port.
+func (b Builder) T() (networkingV1.Ingress, error) {
+	var ing networkingV1.Ingress
+	if err := unstruct.FromUnstructured(b, &ing); err != nil {
+		return networkingV1.Ingress{}, err
+	}
+	if err := naming.Validate("Ingress", ing.Name); err != nil {
+		return networkingV1.Ingress{}, err
+	}
+	if err := validate(ing.Spec); err != nil {
+		return networkingV1.Ingress{}, err
+	}
+	return ing, nil
+}
+
+// IngressClassName sets the name of the IngressClass used to implement the ingress
+func (b Builder) IngressClassName(name string) Builder {
+	return b.setSpec("ingressClassName", name)
+}
+
+// DefaultBackend sets the backend for requests that match no rule
+func (b Builder) DefaultBackend(backend BackendBuilder) Builder {
+	return b.setSpec("defaultBackend", unstruct.Nest(backend))
+}
+
+// Rules sets the host rules of the ingress
+func (b Builder) Rules(rules ...RuleBuilder) Builder {
+	var slice []interface{}
+	for _, r := range rules {
+		slice = append(slice, unstruct.Nest(r))
+	}
+	return b.setSpec("rules", slice)
+}
+
+// TLS sets the TLS configurations of the ingress
+func (b Builder) TLS(tls ...TLSBuilder) Builder {
+	var slice []interface{}
+	for _, t := range tls {
+		slice = append(slice, unstruct.Nest(t))
+	}
+	return b.setSpec("tls", slice)
+}
+
+func (b Builder) setSpec(key string, val interface{}) Builder {
+	spec := b.getIngressSpec()
+	spec[key] = val
+	b["spec"] = spec
+	return b
+}
+
+func (b Builder) getIngressSpec() map[string]interface{} {
+	specIface, ok := b["spec"]
+	if !ok {
+		specIface = map[string]interface{}{}
+	}
+	return specIface.(map[string]interface{})
+}
+
+func validate(spec networkingV1.IngressSpec) error {
+	backends := []*networkingV1.IngressBackend{spec.DefaultBackend}
+	for _, rule := range spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for i := range rule.HTTP.Paths {
+			backends = append(backends, &rule.HTTP.Paths[i].Backend)
+		}
+	}
+	for _, backend := range backends {
+		if backend == nil || backend.Service == nil {
+			continue
+		}
+		if backend.Service.Port.Name == "" && backend.Service.Port.Number == 0 {
+			return fmt.Errorf("ingress: backend service %s has no port", backend.Service.Name)
+		}
+	}
+	return nil
+}
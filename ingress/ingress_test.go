@@ -0,0 +1,92 @@
+package ingress
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/objmeta"
+	"github.com/vladimirvivien/kob/service"
+	networkingV1 "k8s.io/api/networking/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIngressTyped(t *testing.T) {
+	className := "nginx"
+	prefix := networkingV1.PathTypePrefix
+	tests := map[string]struct {
+		builder  Builder
+		expected networkingV1.Ingress
+	}{
+		"empty": {
+			builder:  Builder{},
+			expected: networkingV1.Ingress{},
+		},
+		"object meta only": {
+			builder:  Object(objmeta.Name("simple-ing").Namespace("default")),
+			expected: networkingV1.Ingress{ObjectMeta: metaV1.ObjectMeta{Name: "simple-ing", Namespace: "default"}},
+		},
+		"with class and default backend": {
+			builder: Object(objmeta.Name("simple-ing")).IngressClassName("nginx").DefaultBackend(ServiceBackend("simple-svc", 80)),
+			expected: networkingV1.Ingress{
+				ObjectMeta: metaV1.ObjectMeta{Name: "simple-ing"},
+				Spec: networkingV1.IngressSpec{
+					IngressClassName: &className,
+					DefaultBackend: &networkingV1.IngressBackend{
+						Service: &networkingV1.IngressServiceBackend{Name: "simple-svc", Port: networkingV1.ServiceBackendPort{Number: 80}},
+					},
+				},
+			},
+		},
+		"with route and tls": {
+			builder: Object(objmeta.Name("simple-ing")).
+				Rules(Route("example.com", "/", service.Object(objmeta.Name("simple-svc")).Ports(service.Port(80).Name("http")))).
+				TLS(TLS("example-tls", "example.com")),
+			expected: networkingV1.Ingress{
+				ObjectMeta: metaV1.ObjectMeta{Name: "simple-ing"},
+				Spec: networkingV1.IngressSpec{
+					Rules: []networkingV1.IngressRule{{
+						Host: "example.com",
+						IngressRuleValue: networkingV1.IngressRuleValue{HTTP: &networkingV1.HTTPIngressRuleValue{
+							Paths: []networkingV1.HTTPIngressPath{{
+								Path:     "/",
+								PathType: &prefix,
+								Backend: networkingV1.IngressBackend{
+									Service: &networkingV1.IngressServiceBackend{Name: "simple-svc", Port: networkingV1.ServiceBackendPort{Name: "http"}},
+								},
+							}},
+						}},
+					}},
+					TLS: []networkingV1.IngressTLS{{SecretName: "example-tls", Hosts: []string{"example.com"}}},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ing, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(ing, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", ing, test.expected)
+			}
+		})
+	}
+}
+
+func TestIngressInvalid(t *testing.T) {
+	tests := map[string]Builder{
+		"route to service without port": Object(objmeta.Name("simple-ing")).Rules(Route("example.com", "/", service.Object(objmeta.Name("simple-svc")))),
+		"default backend without port":  Object(objmeta.Name("simple-ing")).DefaultBackend(ServiceBackendFor(service.Object(objmeta.Name("simple-svc")))),
+		"invalid backend service":       Object(objmeta.Name("simple-ing")).DefaultBackend(ServiceBackendFor(service.Object(objmeta.Name("Simple_Svc")).Ports(service.Port(80)))),
+	}
+
+	for name, builder := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := builder.T(); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
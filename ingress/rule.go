@@ -0,0 +1,60 @@
+package ingress
+
+import (
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/service"
+	networkingV1 "k8s.io/api/networking/v1"
+)
+
+// RuleBuilder provides a way to build values of type networkingV1.IngressRule
+type RuleBuilder map[string]interface{}
+
+// Rule starts a new rule for the specified host (an empty host matches all hosts)
+func Rule(host string) RuleBuilder {
+	if host == "" {
+		return RuleBuilder{}
+	}
+	return RuleBuilder{"host": host}
+}
+
+// Route creates a rule that sends requests with the host and path prefix
+// to the service built by svc. The backend port is read from the service
+// builder so that both objects stay in sync.
+func Route(host, path string, svc service.Builder) RuleBuilder {
+	return Rule(host).Path(path, networkingV1.PathTypePrefix, ServiceBackendFor(svc))
+}
+
+// U returns the unstructured value of the builder
+func (b RuleBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b RuleBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b RuleBuilder) T() (networkingV1.IngressRule, error) {
+	var rule networkingV1.IngressRule
+	if err := unstruct.FromUnstructured(b, &rule); err != nil {
+		return networkingV1.IngressRule{}, err
+	}
+	return rule, nil
+}
+
+// Path adds an HTTP path, matched according to pathType, that routes to backend
+func (b RuleBuilder) Path(path string, pathType networkingV1.PathType, backend BackendBuilder) RuleBuilder {
+	http, ok := b["http"].(map[string]interface{})
+	if !ok {
+		http = map[string]interface{}{}
+	}
+	paths, _ := http["paths"].([]interface{})
+	http["paths"] = append(paths, map[string]interface{}{
+		"path":     path,
+		"pathType": pathType,
+		"backend":  unstruct.Nest(backend),
+	})
+	b["http"] = http
+	return b
+}
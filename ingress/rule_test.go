@@ -0,0 +1,49 @@
+package ingress
+
+import (
+	"reflect"
+	"testing"
+
+	networkingV1 "k8s.io/api/networking/v1"
+)
+
+func TestRuleStructured(t *testing.T) {
+	exact := networkingV1.PathTypeExact
+	prefix := networkingV1.PathTypePrefix
+	tests := map[string]struct {
+		builder  RuleBuilder
+		expected networkingV1.IngressRule
+	}{
+		"empty": {
+			builder:  RuleBuilder{},
+			expected: networkingV1.IngressRule{},
+		},
+		"host only": {
+			builder:  Rule("example.com"),
+			expected: networkingV1.IngressRule{Host: "example.com"},
+		},
+		"multiple paths": {
+			builder: Rule("").Path("/api", networkingV1.PathTypeExact, ServiceBackend("api", 80)).Path("/", networkingV1.PathTypePrefix, ServiceBackend("web", 8080)),
+			expected: networkingV1.IngressRule{
+				IngressRuleValue: networkingV1.IngressRuleValue{HTTP: &networkingV1.HTTPIngressRuleValue{
+					Paths: []networkingV1.HTTPIngressPath{
+						{Path: "/api", PathType: &exact, Backend: networkingV1.IngressBackend{Service: &networkingV1.IngressServiceBackend{Name: "api", Port: networkingV1.ServiceBackendPort{Number: 80}}}},
+						{Path: "/", PathType: &prefix, Backend: networkingV1.IngressBackend{Service: &networkingV1.IngressServiceBackend{Name: "web", Port: networkingV1.ServiceBackendPort{Number: 8080}}}},
+					},
+				}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rule, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(rule, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", rule, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package ingress
+
+import (
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	networkingV1 "k8s.io/api/networking/v1"
+)
+
+// TLSBuilder provides a way to build values of type networkingV1.IngressTLS
+type TLSBuilder map[string]interface{}
+
+// TLS starts a new TLS configuration using the certificate in the named
+// secret for the specified hosts
+func TLS(secretName string, hosts ...string) TLSBuilder {
+	b := TLSBuilder{"secretName": secretName}
+	if len(hosts) > 0 {
+		var slice []interface{}
+		for _, h := range hosts {
+			slice = append(slice, h)
+		}
+		b["hosts"] = slice
+	}
+	return b
+}
+
+// U returns the unstructured value of the builder
+func (b TLSBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b TLSBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b TLSBuilder) T() (networkingV1.IngressTLS, error) {
+	var tls networkingV1.IngressTLS
+	if err := unstruct.FromUnstructured(b, &tls); err != nil {
+		return networkingV1.IngressTLS{}, err
+	}
+	return tls, nil
+}
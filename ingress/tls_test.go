@@ -0,0 +1,40 @@
+package ingress
+
+import (
+	"reflect"
+	"testing"
+
+	networkingV1 "k8s.io/api/networking/v1"
+)
+
+func TestTLSStructured(t *testing.T) {
+	tests := map[string]struct {
+		builder  TLSBuilder
+		expected networkingV1.IngressTLS
+	}{
+		"empty": {
+			builder:  TLSBuilder{},
+			expected: networkingV1.IngressTLS{},
+		},
+		"secret only": {
+			builder:  TLS("example-tls"),
+			expected: networkingV1.IngressTLS{SecretName: "example-tls"},
+		},
+		"secret and hosts": {
+			builder:  TLS("example-tls", "example.com", "www.example.com"),
+			expected: networkingV1.IngressTLS{SecretName: "example-tls", Hosts: []string{"example.com", "www.example.com"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tls, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(tls, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", tls, test.expected)
+			}
+		})
+	}
+}
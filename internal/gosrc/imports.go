@@ -0,0 +1,118 @@
+// Package gosrc contains helpers shared by the kob code generators to write Go source
+package gosrc
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var versionElem = regexp.MustCompile(`^v[0-9]+((alpha|beta)[0-9]+)?$`)
+
+// Imports records the packages used by generated code and their names
+type Imports struct {
+	names map[string]string // import path -> package name
+	used  map[string]string // package name -> import path
+}
+
+// NewImports returns Imports where the names are reserved, i.e. for the
+// identifiers declared by the generated code
+func NewImports(reserved ...string) *Imports {
+	i := &Imports{names: map[string]string{}, used: map[string]string{}}
+	for _, name := range reserved {
+		i.used[name] = ""
+	}
+	return i
+}
+
+// Copy returns a copy of the imports
+func (i *Imports) Copy() *Imports {
+	cp := NewImports()
+	for path, name := range i.names {
+		cp.names[path] = name
+	}
+	for name, path := range i.used {
+		cp.used[name] = path
+	}
+	return cp
+}
+
+// Name returns the name the package at path is imported as. Versioned API
+// packages are named after their group and version (i.e. k8s.io/api/core/v1
+// is coreV1), as throughout kob.
+func (i *Imports) Name(path string) string {
+	if name, ok := i.names[path]; ok {
+		return name
+	}
+	elems := strings.Split(path, "/")
+	name := elems[len(elems)-1]
+	if versionElem.MatchString(name) && len(elems) > 1 {
+		name = elems[len(elems)-2] + "V" + name[1:]
+	}
+	name = strings.NewReplacer("-", "", ".", "").Replace(name)
+	for n, unique := 2, name; ; n++ {
+		if _, taken := i.used[unique]; !taken {
+			name = unique
+			break
+		}
+		unique = fmt.Sprintf("%s%d", name, n)
+	}
+	i.names[path] = name
+	i.used[name] = path
+	return name
+}
+
+// Specs returns the import specs, sorted by path
+func (i *Imports) Specs() []string {
+	var paths []string
+	for path := range i.names {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	var specs []string
+	for _, path := range paths {
+		name := i.names[path]
+		if elems := strings.Split(path, "/"); elems[len(elems)-1] == name {
+			specs = append(specs, strconv.Quote(path))
+			continue
+		}
+		specs = append(specs, name+" "+strconv.Quote(path))
+	}
+	return specs
+}
+
+// Decl returns the import declaration of the specs, empty without imports
+func (i *Imports) Decl() string {
+	specs := i.Specs()
+	if len(specs) == 0 {
+		return ""
+	}
+	return "import (\n" + strings.Join(specs, "\n") + "\n)\n"
+}
+
+// TypeName returns the Go source of type t, qualified by the names of the
+// imported packages
+func (i *Imports) TypeName(t reflect.Type) string {
+	if t.Name() != "" {
+		if t.PkgPath() == "" {
+			return t.Name()
+		}
+		return i.Name(t.PkgPath()) + "." + t.Name()
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return "*" + i.TypeName(t.Elem())
+	case reflect.Slice:
+		return "[]" + i.TypeName(t.Elem())
+	case reflect.Map:
+		return "map[" + i.TypeName(t.Key()) + "]" + i.TypeName(t.Elem())
+	case reflect.Interface:
+		if t.NumMethod() == 0 {
+			return "any"
+		}
+	}
+	return t.String()
+}
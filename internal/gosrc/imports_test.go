@@ -0,0 +1,63 @@
+package gosrc
+
+import (
+	"reflect"
+	"testing"
+
+	coreV1 "k8s.io/api/core/v1"
+)
+
+func TestImportsName(t *testing.T) {
+	i := NewImports("ptr")
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{path: "k8s.io/api/core/v1", expected: "coreV1"},
+		{path: "k8s.io/api/admissionregistration/v1beta1", expected: "admissionregistrationV1beta1"},
+		{path: "k8s.io/apimachinery/pkg/api/resource", expected: "resource"},
+		{path: "github.com/vladimirvivien/kob/unstructured", expected: "unstructured"},
+		{path: "example.com/other/unstructured", expected: "unstructured2"},
+		{path: "example.com/ptr", expected: "ptr2"},
+		{path: "k8s.io/api/core/v1", expected: "coreV1"},
+	}
+	for _, test := range tests {
+		if name := i.Name(test.path); name != test.expected {
+			t.Errorf("import name of %s: expected %s, got %s", test.path, test.expected, name)
+		}
+	}
+
+	expected := `import (
+unstructured2 "example.com/other/unstructured"
+ptr2 "example.com/ptr"
+"github.com/vladimirvivien/kob/unstructured"
+admissionregistrationV1beta1 "k8s.io/api/admissionregistration/v1beta1"
+coreV1 "k8s.io/api/core/v1"
+"k8s.io/apimachinery/pkg/api/resource"
+)
+`
+	if decl := i.Decl(); decl != expected {
+		t.Errorf("import declaration not equal \n\n Expected: %s \n\n Got: %s", expected, decl)
+	}
+}
+
+func TestImportsTypeName(t *testing.T) {
+	tests := map[string]struct {
+		typ      reflect.Type
+		expected string
+	}{
+		"named":     {typ: reflect.TypeOf(coreV1.Container{}), expected: "coreV1.Container"},
+		"builtin":   {typ: reflect.TypeOf(int32(0)), expected: "int32"},
+		"pointer":   {typ: reflect.TypeOf(&coreV1.Probe{}), expected: "*coreV1.Probe"},
+		"slice":     {typ: reflect.TypeOf([]coreV1.EnvVar{}), expected: "[]coreV1.EnvVar"},
+		"map":       {typ: reflect.TypeOf(map[string]any{}), expected: "map[string]any"},
+		"named map": {typ: reflect.TypeOf(coreV1.ResourceList{}), expected: "coreV1.ResourceList"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := NewImports().TypeName(test.typ); got != test.expected {
+				t.Errorf("type name: expected %s, got %s", test.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Package scheme contains the runtime.Scheme of the API types built by kob,
+// used to look up the apiVersion and kind of typed objects
+package scheme
+
+import (
+	"fmt"
+	"reflect"
+
+	admissionregV1 "k8s.io/api/admissionregistration/v1"
+	admissionregV1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	appsV1 "k8s.io/api/apps/v1"
+	autoscalingV2 "k8s.io/api/autoscaling/v2"
+	batchV1 "k8s.io/api/batch/v1"
+	coordinationV1 "k8s.io/api/coordination/v1"
+	coreV1 "k8s.io/api/core/v1"
+	discoveryV1 "k8s.io/api/discovery/v1"
+	networkingV1 "k8s.io/api/networking/v1"
+	nodeV1 "k8s.io/api/node/v1"
+	policyV1 "k8s.io/api/policy/v1"
+	rbacV1 "k8s.io/api/rbac/v1"
+	schedulingV1 "k8s.io/api/scheduling/v1"
+	storageV1 "k8s.io/api/storage/v1"
+	apiextV1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Scheme knows the API types built by kob
+var Scheme = runtime.NewScheme()
+
+func init() {
+	for _, add := range []func(*runtime.Scheme) error{
+		admissionregV1.AddToScheme,
+		admissionregV1beta1.AddToScheme,
+		appsV1.AddToScheme,
+		autoscalingV2.AddToScheme,
+		batchV1.AddToScheme,
+		coordinationV1.AddToScheme,
+		coreV1.AddToScheme,
+		discoveryV1.AddToScheme,
+		networkingV1.AddToScheme,
+		nodeV1.AddToScheme,
+		policyV1.AddToScheme,
+		rbacV1.AddToScheme,
+		schedulingV1.AddToScheme,
+		storageV1.AddToScheme,
+		apiextV1.AddToScheme,
+	} {
+		if err := add(Scheme); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// GVK returns the group, version and kind of obj, using its type meta when set
+// (as with unstructured objects) and the scheme otherwise
+func GVK(obj runtime.Object) (schema.GroupVersionKind, error) {
+	if gvk := obj.GetObjectKind().GroupVersionKind(); gvk.Version != "" && gvk.Kind != "" {
+		return gvk, nil
+	}
+	gvks, _, err := Scheme.ObjectKinds(obj)
+	if err != nil {
+		return schema.GroupVersionKind{}, err
+	}
+	if len(gvks) == 0 {
+		return schema.GroupVersionKind{}, fmt.Errorf("scheme: no kind registered for %T", obj)
+	}
+	return gvks[0], nil
+}
+
+// Object is implemented by pointers to the typed API objects
+type Object interface {
+	runtime.Object
+	metaV1.Object
+}
+
+// ObjectOf returns the typed object of v, which is either a kob builder
+// (any value with a T method) or a typed API object, passed by value or pointer
+func ObjectOf(v any) (Object, error) {
+	if obj, ok := v.(Object); ok {
+		return obj, nil
+	}
+	val := reflect.ValueOf(v)
+	if !val.IsValid() {
+		return nil, fmt.Errorf("scheme: nil object")
+	}
+	if method := val.MethodByName("T"); method.IsValid() && method.Type().NumIn() == 0 {
+		out := method.Call(nil)
+		if len(out) == 0 {
+			return nil, fmt.Errorf("scheme: %T: T returns no value", v)
+		}
+		if len(out) == 2 {
+			if err, ok := out[1].Interface().(error); ok && err != nil {
+				return nil, err
+			}
+		}
+		val = out[0]
+	}
+	// typed objects are passed by value, take their address
+	if val.Kind() != reflect.Pointer {
+		ptr := reflect.New(val.Type())
+		ptr.Elem().Set(val)
+		val = ptr
+	}
+	obj, ok := val.Interface().(Object)
+	if !ok {
+		return nil, fmt.Errorf("scheme: %T is not an API object", v)
+	}
+	return obj, nil
+}
@@ -0,0 +1,41 @@
+package scheme
+
+import (
+	"testing"
+
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestGVK(t *testing.T) {
+	custom := &unstructured.Unstructured{}
+	custom.SetAPIVersion("example.com/v1")
+	custom.SetKind("Widget")
+
+	tests := map[string]struct {
+		obj      runtime.Object
+		expected schema.GroupVersionKind
+	}{
+		"core":         {obj: &coreV1.Pod{}, expected: schema.GroupVersionKind{Version: "v1", Kind: "Pod"}},
+		"apps":         {obj: &appsV1.Deployment{}, expected: schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}},
+		"unstructured": {obj: custom, expected: schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gvk, err := GVK(test.obj)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if gvk != test.expected {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", gvk, test.expected)
+			}
+		})
+	}
+
+	if _, err := GVK(&unstructured.Unstructured{}); err == nil {
+		t.Error("expecting error, got none")
+	}
+}
@@ -0,0 +1,29 @@
+package unstruct
+
+// DeepCopy returns a copy of the unstructured value where nested maps and
+// slices are copied, so that builders sharing values do not alias each other.
+// Other values, including typed values stored by builders, are copied as is.
+func DeepCopy(unstruct map[string]any) map[string]any {
+	if unstruct == nil {
+		return nil
+	}
+	cp := make(map[string]any, len(unstruct))
+	for key, val := range unstruct {
+		cp[key] = copyValue(val)
+	}
+	return cp
+}
+
+func copyValue(val any) any {
+	switch v := val.(type) {
+	case map[string]any:
+		return DeepCopy(v)
+	case []any:
+		cp := make([]any, len(v))
+		for i, item := range v {
+			cp[i] = copyValue(item)
+		}
+		return cp
+	}
+	return val
+}
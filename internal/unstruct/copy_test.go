@@ -0,0 +1,28 @@
+package unstruct
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDeepCopy(t *testing.T) {
+	orig := map[string]any{
+		"name":   "web",
+		"labels": map[string]any{"app": "web"},
+		"ports":  []any{map[string]any{"port": int64(80)}},
+	}
+	cp := DeepCopy(orig)
+	if !reflect.DeepEqual(cp, orig) {
+		t.Fatalf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", cp, orig)
+	}
+
+	cp["labels"].(map[string]any)["app"] = "api"
+	cp["ports"].([]any)[0].(map[string]any)["port"] = int64(8080)
+	if orig["labels"].(map[string]any)["app"] != "web" || orig["ports"].([]any)[0].(map[string]any)["port"] != int64(80) {
+		t.Errorf("copy aliases the original value: %#v", orig)
+	}
+
+	if DeepCopy(nil) != nil {
+		t.Error("expecting nil copy of nil value")
+	}
+}
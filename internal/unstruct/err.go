@@ -0,0 +1,92 @@
+package unstruct
+
+import "k8s.io/apimachinery/pkg/runtime"
+
+// errKey is the key under which map builders record the first error found
+// while building, it can't collide with the name of an API field. It never
+// leaves kob: the U method of map builders strips it (see Strip).
+const errKey = "\x00err"
+
+// SetErr records err on the unstructured value of a map builder, unless an
+// error is already recorded or err is nil, and returns the value
+func SetErr(unstruct map[string]any, err error) map[string]any {
+	if err == nil || unstruct == nil {
+		return unstruct
+	}
+	if _, found := unstruct[errKey]; !found {
+		unstruct[errKey] = err
+	}
+	return unstruct
+}
+
+// Err returns the error recorded on the unstructured value or, as the values
+// of builders are nested in each other, on any of its nested maps
+func Err(unstruct map[string]any) error {
+	if err, ok := unstruct[errKey].(error); ok {
+		return err
+	}
+	for _, val := range unstruct {
+		if err := errOf(val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func errOf(val any) error {
+	switch v := val.(type) {
+	case map[string]any:
+		return Err(v)
+	case []any:
+		for _, item := range v {
+			if err := errOf(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Strip returns the unstructured value without the errors recorded on it or on
+// its nested maps, as returned by the U method of map builders so that the
+// value is a plain unstructured object. The value itself is returned when no
+// error is recorded, otherwise a copy.
+func Strip(unstruct map[string]any) map[string]any {
+	if Err(unstruct) == nil {
+		return unstruct
+	}
+	cp := DeepCopy(unstruct)
+	strip(cp)
+	return cp
+}
+
+func strip(val any) {
+	switch v := val.(type) {
+	case map[string]any:
+		delete(v, errKey)
+		for _, item := range v {
+			strip(item)
+		}
+	case []any:
+		for _, item := range v {
+			strip(item)
+		}
+	}
+}
+
+// Nest returns the value of the map builder, with the errors recorded on it,
+// to nest in the value of another builder. Unlike the U method of the nested
+// builder, it keeps the errors so that the T method of the other builder
+// returns them.
+func Nest[M ~map[string]any](builder M) map[string]any {
+	return builder
+}
+
+// FromUnstructured returns the error recorded on the unstructured value, if
+// any, or converts it into obj, which should be a pointer to a typed API value
+func FromUnstructured(unstruct map[string]any, obj any) error {
+	if err := Err(unstruct); err != nil {
+		return err
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(unstruct, obj)
+}
@@ -0,0 +1,79 @@
+package unstruct
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	coreV1 "k8s.io/api/core/v1"
+)
+
+func TestErr(t *testing.T) {
+	invalid := errors.New("invalid")
+	tests := map[string]struct {
+		unstruct map[string]any
+		expected error
+	}{
+		"none": {
+			unstruct: map[string]any{"metadata": map[string]any{"name": "web"}},
+		},
+		"recorded": {
+			unstruct: SetErr(map[string]any{}, invalid),
+			expected: invalid,
+		},
+		"first recorded": {
+			unstruct: SetErr(SetErr(map[string]any{}, invalid), errors.New("other")),
+			expected: invalid,
+		},
+		"nested map": {
+			unstruct: map[string]any{"spec": SetErr(map[string]any{}, invalid)},
+			expected: invalid,
+		},
+		"nested slice": {
+			unstruct: map[string]any{"containers": []any{SetErr(map[string]any{}, invalid)}},
+			expected: invalid,
+		},
+		"copied": {
+			unstruct: DeepCopy(map[string]any{"spec": SetErr(map[string]any{}, invalid)}),
+			expected: invalid,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := Err(test.unstruct); err != test.expected {
+				t.Errorf("error not equal \n\n Got: %v \n\n Expected: %v", err, test.expected)
+			}
+		})
+	}
+}
+
+func TestFromUnstructured(t *testing.T) {
+	var cm coreV1.ConfigMap
+	if err := FromUnstructured(map[string]any{"data": map[string]any{"a": "1"}}, &cm); err != nil {
+		t.Fatal(err)
+	}
+	if cm.Data["a"] != "1" {
+		t.Errorf("object not converted: %#v", cm)
+	}
+	if err := FromUnstructured(SetErr(map[string]any{}, errors.New("invalid")), &cm); err == nil {
+		t.Error("expecting error, got none")
+	}
+}
+
+func TestStrip(t *testing.T) {
+	clean := map[string]any{"metadata": map[string]any{"name": "web"}}
+	if u := Strip(clean); !reflect.DeepEqual(u, clean) {
+		t.Errorf("object not equal \n\n Got: %#v \n\n Expected: %#v", u, clean)
+	}
+
+	u := map[string]any{
+		"spec": SetErr(map[string]any{"containers": []any{SetErr(map[string]any{"name": "web"}, errors.New("invalid"))}}, errors.New("invalid")),
+	}
+	expected := map[string]any{"spec": map[string]any{"containers": []any{map[string]any{"name": "web"}}}}
+	if stripped := Strip(u); !reflect.DeepEqual(stripped, expected) {
+		t.Errorf("object not equal \n\n Got: %#v \n\n Expected: %#v", stripped, expected)
+	}
+	if Err(u) == nil {
+		t.Error("expecting the error to be kept on the builder value, got none")
+	}
+}
@@ -0,0 +1,69 @@
+package unstruct
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// JSONValue converts val into a value made of the JSON-compatible types
+// used by unstructured objects (map[string]interface{}, []interface{},
+// string, int64, float64, bool and nil). The error recorded on the value of
+// a map builder is returned.
+func JSONValue(val any) (interface{}, error) {
+	switch v := val.(type) {
+	case interface {
+		U() map[string]interface{}
+		Err() error
+	}:
+		if err := v.Err(); err != nil {
+			return nil, err
+		}
+		val = v.U()
+	case interface{ U() map[string]interface{} }:
+		u := v.U()
+		if err := Err(u); err != nil {
+			return nil, err
+		}
+		val = u
+	case interface {
+		U() (map[string]any, error)
+	}:
+		u, err := v.U()
+		if err != nil {
+			return nil, err
+		}
+		val = u
+	}
+
+	data, err := json.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	dec := json.NewDecoder(strings.NewReader(string(data)))
+	dec.UseNumber()
+	if err := dec.Decode(&out); err != nil {
+		return nil, err
+	}
+	return fromNumbers(out), nil
+}
+
+func fromNumbers(val interface{}) interface{} {
+	switch v := val.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = fromNumbers(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = fromNumbers(item)
+		}
+	}
+	return val
+}
@@ -0,0 +1,60 @@
+package unstruct
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+type builder map[string]interface{}
+
+func (b builder) U() map[string]interface{} {
+	return b
+}
+
+func TestJSONValue(t *testing.T) {
+	tests := map[string]struct {
+		val      any
+		expected interface{}
+	}{
+		"integer": {val: int32(3), expected: int64(3)},
+		"float":   {val: 1.5, expected: 1.5},
+		"typed string": {
+			val:      coreV1.DNSClusterFirst,
+			expected: "ClusterFirst",
+		},
+		"struct": {
+			val:      coreV1.Toleration{Key: "gpu", Operator: coreV1.TolerationOpExists},
+			expected: map[string]interface{}{"key": "gpu", "operator": "Exists"},
+		},
+		"quantities": {
+			val:      coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("250m")},
+			expected: map[string]interface{}{"cpu": "250m"},
+		},
+		"builder": {
+			val:      builder{"replicas": int64(2)},
+			expected: map[string]interface{}{"replicas": int64(2)},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			val, err := JSONValue(test.val)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(val, test.expected) {
+				t.Errorf("value not equal \n\n Got: %#v \n\n Expected: %#v", val, test.expected)
+			}
+		})
+	}
+}
+
+func TestJSONValueRecordedError(t *testing.T) {
+	b := builder(SetErr(map[string]interface{}{"replicas": int64(2)}, errors.New("invalid")))
+	if _, err := JSONValue(b); err == nil {
+		t.Error("expecting error, got none")
+	}
+}
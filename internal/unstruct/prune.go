@@ -0,0 +1,130 @@
+// Package unstruct contains helpers shared by builders to manipulate
+// unstructured map[string]any values
+package unstruct
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Prune removes nil values and empty maps (recursively) from the unstructured value.
+// It is used to drop zero-valued fields, such as metadata.creationTimestamp, that are
+// rendered by the default unstructured converter for non-pointer struct fields.
+func Prune(unstruct map[string]any) map[string]any {
+	for key, val := range unstruct {
+		if pruneValue(val) {
+			delete(unstruct, key)
+		}
+	}
+	return unstruct
+}
+
+// pruneValue prunes val in place and reports whether val itself is empty
+func pruneValue(val any) bool {
+	switch v := val.(type) {
+	case nil:
+		return true
+	case map[string]any:
+		return len(Prune(v)) == 0
+	case []any:
+		for _, item := range v {
+			if m, ok := item.(map[string]any); ok {
+				Prune(m)
+			}
+		}
+	}
+	return false
+}
+
+// PruneEmpty removes from the unstructured value of obj, a typed API value, the
+// null values and the empty fields rendered by the default unstructured
+// converter, which are the zero struct fields tagged omitempty (i.e.
+// resources: {} or targetPort: 0), recursively. Unlike Prune, empty values
+// that are meaningful are kept: zero fields required by the API (i.e. the
+// podSelector of a network policy) and pointers to empty values (i.e. emptyDir: {}).
+func PruneEmpty(unstruct map[string]any, obj any) map[string]any {
+	pruneStruct(unstruct, reflect.ValueOf(obj))
+	return unstruct
+}
+
+// pruneStruct prunes the fields of u, the unstructured value of the struct v
+func pruneStruct(u map[string]any, v reflect.Value) {
+	v = indirect(v)
+	if !v.IsValid() || v.Kind() != reflect.Struct {
+		return
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" && f.Anonymous {
+			// embedded fields (i.e. TypeMeta) are inlined
+			pruneStruct(u, v.Field(i))
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		val, found := u[name]
+		if !found {
+			continue
+		}
+		fv := v.Field(i)
+		omitEmpty := strings.Contains(opts, "omitempty")
+		if val == nil || (omitEmpty && fv.Kind() == reflect.Struct && fv.IsZero()) {
+			delete(u, name)
+			continue
+		}
+		pruneTyped(val, fv)
+		if m, ok := val.(map[string]any); ok && omitEmpty && fv.Kind() == reflect.Struct && len(m) == 0 {
+			delete(u, name)
+		}
+	}
+}
+
+// pruneTyped prunes val, the unstructured value of v
+func pruneTyped(val any, v reflect.Value) {
+	v = indirect(v)
+	if !v.IsValid() {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		if m, ok := val.(map[string]any); ok {
+			pruneStruct(m, v)
+		}
+	case reflect.Slice, reflect.Array:
+		if s, ok := val.([]any); ok && len(s) == v.Len() {
+			for i, item := range s {
+				pruneTyped(item, v.Index(i))
+			}
+		}
+	case reflect.Map:
+		m, ok := val.(map[string]any)
+		if !ok || v.Type().Key().Kind() != reflect.String {
+			return
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			if item, found := m[iter.Key().String()]; found {
+				pruneTyped(item, iter.Value())
+			}
+		}
+	}
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
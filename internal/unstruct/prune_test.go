@@ -0,0 +1,108 @@
+package unstruct
+
+import (
+	"reflect"
+	"testing"
+
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	networkingV1 "k8s.io/api/networking/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestPrune(t *testing.T) {
+	tests := map[string]struct {
+		unstruct map[string]any
+		expected map[string]any
+	}{
+		"empty": {
+			unstruct: map[string]any{},
+			expected: map[string]any{},
+		},
+		"nil values": {
+			unstruct: map[string]any{"name": "simple-name", "creationTimestamp": nil},
+			expected: map[string]any{"name": "simple-name"},
+		},
+		"empty nested maps": {
+			unstruct: map[string]any{"name": "simple-name", "resources": map[string]any{"limits": map[string]any{}}},
+			expected: map[string]any{"name": "simple-name"},
+		},
+		"maps in slices": {
+			unstruct: map[string]any{"containers": []any{map[string]any{"name": "simple-name", "resources": map[string]any{}}}},
+			expected: map[string]any{"containers": []any{map[string]any{"name": "simple-name"}}},
+		},
+		"zero scalars kept": {
+			unstruct: map[string]any{"containerPort": int64(0), "stdin": false},
+			expected: map[string]any{"containerPort": int64(0), "stdin": false},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pruned := Prune(test.unstruct)
+			if !reflect.DeepEqual(pruned, test.expected) {
+				t.Errorf("object not equal \n\n Pruned: %#v \n\n Expected: %#v", pruned, test.expected)
+			}
+		})
+	}
+}
+
+func TestPruneEmpty(t *testing.T) {
+	zero := int32(0)
+	tests := map[string]struct {
+		obj      any
+		expected map[string]any
+	}{
+		"empty structs": {
+			obj: &appsV1.Deployment{ObjectMeta: metaV1.ObjectMeta{Name: "web"}, Spec: appsV1.DeploymentSpec{
+				Template: coreV1.PodTemplateSpec{Spec: coreV1.PodSpec{Containers: []coreV1.Container{{Name: "web"}}}},
+			}},
+			expected: map[string]any{
+				"metadata": map[string]any{"name": "web"},
+				"spec": map[string]any{"template": map[string]any{"spec": map[string]any{
+					"containers": []any{map[string]any{"name": "web"}},
+				}}},
+			},
+		},
+		"zero int or string": {
+			obj: &coreV1.Service{Spec: coreV1.ServiceSpec{Ports: []coreV1.ServicePort{{Port: 80}}}},
+			expected: map[string]any{
+				"spec": map[string]any{"ports": []any{map[string]any{"port": int64(80)}}},
+			},
+		},
+		"meaningful empty values kept": {
+			obj: &networkingV1.NetworkPolicy{Spec: networkingV1.NetworkPolicySpec{
+				Ingress: []networkingV1.NetworkPolicyIngressRule{{From: []networkingV1.NetworkPolicyPeer{{NamespaceSelector: &metaV1.LabelSelector{}}}}},
+			}},
+			expected: map[string]any{
+				"spec": map[string]any{
+					"podSelector": map[string]any{},
+					"ingress":     []any{map[string]any{"from": []any{map[string]any{"namespaceSelector": map[string]any{}}}}},
+				},
+			},
+		},
+		"zero pointers kept": {
+			obj: &appsV1.Deployment{Spec: appsV1.DeploymentSpec{Replicas: &zero, Template: coreV1.PodTemplateSpec{Spec: coreV1.PodSpec{
+				Volumes: []coreV1.Volume{{Name: "cache", VolumeSource: coreV1.VolumeSource{EmptyDir: &coreV1.EmptyDirVolumeSource{}}}},
+			}}}},
+			expected: map[string]any{
+				"spec": map[string]any{"replicas": int64(0), "template": map[string]any{"spec": map[string]any{
+					"volumes": []any{map[string]any{"name": "cache", "emptyDir": map[string]any{}}},
+				}}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(test.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if pruned := PruneEmpty(u, test.obj); !reflect.DeepEqual(pruned, test.expected) {
+				t.Errorf("object not equal \n\n Pruned: %#v \n\n Expected: %#v", pruned, test.expected)
+			}
+		})
+	}
+}
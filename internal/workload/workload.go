@@ -0,0 +1,175 @@
+// Package workload contains the pod template and selector helpers shared by
+// the builders of workload objects (deployments and replica sets)
+package workload
+
+import (
+	"fmt"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/objmeta"
+)
+
+// SetTemplate sets the pod template (spec.template) of obj using copies of
+// the pod metadata and spec
+func SetTemplate(obj, meta, podSpec map[string]interface{}) {
+	template := map[string]interface{}{}
+	if len(meta) > 0 {
+		template["metadata"] = unstruct.DeepCopy(meta)
+	}
+	if podSpec != nil {
+		template["spec"] = unstruct.DeepCopy(podSpec)
+	}
+	spec := getSpec(obj)
+	spec["template"] = template
+	obj["spec"] = spec
+}
+
+// SetSelector sets the label selector (spec.selector) of obj to match the labels
+func SetSelector(obj map[string]interface{}, labels map[string]string) {
+	spec := getSpec(obj)
+	spec["selector"] = map[string]interface{}{"matchLabels": unstructuredLabels(labels)}
+	obj["spec"] = spec
+}
+
+// SetLabelSelector sets the label selector (spec.selector) of obj to a copy of sel
+func SetLabelSelector(obj, sel map[string]interface{}) {
+	spec := getSpec(obj)
+	spec["selector"] = unstruct.DeepCopy(sel)
+	obj["spec"] = spec
+}
+
+// SetTemplateFromPod sets the pod template of obj from pod, keeping only the
+// labels and annotations of its metadata. When obj has no selector, the pod's
+// labels are selected and a pod without labels is recorded as an error of obj,
+// as the API server rejects an empty selector. A selector that is set is never
+// changed (see InheritLabels). The errors recorded on pod are recorded on obj.
+func SetTemplateFromPod(obj, pod map[string]interface{}) {
+	unstruct.SetErr(obj, unstruct.Err(pod))
+	podMeta, _ := pod["metadata"].(map[string]interface{})
+	meta := map[string]interface{}{}
+	for _, key := range []string{"labels", "annotations"} {
+		if val, ok := podMeta[key]; ok {
+			meta[key] = val
+		}
+	}
+	podSpec, _ := pod["spec"].(map[string]interface{})
+	SetTemplate(obj, meta, podSpec)
+	if _, found := getSpec(obj)["selector"]; found {
+		return
+	}
+	labels := objmeta.SelectorLabels(Labels(podMeta))
+	if len(labels) == 0 {
+		unstruct.SetErr(obj, fmt.Errorf("workload: pod %q has no labels to select", Name(pod)))
+		return
+	}
+	SetSelector(obj, labels)
+}
+
+// InheritLabels copies the labels of obj with the keys into its pod template,
+// without replacing labels already set on the template. When obj has no
+// selector, its pods are selected by the inherited labels; a selector that is
+// set is never changed, as it is immutable once the workload is created.
+func InheritLabels(obj map[string]interface{}, keys []string) {
+	meta, _ := obj["metadata"].(map[string]interface{})
+	labels := Labels(meta)
+
+	spec := getSpec(obj)
+	template, ok := spec["template"].(map[string]interface{})
+	if !ok {
+		template = map[string]interface{}{}
+	}
+	templateMeta, ok := template["metadata"].(map[string]interface{})
+	if !ok {
+		templateMeta = map[string]interface{}{}
+	}
+	templateLabels := Labels(templateMeta)
+	inherited := map[string]string{}
+	for _, key := range keys {
+		val, found := templateLabels[key]
+		if !found {
+			val, found = labels[key]
+		}
+		if found {
+			templateLabels[key] = val
+			inherited[key] = val
+		}
+	}
+	if len(inherited) == 0 {
+		return
+	}
+	templateMeta["labels"] = unstructuredLabels(templateLabels)
+	template["metadata"] = templateMeta
+	spec["template"] = template
+	if _, found := spec["selector"]; !found {
+		spec["selector"] = map[string]interface{}{"matchLabels": unstructuredLabels(inherited)}
+	}
+	obj["spec"] = spec
+}
+
+// Pod returns a standalone pod, with the name, built from the pod template of
+// obj and placed in obj's namespace
+func Pod(obj map[string]interface{}, name string) map[string]interface{} {
+	template, _ := getSpec(obj)["template"].(map[string]interface{})
+	template = unstruct.DeepCopy(template)
+	if template == nil {
+		template = map[string]interface{}{}
+	}
+	meta, ok := template["metadata"].(map[string]interface{})
+	if !ok {
+		meta = map[string]interface{}{}
+	}
+	meta["name"] = name
+	if ns := Namespace(obj); ns != "" {
+		meta["namespace"] = ns
+	}
+	template["metadata"] = meta
+	return template
+}
+
+// Labels returns the labels of the metadata as a map[string]string
+func Labels(meta map[string]interface{}) map[string]string {
+	labels := map[string]string{}
+	switch l := meta["labels"].(type) {
+	case map[string]interface{}:
+		for k, v := range l {
+			if s, ok := v.(string); ok {
+				labels[k] = s
+			}
+		}
+	case map[string]string:
+		for k, v := range l {
+			labels[k] = v
+		}
+	}
+	return labels
+}
+
+// Name returns the name of obj
+func Name(obj map[string]interface{}) string {
+	meta, _ := obj["metadata"].(map[string]interface{})
+	name, _ := meta["name"].(string)
+	return name
+}
+
+// Namespace returns the namespace of obj
+func Namespace(obj map[string]interface{}) string {
+	meta, _ := obj["metadata"].(map[string]interface{})
+	ns, _ := meta["namespace"].(string)
+	return ns
+}
+
+func unstructuredLabels(labels map[string]string) map[string]interface{} {
+	result := make(map[string]interface{}, len(labels))
+	for k, v := range labels {
+		result[k] = v
+	}
+	return result
+}
+
+func getSpec(obj map[string]interface{}) map[string]interface{} {
+	spec, ok := obj["spec"].(map[string]interface{})
+	if !ok {
+		spec = map[string]interface{}{}
+	}
+	return spec
+}
@@ -0,0 +1,146 @@
+package workload
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+)
+
+func TestSetTemplateFromPod(t *testing.T) {
+	pod := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":        "web",
+			"namespace":   "shop",
+			"labels":      map[string]interface{}{"app": "web"},
+			"annotations": map[string]interface{}{"team": "a"},
+		},
+		"spec": map[string]interface{}{"containers": []interface{}{map[string]interface{}{"name": "web"}}},
+	}
+	obj := map[string]interface{}{}
+	SetTemplateFromPod(obj, pod)
+
+	expected := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"selector": map[string]interface{}{"matchLabels": map[string]interface{}{"app": "web"}},
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"labels":      map[string]interface{}{"app": "web"},
+					"annotations": map[string]interface{}{"team": "a"},
+				},
+				"spec": map[string]interface{}{"containers": []interface{}{map[string]interface{}{"name": "web"}}},
+			},
+		},
+	}
+	if !reflect.DeepEqual(obj, expected) {
+		t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", obj, expected)
+	}
+
+	standalone := Pod(obj, "web-test")
+	expectedPod := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":        "web-test",
+			"labels":      map[string]interface{}{"app": "web"},
+			"annotations": map[string]interface{}{"team": "a"},
+		},
+		"spec": map[string]interface{}{"containers": []interface{}{map[string]interface{}{"name": "web"}}},
+	}
+	if !reflect.DeepEqual(standalone, expectedPod) {
+		t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", standalone, expectedPod)
+	}
+}
+
+func TestSetTemplateFromPodSelector(t *testing.T) {
+	pod := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "web", "labels": map[string]interface{}{"app": "web", "track": "canary"}},
+	}
+	obj := map[string]interface{}{
+		"spec": map[string]interface{}{"selector": map[string]interface{}{"matchLabels": map[string]interface{}{"app": "web"}}},
+	}
+	SetTemplateFromPod(obj, pod)
+	expected := map[string]interface{}{"matchLabels": map[string]interface{}{"app": "web"}}
+	if sel := obj["spec"].(map[string]interface{})["selector"]; !reflect.DeepEqual(sel, expected) {
+		t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", sel, expected)
+	}
+
+	obj = map[string]interface{}{}
+	SetTemplateFromPod(obj, map[string]interface{}{"metadata": map[string]interface{}{"name": "web"}})
+	if err := unstruct.Err(obj); err == nil {
+		t.Error("expecting error, got none")
+	}
+	if _, found := obj["spec"].(map[string]interface{})["selector"]; found {
+		t.Error("expecting no selector for a pod without labels")
+	}
+}
+
+func TestLabels(t *testing.T) {
+	tests := map[string]struct {
+		meta     map[string]interface{}
+		expected map[string]string
+	}{
+		"none":         {meta: map[string]interface{}{}, expected: map[string]string{}},
+		"unstructured": {meta: map[string]interface{}{"labels": map[string]interface{}{"app": "web"}}, expected: map[string]string{"app": "web"}},
+		"typed":        {meta: map[string]interface{}{"labels": map[string]string{"app": "web"}}, expected: map[string]string{"app": "web"}},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if labels := Labels(test.meta); !reflect.DeepEqual(labels, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", labels, test.expected)
+			}
+		})
+	}
+}
+
+func TestInheritLabels(t *testing.T) {
+	meta := func() map[string]interface{} {
+		return map[string]interface{}{"labels": map[string]interface{}{"app": "web", "tier": "front", "version": "1.2.0"}}
+	}
+	tests := map[string]struct {
+		obj      map[string]interface{}
+		keys     []string
+		expected map[string]interface{}
+	}{
+		"no selector": {
+			obj:  map[string]interface{}{"metadata": meta()},
+			keys: []string{"app", "tier", "missing"},
+			expected: map[string]interface{}{
+				"selector": map[string]interface{}{"matchLabels": map[string]interface{}{"app": "web", "tier": "front"}},
+				"template": map[string]interface{}{"metadata": map[string]interface{}{"labels": map[string]interface{}{"app": "web", "tier": "front"}}},
+			},
+		},
+		"template label kept": {
+			obj: map[string]interface{}{"metadata": meta(), "spec": map[string]interface{}{
+				"template": map[string]interface{}{"metadata": map[string]interface{}{"labels": map[string]interface{}{"tier": "back"}}},
+			}},
+			keys: []string{"tier"},
+			expected: map[string]interface{}{
+				"selector": map[string]interface{}{"matchLabels": map[string]interface{}{"tier": "back"}},
+				"template": map[string]interface{}{"metadata": map[string]interface{}{"labels": map[string]interface{}{"tier": "back"}}},
+			},
+		},
+		"selector set": {
+			obj: map[string]interface{}{"metadata": meta(), "spec": map[string]interface{}{
+				"selector": map[string]interface{}{"matchLabels": map[string]interface{}{"app": "web"}},
+			}},
+			keys: []string{"app", "version"},
+			expected: map[string]interface{}{
+				"selector": map[string]interface{}{"matchLabels": map[string]interface{}{"app": "web"}},
+				"template": map[string]interface{}{"metadata": map[string]interface{}{"labels": map[string]interface{}{"app": "web", "version": "1.2.0"}}},
+			},
+		},
+		"nothing inherited": {
+			obj:  map[string]interface{}{"metadata": meta()},
+			keys: []string{"missing"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			InheritLabels(test.obj, test.keys)
+			spec, _ := test.obj["spec"].(map[string]interface{})
+			if !reflect.DeepEqual(spec, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", spec, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+// Package job contains builder types to build values of type batchV1.Job
+package job
+
+import (
+	"fmt"
+
+	"github.com/vladimirvivien/kob/container"
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	"github.com/vladimirvivien/kob/pod"
+	batchV1 "k8s.io/api/batch/v1"
+	coreV1 "k8s.io/api/core/v1"
+)
+
+// Builder provides a way to build values of type batchV1.Job
+type Builder map[string]interface{}
+
+// Object starts a new Job builder using the provided object metadata
+func Object(metadata objmeta.Builder) Builder {
+	meta, err := metadata.U()
+	return Builder(unstruct.SetErr(map[string]interface{}{"metadata": meta}, err))
+}
+
+// U returns the unstructured value of the builder
+func (b Builder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b Builder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder. An error is returned
+// if the Job spec is invalid (i.e. its pod template uses restart policy Always).
+func (b Builder) T() (batchV1.Job, error) {
+	var job batchV1.Job
+	if err := unstruct.FromUnstructured(b, &job); err != nil {
+		return batchV1.Job{}, err
+	}
+	if err := naming.Validate("Job", job.Name); err != nil {
+		return batchV1.Job{}, err
+	}
+	if err := validate(job.Spec); err != nil {
+		return batchV1.Job{}, err
+	}
+	return job, nil
+}
+
+// Completions sets the desired number of successfully finished pods
+func (b Builder) Completions(c int) Builder {
+	return b.setSpec("completions", int64(c))
+}
+
+// Parallelism sets the maximum number of pods the job should run at any given time
+func (b Builder) Parallelism(p int) Builder {
+	return b.setSpec("parallelism", int64(p))
+}
+
+// CompletionMode sets how pod completions are tracked (NonIndexed or Indexed)
+func (b Builder) CompletionMode(mode batchV1.CompletionMode) Builder {
+	return b.setSpec("completionMode", mode)
+}
+
+// Indexed is a shortcut for CompletionMode(batchV1.IndexedCompletion)
+func (b Builder) Indexed() Builder {
+	return b.CompletionMode(batchV1.IndexedCompletion)
+}
+
+// BackoffLimit sets the number of retries before marking the job as failed
+func (b Builder) BackoffLimit(l int) Builder {
+	return b.setSpec("backoffLimit", int64(l))
+}
+
+// BackoffLimitPerIndex sets the number of retries allowed for each index
+// of an Indexed job
+func (b Builder) BackoffLimitPerIndex(l int) Builder {
+	return b.setSpec("backoffLimitPerIndex", int64(l))
+}
+
+// MaxFailedIndexes sets the maximum number of failed indexes (used
+// along with BackoffLimitPerIndex) before the job is marked as failed
+func (b Builder) MaxFailedIndexes(m int) Builder {
+	return b.setSpec("maxFailedIndexes", int64(m))
+}
+
+// PodFailurePolicy sets the rules used to handle pod failures. Because the
+// policy requires it, the pod template's restart policy is set to Never.
+func (b Builder) PodFailurePolicy(rules ...PodFailurePolicyRuleBuilder) Builder {
+	var slice []interface{}
+	for _, r := range rules {
+		slice = append(slice, unstruct.Nest(r))
+	}
+	b.setSpec("podFailurePolicy", map[string]interface{}{"rules": slice})
+	b.defaultRestartPolicy()
+	return b
+}
+
+// ActiveDeadlineSeconds sets how long, in seconds, the job may be active
+// before the system tries to terminate it
+func (b Builder) ActiveDeadlineSeconds(s int) Builder {
+	return b.setSpec("activeDeadlineSeconds", int64(s))
+}
+
+// TTLSecondsAfterFinished sets how long, in seconds, a finished job is kept
+// before it is automatically deleted
+func (b Builder) TTLSecondsAfterFinished(s int) Builder {
+	return b.setSpec("ttlSecondsAfterFinished", int64(s))
+}
+
+// Suspend sets whether the job controller should create pods or not
+func (b Builder) Suspend(s bool) Builder {
+	return b.setSpec("suspend", s)
+}
+
+// PodSpec sets the pod template of the job with the specified containers.
+// The restart policy of the template defaults to OnFailure (or Never when a pod
+// failure policy is set) since Always is not allowed for jobs.
+func (b Builder) PodSpec(containers ...container.Builder) Builder {
+	b.setSpec("template", map[string]interface{}{
+		"spec": unstruct.Nest(pod.Spec(containers...)),
+	})
+	b.defaultRestartPolicy()
+	return b
+}
+
+// PodSpecWithMetadata sets the pod template of the job with the specified metadata
+// and containers. The restart policy is defaulted the same way as PodSpec.
+func (b Builder) PodSpecWithMetadata(metadata objmeta.Builder, containers ...container.Builder) Builder {
+	meta, err := metadata.U()
+	unstruct.SetErr(b, err)
+	b.setSpec("template", map[string]interface{}{
+		"metadata": meta,
+		"spec":     unstruct.Nest(pod.Spec(containers...)),
+	})
+	b.defaultRestartPolicy()
+	return b
+}
+
+// RestartPolicy overrides the restart policy of the job's pod template
+// (only OnFailure or Never are valid for jobs)
+func (b Builder) RestartPolicy(pol coreV1.RestartPolicy) Builder {
+	spec := b.getTemplateSpec()
+	if spec == nil {
+		return b
+	}
+	spec["restartPolicy"] = pol
+	return b
+}
+
+func (b Builder) setSpec(key string, val interface{}) Builder {
+	spec := b.getJobSpec()
+	spec[key] = val
+	b["spec"] = spec
+	return b
+}
+
+func (b Builder) getJobSpec() map[string]interface{} {
+	specIface, ok := b["spec"]
+	if !ok {
+		specIface = map[string]interface{}{}
+	}
+	return specIface.(map[string]interface{})
+}
+
+// getTemplateSpec returns the pod spec of the job template or nil if no template is set
+func (b Builder) getTemplateSpec() map[string]interface{} {
+	tmpl, ok := b.getJobSpec()["template"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	spec, ok := tmpl["spec"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return spec
+}
+
+// defaultRestartPolicy sets the restart policy for the pod template to
+// OnFailure or to Never if a pod failure policy is set
+func (b Builder) defaultRestartPolicy() {
+	pol := coreV1.RestartPolicyOnFailure
+	if _, ok := b.getJobSpec()["podFailurePolicy"]; ok {
+		pol = coreV1.RestartPolicyNever
+	}
+	b.RestartPolicy(pol)
+}
+
+func validate(spec batchV1.JobSpec) error {
+	switch spec.Template.Spec.RestartPolicy {
+	case coreV1.RestartPolicyAlways:
+		return fmt.Errorf("job: restart policy %s not allowed", coreV1.RestartPolicyAlways)
+	case coreV1.RestartPolicyOnFailure:
+		if spec.PodFailurePolicy != nil {
+			return fmt.Errorf("job: pod failure policy requires restart policy %s", coreV1.RestartPolicyNever)
+		}
+	}
+	if spec.BackoffLimitPerIndex != nil && (spec.CompletionMode == nil || *spec.CompletionMode != batchV1.IndexedCompletion) {
+		return fmt.Errorf("job: backoffLimitPerIndex requires completion mode %s", batchV1.IndexedCompletion)
+	}
+	if spec.MaxFailedIndexes != nil && spec.BackoffLimitPerIndex == nil {
+		return fmt.Errorf("job: maxFailedIndexes requires backoffLimitPerIndex")
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package job
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/container"
+	"github.com/vladimirvivien/kob/objmeta"
+	batchV1 "k8s.io/api/batch/v1"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestJobUnstructured(t *testing.T) {
+	tests := map[string]struct {
+		builder  Builder
+		expected map[string]interface{}
+	}{
+		"empty": {
+			builder:  Builder{},
+			expected: map[string]interface{}{},
+		},
+		"job with object meta only": {
+			builder:  Object(objmeta.Name("simple-job").Namespace("default")),
+			expected: map[string]interface{}{"metadata": map[string]interface{}{"name": "simple-job", "namespace": "default"}},
+		},
+		"job with completions and parallelism": {
+			builder: Object(objmeta.Name("simple-job")).Completions(5).Parallelism(2),
+			expected: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "simple-job"},
+				"spec":     map[string]interface{}{"completions": int64(5), "parallelism": int64(2)},
+			},
+		},
+		"job with podspec": {
+			builder: Object(objmeta.Name("simple-job")).PodSpec(container.Name("simple-container")),
+			expected: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "simple-job"},
+				"spec": map[string]interface{}{
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"containers":    []interface{}{map[string]interface{}{"name": "simple-container"}},
+							"restartPolicy": coreV1.RestartPolicyOnFailure,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			job := test.builder
+			if !reflect.DeepEqual(job.U(), test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", job.U(), test.expected)
+			}
+		})
+	}
+}
+
+func TestJobTyped(t *testing.T) {
+	tests := map[string]struct {
+		builder  Builder
+		expected batchV1.Job
+	}{
+		"empty": {
+			builder:  Builder{},
+			expected: batchV1.Job{},
+		},
+		"object meta only": {
+			builder:  Object(objmeta.Name("simple-job").Namespace("default")),
+			expected: batchV1.Job{ObjectMeta: metaV1.ObjectMeta{Name: "simple-job", Namespace: "default"}},
+		},
+		"with podspec": {
+			builder: Object(objmeta.Name("simple-job")).Completions(5).Parallelism(2).BackoffLimit(3).ActiveDeadlineSeconds(60).TTLSecondsAfterFinished(30).
+				PodSpec(container.Name("simple-container")),
+			expected: batchV1.Job{
+				ObjectMeta: metaV1.ObjectMeta{Name: "simple-job"},
+				Spec: batchV1.JobSpec{
+					Completions:             int32Ptr(5),
+					Parallelism:             int32Ptr(2),
+					BackoffLimit:            int32Ptr(3),
+					ActiveDeadlineSeconds:   int64Ptr(60),
+					TTLSecondsAfterFinished: int32Ptr(30),
+					Template: coreV1.PodTemplateSpec{
+						Spec: coreV1.PodSpec{
+							Containers:    []coreV1.Container{{Name: "simple-container"}},
+							RestartPolicy: coreV1.RestartPolicyOnFailure,
+						},
+					},
+				},
+			},
+		},
+		"indexed with backoff limit per index": {
+			builder: Object(objmeta.Name("simple-job")).Completions(5).Indexed().BackoffLimitPerIndex(1).MaxFailedIndexes(2).
+				PodSpecWithMetadata(objmeta.Name("job-pods"), container.Name("simple-container")),
+			expected: batchV1.Job{
+				ObjectMeta: metaV1.ObjectMeta{Name: "simple-job"},
+				Spec: batchV1.JobSpec{
+					Completions:          int32Ptr(5),
+					CompletionMode:       completionModePtr(batchV1.IndexedCompletion),
+					BackoffLimitPerIndex: int32Ptr(1),
+					MaxFailedIndexes:     int32Ptr(2),
+					Template: coreV1.PodTemplateSpec{
+						ObjectMeta: metaV1.ObjectMeta{Name: "job-pods"},
+						Spec: coreV1.PodSpec{
+							Containers:    []coreV1.Container{{Name: "simple-container"}},
+							RestartPolicy: coreV1.RestartPolicyOnFailure,
+						},
+					},
+				},
+			},
+		},
+		"with pod failure policy": {
+			builder: Object(objmeta.Name("simple-job")).PodSpec(container.Name("simple-container")).PodFailurePolicy(IgnoreDisruptions()),
+			expected: batchV1.Job{
+				ObjectMeta: metaV1.ObjectMeta{Name: "simple-job"},
+				Spec: batchV1.JobSpec{
+					PodFailurePolicy: &batchV1.PodFailurePolicy{
+						Rules: []batchV1.PodFailurePolicyRule{{
+							Action:          batchV1.PodFailurePolicyActionIgnore,
+							OnPodConditions: []batchV1.PodFailurePolicyOnPodConditionsPattern{{Type: coreV1.DisruptionTarget, Status: coreV1.ConditionTrue}},
+						}},
+					},
+					Template: coreV1.PodTemplateSpec{
+						Spec: coreV1.PodSpec{
+							Containers:    []coreV1.Container{{Name: "simple-container"}},
+							RestartPolicy: coreV1.RestartPolicyNever,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			job, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(job, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", job, test.expected)
+			}
+		})
+	}
+}
+
+func TestJobInvalid(t *testing.T) {
+	tests := map[string]Builder{
+		"restart policy always":                Object(objmeta.Name("simple-job")).PodSpec(container.Name("simple-container")).RestartPolicy(coreV1.RestartPolicyAlways),
+		"pod failure policy with on failure":   Object(objmeta.Name("simple-job")).PodFailurePolicy(IgnoreDisruptions()).PodSpec(container.Name("simple-container")).RestartPolicy(coreV1.RestartPolicyOnFailure),
+		"backoff limit per index not indexed":  Object(objmeta.Name("simple-job")).BackoffLimitPerIndex(1),
+		"max failed indexes without per index": Object(objmeta.Name("simple-job")).Indexed().MaxFailedIndexes(1),
+	}
+
+	for name, builder := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := builder.T(); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func completionModePtr(mode batchV1.CompletionMode) *batchV1.CompletionMode {
+	return &mode
+}
@@ -0,0 +1,71 @@
+package job
+
+import (
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	batchV1 "k8s.io/api/batch/v1"
+	coreV1 "k8s.io/api/core/v1"
+)
+
+// PodFailurePolicyRuleBuilder provides a way to build values of type batchV1.PodFailurePolicyRule
+type PodFailurePolicyRuleBuilder map[string]interface{}
+
+// PodFailurePolicyRule starts a new rule with the action taken when the rule matches
+func PodFailurePolicyRule(action batchV1.PodFailurePolicyAction) PodFailurePolicyRuleBuilder {
+	return PodFailurePolicyRuleBuilder{"action": action}
+}
+
+// FailJobOnExitCodes is a shortcut for a rule that fails the job when the
+// container exits with one of the specified codes
+func FailJobOnExitCodes(containerName string, codes ...int32) PodFailurePolicyRuleBuilder {
+	return PodFailurePolicyRule(batchV1.PodFailurePolicyActionFailJob).OnExitCodes(containerName, batchV1.PodFailurePolicyOnExitCodesOpIn, codes...)
+}
+
+// IgnoreDisruptions is a shortcut for a rule that does not count pod
+// disruptions (i.e. preemption or eviction) towards the backoff limit
+func IgnoreDisruptions() PodFailurePolicyRuleBuilder {
+	return PodFailurePolicyRule(batchV1.PodFailurePolicyActionIgnore).OnPodConditions(coreV1.DisruptionTarget)
+}
+
+// U returns the unstructured value of the builder
+func (b PodFailurePolicyRuleBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b PodFailurePolicyRuleBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b PodFailurePolicyRuleBuilder) T() (batchV1.PodFailurePolicyRule, error) {
+	var rule batchV1.PodFailurePolicyRule
+	if err := unstruct.FromUnstructured(b, &rule); err != nil {
+		return batchV1.PodFailurePolicyRule{}, err
+	}
+	return rule, nil
+}
+
+// OnExitCodes matches the rule against the exit codes of the named container
+// (an empty name matches all containers)
+func (b PodFailurePolicyRuleBuilder) OnExitCodes(containerName string, op batchV1.PodFailurePolicyOnExitCodesOperator, codes ...int32) PodFailurePolicyRuleBuilder {
+	values := []interface{}{}
+	for _, c := range codes {
+		values = append(values, int64(c))
+	}
+	req := map[string]interface{}{"operator": op, "values": values}
+	if containerName != "" {
+		req["containerName"] = containerName
+	}
+	b["onExitCodes"] = req
+	return b
+}
+
+// OnPodConditions matches the rule against the specified pod conditions with status True
+func (b PodFailurePolicyRuleBuilder) OnPodConditions(conditions ...coreV1.PodConditionType) PodFailurePolicyRuleBuilder {
+	var slice []interface{}
+	for _, c := range conditions {
+		slice = append(slice, map[string]interface{}{"type": c, "status": coreV1.ConditionTrue})
+	}
+	b["onPodConditions"] = slice
+	return b
+}
@@ -0,0 +1,66 @@
+package job
+
+import (
+	"reflect"
+	"testing"
+
+	batchV1 "k8s.io/api/batch/v1"
+	coreV1 "k8s.io/api/core/v1"
+)
+
+func TestPodFailurePolicyRuleStructured(t *testing.T) {
+	containerName := "simple-container"
+	tests := map[string]struct {
+		builder  PodFailurePolicyRuleBuilder
+		expected batchV1.PodFailurePolicyRule
+	}{
+		"empty": {
+			builder:  PodFailurePolicyRuleBuilder{},
+			expected: batchV1.PodFailurePolicyRule{},
+		},
+		"action only": {
+			builder:  PodFailurePolicyRule(batchV1.PodFailurePolicyActionCount),
+			expected: batchV1.PodFailurePolicyRule{Action: batchV1.PodFailurePolicyActionCount},
+		},
+		"fail job on exit codes": {
+			builder: FailJobOnExitCodes(containerName, 42, 43),
+			expected: batchV1.PodFailurePolicyRule{
+				Action: batchV1.PodFailurePolicyActionFailJob,
+				OnExitCodes: &batchV1.PodFailurePolicyOnExitCodesRequirement{
+					ContainerName: &containerName,
+					Operator:      batchV1.PodFailurePolicyOnExitCodesOpIn,
+					Values:        []int32{42, 43},
+				},
+			},
+		},
+		"exit codes all containers": {
+			builder: PodFailurePolicyRule(batchV1.PodFailurePolicyActionFailIndex).OnExitCodes("", batchV1.PodFailurePolicyOnExitCodesOpNotIn, 0),
+			expected: batchV1.PodFailurePolicyRule{
+				Action: batchV1.PodFailurePolicyActionFailIndex,
+				OnExitCodes: &batchV1.PodFailurePolicyOnExitCodesRequirement{
+					Operator: batchV1.PodFailurePolicyOnExitCodesOpNotIn,
+					Values:   []int32{0},
+				},
+			},
+		},
+		"ignore disruptions": {
+			builder: IgnoreDisruptions(),
+			expected: batchV1.PodFailurePolicyRule{
+				Action:          batchV1.PodFailurePolicyActionIgnore,
+				OnPodConditions: []batchV1.PodFailurePolicyOnPodConditionsPattern{{Type: coreV1.DisruptionTarget, Status: coreV1.ConditionTrue}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rule, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(rule, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", rule, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Package lease contains builder types to build values of type coordinationV1.Lease
+package lease
+
+import (
+	"time"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	coordinationV1 "k8s.io/api/coordination/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Builder provides a way to build values of type coordinationV1.Lease
+type Builder map[string]interface{}
+
+// Object starts a new Lease builder using the provided object metadata
+func Object(metadata objmeta.Builder) Builder {
+	meta, err := metadata.U()
+	return Builder(unstruct.SetErr(map[string]interface{}{"metadata": meta}, err))
+}
+
+// U returns the unstructured value of the builder
+func (b Builder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b Builder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b Builder) T() (coordinationV1.Lease, error) {
+	var lease coordinationV1.Lease
+	if err := unstruct.FromUnstructured(b, &lease); err != nil {
+		return coordinationV1.Lease{}, err
+	}
+	if err := naming.Validate("Lease", lease.Name); err != nil {
+		return coordinationV1.Lease{}, err
+	}
+	return lease, nil
+}
+
+// HolderIdentity sets the identity of the current holder of the lease
+func (b Builder) HolderIdentity(id string) Builder {
+	return b.setSpec("holderIdentity", id)
+}
+
+// LeaseDurationSeconds sets how long candidates wait before forcefully acquiring the lease
+func (b Builder) LeaseDurationSeconds(secs int32) Builder {
+	return b.setSpec("leaseDurationSeconds", int64(secs))
+}
+
+// AcquireTime sets when the current lease was acquired
+func (b Builder) AcquireTime(t time.Time) Builder {
+	return b.setSpec("acquireTime", microTime(t))
+}
+
+// RenewTime sets when the current holder last renewed the lease
+func (b Builder) RenewTime(t time.Time) Builder {
+	return b.setSpec("renewTime", microTime(t))
+}
+
+// LeaseTransitions sets the number of times the lease changed holders
+func (b Builder) LeaseTransitions(count int32) Builder {
+	return b.setSpec("leaseTransitions", int64(count))
+}
+
+func (b Builder) setSpec(key string, val interface{}) Builder {
+	spec := b.getLeaseSpec()
+	spec[key] = val
+	b["spec"] = spec
+	return b
+}
+
+func (b Builder) getLeaseSpec() map[string]interface{} {
+	specIface, ok := b["spec"]
+	if !ok {
+		specIface = map[string]interface{}{}
+	}
+	return specIface.(map[string]interface{})
+}
+
+func microTime(t time.Time) string {
+	return t.UTC().Format(metaV1.RFC3339Micro)
+}
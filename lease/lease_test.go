@@ -0,0 +1,67 @@
+package lease
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/vladimirvivien/kob/objmeta"
+	coordinationV1 "k8s.io/api/coordination/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLeaseTyped(t *testing.T) {
+	acquired := time.Date(2023, 10, 1, 12, 0, 0, 123456000, time.UTC)
+	renewed := acquired.Add(10 * time.Second)
+	tests := map[string]struct {
+		builder  Builder
+		expected coordinationV1.Lease
+	}{
+		"empty": {
+			builder:  Builder{},
+			expected: coordinationV1.Lease{},
+		},
+		"object meta only": {
+			builder:  Object(objmeta.Name("controller-leader").Namespace("kube-system")),
+			expected: coordinationV1.Lease{ObjectMeta: metaV1.ObjectMeta{Name: "controller-leader", Namespace: "kube-system"}},
+		},
+		"held lease": {
+			builder: Object(objmeta.Name("controller-leader")).
+				HolderIdentity("controller-0").
+				LeaseDurationSeconds(15).
+				AcquireTime(acquired).
+				RenewTime(renewed).
+				LeaseTransitions(3),
+			expected: coordinationV1.Lease{
+				ObjectMeta: metaV1.ObjectMeta{Name: "controller-leader"},
+				Spec: coordinationV1.LeaseSpec{
+					HolderIdentity:       strPtr("controller-0"),
+					LeaseDurationSeconds: int32Ptr(15),
+					AcquireTime:          &metaV1.MicroTime{Time: acquired.Local()},
+					RenewTime:            &metaV1.MicroTime{Time: renewed.Local()},
+					LeaseTransitions:     int32Ptr(3),
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			lease, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(lease, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", lease, test.expected)
+			}
+		})
+	}
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,86 @@
+package limitrange
+
+import (
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	coreV1 "k8s.io/api/core/v1"
+)
+
+// ItemBuilder provides a way to build values of type coreV1.LimitRangeItem
+type ItemBuilder map[string]interface{}
+
+// Item starts a new limit for the kind of resource (Container, Pod, PersistentVolumeClaim)
+func Item(limitType coreV1.LimitType) ItemBuilder {
+	return ItemBuilder{"type": limitType}
+}
+
+// Container starts a new limit applied to each container
+func Container() ItemBuilder {
+	return Item(coreV1.LimitTypeContainer)
+}
+
+// Pod starts a new limit applied to the sum of all containers in a pod
+func Pod() ItemBuilder {
+	return Item(coreV1.LimitTypePod)
+}
+
+// PersistentVolumeClaim starts a new limit applied to each claim's storage request
+func PersistentVolumeClaim() ItemBuilder {
+	return Item(coreV1.LimitTypePersistentVolumeClaim)
+}
+
+// U returns the unstructured value of the builder
+func (b ItemBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b ItemBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b ItemBuilder) T() (coreV1.LimitRangeItem, error) {
+	var item coreV1.LimitRangeItem
+	if err := unstruct.FromUnstructured(b, &item); err != nil {
+		return coreV1.LimitRangeItem{}, err
+	}
+	return item, nil
+}
+
+// Default sets the limits applied to containers that specify none
+func (b ItemBuilder) Default(res coreV1.ResourceList) ItemBuilder {
+	b["default"] = resourceList(res)
+	return b
+}
+
+// DefaultRequest sets the requests applied to containers that specify none
+func (b ItemBuilder) DefaultRequest(res coreV1.ResourceList) ItemBuilder {
+	b["defaultRequest"] = resourceList(res)
+	return b
+}
+
+// Min sets the minimum usage allowed
+func (b ItemBuilder) Min(res coreV1.ResourceList) ItemBuilder {
+	b["min"] = resourceList(res)
+	return b
+}
+
+// Max sets the maximum usage allowed
+func (b ItemBuilder) Max(res coreV1.ResourceList) ItemBuilder {
+	b["max"] = resourceList(res)
+	return b
+}
+
+// MaxLimitRequestRatio sets the maximum allowed ratio between limit and request
+func (b ItemBuilder) MaxLimitRequestRatio(res coreV1.ResourceList) ItemBuilder {
+	b["maxLimitRequestRatio"] = resourceList(res)
+	return b
+}
+
+func resourceList(res coreV1.ResourceList) map[string]interface{} {
+	list := map[string]interface{}{}
+	for name, qty := range res {
+		list[string(name)] = qty.String()
+	}
+	return list
+}
@@ -0,0 +1,53 @@
+package limitrange
+
+import (
+	"reflect"
+	"testing"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestItemStructured(t *testing.T) {
+	tests := map[string]struct {
+		builder  ItemBuilder
+		expected coreV1.LimitRangeItem
+	}{
+		"empty": {
+			builder:  ItemBuilder{},
+			expected: coreV1.LimitRangeItem{},
+		},
+		"pod": {
+			builder:  Pod(),
+			expected: coreV1.LimitRangeItem{Type: coreV1.LimitTypePod},
+		},
+		"container defaults": {
+			builder: Container().
+				Default(coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("500m"), coreV1.ResourceMemory: resource.MustParse("512Mi")}).
+				DefaultRequest(coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("100m")}).
+				Min(coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("10m")}).
+				Max(coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("2")}).
+				MaxLimitRequestRatio(coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("10")}),
+			expected: coreV1.LimitRangeItem{
+				Type:                 coreV1.LimitTypeContainer,
+				Default:              coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("500m"), coreV1.ResourceMemory: resource.MustParse("512Mi")},
+				DefaultRequest:       coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("100m")},
+				Min:                  coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("10m")},
+				Max:                  coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("2")},
+				MaxLimitRequestRatio: coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("10")},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			item, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(item, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", item, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Package limitrange contains builder types to build values of type coreV1.LimitRange
+package limitrange
+
+import (
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	coreV1 "k8s.io/api/core/v1"
+)
+
+// Builder provides a way to build values of type coreV1.LimitRange
+type Builder map[string]interface{}
+
+// Object starts a new LimitRange builder using the provided object metadata
+func Object(metadata objmeta.Builder) Builder {
+	meta, err := metadata.U()
+	return Builder(unstruct.SetErr(map[string]interface{}{"metadata": meta}, err))
+}
+
+// U returns the unstructured value of the builder
+func (b Builder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b Builder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b Builder) T() (coreV1.LimitRange, error) {
+	var lr coreV1.LimitRange
+	if err := unstruct.FromUnstructured(b, &lr); err != nil {
+		return coreV1.LimitRange{}, err
+	}
+	if err := naming.Validate("LimitRange", lr.Name); err != nil {
+		return coreV1.LimitRange{}, err
+	}
+	return lr, nil
+}
+
+// Limits sets the limits enforced by the limit range
+func (b Builder) Limits(items ...ItemBuilder) Builder {
+	var slice []interface{}
+	for _, i := range items {
+		slice = append(slice, unstruct.Nest(i))
+	}
+	b["spec"] = map[string]interface{}{"limits": slice}
+	return b
+}
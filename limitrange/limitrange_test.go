@@ -0,0 +1,52 @@
+package limitrange
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/objmeta"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLimitRangeTyped(t *testing.T) {
+	tests := map[string]struct {
+		builder  Builder
+		expected coreV1.LimitRange
+	}{
+		"empty": {
+			builder:  Builder{},
+			expected: coreV1.LimitRange{},
+		},
+		"object meta only": {
+			builder:  Object(objmeta.Name("limits").Namespace("team-a")),
+			expected: coreV1.LimitRange{ObjectMeta: metaV1.ObjectMeta{Name: "limits", Namespace: "team-a"}},
+		},
+		"container and claim limits": {
+			builder: Object(objmeta.Name("limits")).Limits(
+				Container().Max(coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("2")}),
+				PersistentVolumeClaim().Min(coreV1.ResourceList{coreV1.ResourceStorage: resource.MustParse("1Gi")}),
+			),
+			expected: coreV1.LimitRange{
+				ObjectMeta: metaV1.ObjectMeta{Name: "limits"},
+				Spec: coreV1.LimitRangeSpec{Limits: []coreV1.LimitRangeItem{
+					{Type: coreV1.LimitTypeContainer, Max: coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("2")}},
+					{Type: coreV1.LimitTypePersistentVolumeClaim, Min: coreV1.ResourceList{coreV1.ResourceStorage: resource.MustParse("1Gi")}},
+				}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			lr, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(lr, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", lr, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Package namespace contains builder types to build values of type coreV1.Namespace
+package namespace
+
+import (
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	coreV1 "k8s.io/api/core/v1"
+)
+
+// Level is a Pod Security Standards level
+type Level string
+
+// Mode is the way a pod security admission level is applied to a namespace
+type Mode string
+
+const (
+	LevelPrivileged Level = "privileged"
+	LevelBaseline   Level = "baseline"
+	LevelRestricted Level = "restricted"
+
+	ModeEnforce Mode = "enforce"
+	ModeAudit   Mode = "audit"
+	ModeWarn    Mode = "warn"
+)
+
+const podSecurityLabelPrefix = "pod-security.kubernetes.io/"
+
+// Builder provides a way to build values of type coreV1.Namespace
+type Builder map[string]interface{}
+
+// Object starts a new Namespace builder using the provided object metadata
+func Object(metadata objmeta.Builder) Builder {
+	meta, err := metadata.U()
+	return Builder(unstruct.SetErr(map[string]interface{}{"metadata": meta}, err))
+}
+
+// U returns the unstructured value of the builder
+func (b Builder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b Builder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b Builder) T() (coreV1.Namespace, error) {
+	var ns coreV1.Namespace
+	if err := unstruct.FromUnstructured(b, &ns); err != nil {
+		return coreV1.Namespace{}, err
+	}
+	if err := naming.Validate("Namespace", ns.Name); err != nil {
+		return coreV1.Namespace{}, err
+	}
+	return ns, nil
+}
+
+// PodSecurity sets the pod security admission label for mode to level
+// (i.e. pod-security.kubernetes.io/enforce: restricted)
+func (b Builder) PodSecurity(mode Mode, level Level) Builder {
+	return b.setLabel(podSecurityLabelPrefix+string(mode), string(level))
+}
+
+// PodSecurityVersion pins the version of the policy applied for mode
+// (i.e. pod-security.kubernetes.io/enforce-version: v1.28)
+func (b Builder) PodSecurityVersion(mode Mode, version string) Builder {
+	return b.setLabel(podSecurityLabelPrefix+string(mode)+"-version", version)
+}
+
+// EnforcePodSecurity enforces, audits and warns at the specified level
+func (b Builder) EnforcePodSecurity(level Level) Builder {
+	return b.PodSecurity(ModeEnforce, level).PodSecurity(ModeAudit, level).PodSecurity(ModeWarn, level)
+}
+
+func (b Builder) setLabel(key, val string) Builder {
+	meta, ok := b["metadata"].(map[string]interface{})
+	if !ok {
+		meta = map[string]interface{}{}
+	}
+	labels, ok := meta["labels"].(map[string]interface{})
+	if !ok {
+		labels = map[string]interface{}{}
+	}
+	labels[key] = val
+	meta["labels"] = labels
+	b["metadata"] = meta
+	return b
+}
@@ -0,0 +1,56 @@
+package namespace
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/objmeta"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNamespaceTyped(t *testing.T) {
+	tests := map[string]struct {
+		builder  Builder
+		expected coreV1.Namespace
+	}{
+		"empty": {
+			builder:  Builder{},
+			expected: coreV1.Namespace{},
+		},
+		"name only": {
+			builder:  Object(objmeta.Name("team-a")),
+			expected: coreV1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "team-a"}},
+		},
+		"pod security with existing labels": {
+			builder: Object(objmeta.Name("team-a").Labels(map[string]string{"team": "a"})).
+				PodSecurity(ModeEnforce, LevelBaseline).PodSecurityVersion(ModeEnforce, "v1.28").PodSecurity(ModeWarn, LevelRestricted),
+			expected: coreV1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "team-a", Labels: map[string]string{
+				"team":                                       "a",
+				"pod-security.kubernetes.io/enforce":         "baseline",
+				"pod-security.kubernetes.io/enforce-version": "v1.28",
+				"pod-security.kubernetes.io/warn":            "restricted",
+			}}},
+		},
+		"enforce pod security": {
+			builder: Object(objmeta.Name("team-a")).EnforcePodSecurity(LevelRestricted),
+			expected: coreV1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "team-a", Labels: map[string]string{
+				"pod-security.kubernetes.io/enforce": "restricted",
+				"pod-security.kubernetes.io/audit":   "restricted",
+				"pod-security.kubernetes.io/warn":    "restricted",
+			}}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ns, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(ns, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", ns, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Package naming validates object names against the rules of their kind and
+// generates deterministic names for objects derived from a parent object
+package naming
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/validation/path"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// MaxLength is the length of generated names, the longest DNS-1123 label
+const MaxLength = validation.DNS1123LabelMaxLength
+
+// hashLength is the length of the hash added to generated names
+const hashLength = 8
+
+// Rule is a set of constraints on object names
+type Rule int
+
+const (
+	// DNSSubdomain names, at most 253 characters, are used by most kinds
+	DNSSubdomain Rule = iota
+	// DNSLabel names, at most 63 characters, are used by namespaces
+	DNSLabel
+	// DNS1035Label names are DNS labels starting with a letter, used by services
+	DNS1035Label
+	// PathSegment names can be any string usable in a URL path, used by RBAC kinds
+	PathSegment
+)
+
+// maxLengths lists the kinds whose names are shorter than required by their rule,
+// cron job names are at most 52 characters as the created jobs add a suffix
+var maxLengths = map[string]int{
+	"CronJob": 52,
+}
+
+// RuleFor returns the naming rule of kind
+func RuleFor(kind string) Rule {
+	switch kind {
+	case "Service":
+		return DNS1035Label
+	case "Namespace":
+		return DNSLabel
+	case "Role", "ClusterRole", "RoleBinding", "ClusterRoleBinding":
+		return PathSegment
+	}
+	return DNSSubdomain
+}
+
+// Validate returns an error when name is not a valid name for kind.
+// Empty names are valid as they may be generated by the API server (see generateName).
+func Validate(kind, name string) error {
+	if name == "" {
+		return nil
+	}
+	var errs []string
+	switch RuleFor(kind) {
+	case DNS1035Label:
+		errs = validation.IsDNS1035Label(name)
+	case DNSLabel:
+		errs = validation.IsDNS1123Label(name)
+	case PathSegment:
+		errs = path.IsValidPathSegmentName(name)
+	default:
+		errs = validation.IsDNS1123Subdomain(name)
+	}
+	if max, ok := maxLengths[kind]; ok && len(name) > max {
+		errs = append(errs, validation.MaxLenError(max))
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("naming: invalid %s name %q: %s", kind, name, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Name returns the name of an object derived from parent, as parent-suffix-<hash>.
+// The hash is computed from the parent and suffix, so the same name is always
+// generated, and parent-suffix is truncated to keep the name within MaxLength.
+// Dots are replaced by dashes, so the name is a DNS-1123 label.
+func Name(parent, suffix string) string {
+	h := fnv.New32a()
+	h.Write([]byte(parent + "/" + suffix))
+	hash := fmt.Sprintf("%0*x", hashLength, h.Sum32())
+
+	base := parent
+	if suffix != "" {
+		base = parent + "-" + suffix
+	}
+	if max := MaxLength - hashLength - 1; len(base) > max {
+		base = base[:max]
+	}
+	base = strings.Trim(strings.ReplaceAll(base, ".", "-"), "-")
+	if base == "" {
+		return hash
+	}
+	return base + "-" + hash
+}
@@ -0,0 +1,82 @@
+package naming
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		kind  string
+		name  string
+		valid bool
+	}{
+		"empty name":              {kind: "Deployment", name: "", valid: true},
+		"subdomain":               {kind: "Deployment", name: "web.shop-1", valid: true},
+		"subdomain uppercase":     {kind: "Deployment", name: "My_Deployment"},
+		"subdomain too long":      {kind: "ConfigMap", name: strings.Repeat("a", 254)},
+		"service":                 {kind: "Service", name: "web", valid: true},
+		"service starts with num": {kind: "Service", name: "1-web"},
+		"service with dot":        {kind: "Service", name: "web.shop"},
+		"namespace":               {kind: "Namespace", name: "1-shop", valid: true},
+		"namespace with dot":      {kind: "Namespace", name: "shop.prod"},
+		"role with colon":         {kind: "ClusterRole", name: "system:controller:web", valid: true},
+		"role with slash":         {kind: "Role", name: "pods/reader"},
+		"cronjob":                 {kind: "CronJob", name: strings.Repeat("a", 52), valid: true},
+		"cronjob too long":        {kind: "CronJob", name: strings.Repeat("a", 53)},
+		"unknown kind":            {kind: "Widget", name: "my-widget", valid: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := Validate(test.kind, test.name)
+			if test.valid && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if !test.valid && err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
+
+func TestName(t *testing.T) {
+	long := strings.Repeat("a", 70)
+	tests := map[string]struct {
+		parent string
+		suffix string
+		prefix string
+	}{
+		"short":             {parent: "web", suffix: "svc", prefix: "web-svc-"},
+		"no suffix":         {parent: "web", prefix: "web-"},
+		"no parent":         {suffix: "pdb", prefix: "pdb-"},
+		"dotted parent":     {parent: "web.shop", suffix: "svc", prefix: "web-shop-svc-"},
+		"truncated":         {parent: long, suffix: "pdb", prefix: long[:54] + "-"},
+		"truncated at dash": {parent: strings.Repeat("a", 53), suffix: "hpa", prefix: strings.Repeat("a", 53) + "-"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			n := Name(test.parent, test.suffix)
+			if !strings.HasPrefix(n, test.prefix) || len(n) != len(test.prefix)+hashLength {
+				t.Errorf("unexpected name %q, expecting prefix %q and a %d characters hash", n, test.prefix, hashLength)
+			}
+			if len(n) > MaxLength {
+				t.Errorf("name %q longer than %d", n, MaxLength)
+			}
+			if err := Validate("Service", n); err != nil {
+				t.Error(err)
+			}
+			if again := Name(test.parent, test.suffix); again != n {
+				t.Errorf("name not deterministic: %q and %q", n, again)
+			}
+		})
+	}
+
+	if Name(long+"x", "pdb") == Name(long+"y", "pdb") {
+		t.Error("truncated names of different parents must differ")
+	}
+	if Name("web", "svc") == Name("web-svc", "") {
+		t.Error("names of different parents and suffixes must differ")
+	}
+}
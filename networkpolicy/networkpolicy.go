@@ -0,0 +1,122 @@
+// Package networkpolicy contains builder types to build values of type networkingV1.NetworkPolicy
+package networkpolicy
+
+import (
+	"github.com/vladimirvivien/kob/deployment"
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	"github.com/vladimirvivien/kob/selector"
+	networkingV1 "k8s.io/api/networking/v1"
+)
+
+// Builder provides a way to build values of type networkingV1.NetworkPolicy
+type Builder map[string]interface{}
+
+// Object starts a new NetworkPolicy builder using the provided object metadata.
+// The policy selects all pods in its namespace until PodSelector is set.
+func Object(metadata objmeta.Builder) Builder {
+	meta, err := metadata.U()
+	return Builder(unstruct.SetErr(map[string]interface{}{
+		"metadata": meta,
+		"spec":     map[string]interface{}{"podSelector": map[string]interface{}{}},
+	}, err))
+}
+
+// U returns the unstructured value of the builder
+func (b Builder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b Builder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b Builder) T() (networkingV1.NetworkPolicy, error) {
+	var pol networkingV1.NetworkPolicy
+	if err := unstruct.FromUnstructured(b, &pol); err != nil {
+		return networkingV1.NetworkPolicy{}, err
+	}
+	if err := naming.Validate("NetworkPolicy", pol.Name); err != nil {
+		return networkingV1.NetworkPolicy{}, err
+	}
+	return pol, nil
+}
+
+// PodSelector sets the labels of the pods the policy applies to
+// (empty labels select all pods in the namespace)
+func (b Builder) PodSelector(labels map[string]string) Builder {
+	return b.setSpec("podSelector", matchLabels(labels))
+}
+
+// PodLabelSelector sets the selector of the pods the policy applies to,
+// supporting label expressions
+func (b Builder) PodLabelSelector(sel selector.Builder) Builder {
+	return b.setSpec("podSelector", unstruct.Nest(sel))
+}
+
+// PodSelectorFor sets the policy to apply to the pods managed by the deployment,
+// an invalid deployment or an empty selector is recorded as an error returned by T
+func (b Builder) PodSelectorFor(dep deployment.Builder) Builder {
+	sel, _, err := podSelector(dep)
+	if err != nil {
+		return Builder(unstruct.SetErr(b, err))
+	}
+	return b.PodLabelSelector(sel)
+}
+
+// PolicyTypes sets the types of rules the policy applies to (Ingress, Egress)
+func (b Builder) PolicyTypes(types ...networkingV1.PolicyType) Builder {
+	var slice []interface{}
+	for _, t := range types {
+		slice = append(slice, t)
+	}
+	return b.setSpec("policyTypes", slice)
+}
+
+// Ingress sets the rules for traffic allowed into the selected pods
+func (b Builder) Ingress(rules ...IngressRuleBuilder) Builder {
+	slice := []interface{}{}
+	for _, r := range rules {
+		slice = append(slice, unstruct.Nest(r))
+	}
+	return b.setSpec("ingress", slice)
+}
+
+// Egress sets the rules for traffic allowed out of the selected pods
+func (b Builder) Egress(rules ...EgressRuleBuilder) Builder {
+	slice := []interface{}{}
+	for _, r := range rules {
+		slice = append(slice, unstruct.Nest(r))
+	}
+	return b.setSpec("egress", slice)
+}
+
+func (b Builder) setSpec(key string, val interface{}) Builder {
+	spec := b.getPolicySpec()
+	spec[key] = val
+	b["spec"] = spec
+	return b
+}
+
+func (b Builder) getPolicySpec() map[string]interface{} {
+	specIface, ok := b["spec"]
+	if !ok {
+		specIface = map[string]interface{}{}
+	}
+	return specIface.(map[string]interface{})
+}
+
+// matchLabels returns an unstructured label selector matching the labels
+func matchLabels(labels map[string]string) map[string]interface{} {
+	if len(labels) == 0 {
+		return map[string]interface{}{}
+	}
+	match := map[string]interface{}{}
+	for k, v := range labels {
+		match[k] = v
+	}
+	return map[string]interface{}{"matchLabels": match}
+}
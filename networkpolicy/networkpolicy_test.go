@@ -0,0 +1,172 @@
+package networkpolicy
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/container"
+	"github.com/vladimirvivien/kob/deployment"
+	"github.com/vladimirvivien/kob/objmeta"
+	"github.com/vladimirvivien/kob/selector"
+	coreV1 "k8s.io/api/core/v1"
+	networkingV1 "k8s.io/api/networking/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestNetworkPolicyUnstructured(t *testing.T) {
+	tests := map[string]struct {
+		builder  Builder
+		expected map[string]interface{}
+	}{
+		"empty": {
+			builder:  Builder{},
+			expected: map[string]interface{}{},
+		},
+		"object meta only": {
+			builder: Object(objmeta.Name("simple-pol").Namespace("default")),
+			expected: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "simple-pol", "namespace": "default"},
+				"spec":     map[string]interface{}{"podSelector": map[string]interface{}{}},
+			},
+		},
+		"with pod selector": {
+			builder: Object(objmeta.Name("simple-pol")).PodSelector(map[string]string{"app": "db"}),
+			expected: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "simple-pol"},
+				"spec":     map[string]interface{}{"podSelector": map[string]interface{}{"matchLabels": map[string]interface{}{"app": "db"}}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pol := test.builder
+			if !reflect.DeepEqual(pol.U(), test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", pol.U(), test.expected)
+			}
+		})
+	}
+}
+
+func TestNetworkPolicyTyped(t *testing.T) {
+	tcp := coreV1.ProtocolTCP
+	port := intstr.FromInt(5432)
+	web := deployment.Object(objmeta.Name("web").Namespace("frontend")).
+		PodSpecWithMetadata(objmeta.Name("web").Labels(map[string]string{"app": "web"}), container.Name("web"))
+	tests := map[string]struct {
+		builder  Builder
+		expected networkingV1.NetworkPolicy
+	}{
+		"empty": {
+			builder:  Builder{},
+			expected: networkingV1.NetworkPolicy{},
+		},
+		"deny all": {
+			builder: DenyAll("default"),
+			expected: networkingV1.NetworkPolicy{
+				ObjectMeta: metaV1.ObjectMeta{Name: "default-deny-all", Namespace: "default"},
+				Spec: networkingV1.NetworkPolicySpec{
+					PolicyTypes: []networkingV1.PolicyType{networkingV1.PolicyTypeIngress, networkingV1.PolicyTypeEgress},
+				},
+			},
+		},
+		"pod label selector": {
+			builder: Object(objmeta.Name("web")).PodLabelSelector(selector.DoesNotExist("legacy")),
+			expected: networkingV1.NetworkPolicy{
+				ObjectMeta: metaV1.ObjectMeta{Name: "web"},
+				Spec: networkingV1.NetworkPolicySpec{
+					PodSelector: metaV1.LabelSelector{MatchExpressions: []metaV1.LabelSelectorRequirement{{Key: "legacy", Operator: metaV1.LabelSelectorOpDoesNotExist}}},
+				},
+			},
+		},
+		"allow from deployment": {
+			builder: Object(objmeta.Name("db-ingress").Namespace("backend")).PodSelector(map[string]string{"app": "db"}).
+				PolicyTypes(networkingV1.PolicyTypeIngress).
+				Ingress(AllowFrom(web).Ports(Port(coreV1.ProtocolTCP, 5432))),
+			expected: networkingV1.NetworkPolicy{
+				ObjectMeta: metaV1.ObjectMeta{Name: "db-ingress", Namespace: "backend"},
+				Spec: networkingV1.NetworkPolicySpec{
+					PodSelector: metaV1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+					PolicyTypes: []networkingV1.PolicyType{networkingV1.PolicyTypeIngress},
+					Ingress: []networkingV1.NetworkPolicyIngressRule{{
+						From: []networkingV1.NetworkPolicyPeer{{
+							PodSelector:       &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+							NamespaceSelector: &metaV1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "frontend"}},
+						}},
+						Ports: []networkingV1.NetworkPolicyPort{{Protocol: &tcp, Port: &port}},
+					}},
+				},
+			},
+		},
+		"selector expressions of deployment": {
+			builder: Object(objmeta.Name("web-egress")).
+				PodSelectorFor(deployment.Object(objmeta.Name("web")).LabelSelector(selector.In("app", "web", "api"))).
+				Ingress(AllowFrom(deployment.Object(objmeta.Name("lb")).LabelSelector(selector.Exists("lb")))),
+			expected: networkingV1.NetworkPolicy{
+				ObjectMeta: metaV1.ObjectMeta{Name: "web-egress"},
+				Spec: networkingV1.NetworkPolicySpec{
+					PodSelector: metaV1.LabelSelector{MatchExpressions: []metaV1.LabelSelectorRequirement{
+						{Key: "app", Operator: metaV1.LabelSelectorOpIn, Values: []string{"web", "api"}},
+					}},
+					Ingress: []networkingV1.NetworkPolicyIngressRule{{
+						From: []networkingV1.NetworkPolicyPeer{{
+							PodSelector: &metaV1.LabelSelector{MatchExpressions: []metaV1.LabelSelectorRequirement{
+								{Key: "lb", Operator: metaV1.LabelSelectorOpExists},
+							}},
+						}},
+					}},
+				},
+			},
+		},
+		"pod selector for deployment": {
+			builder: Object(objmeta.Name("web-egress")).PodSelectorFor(web).PolicyTypes(networkingV1.PolicyTypeEgress).Egress(AllowDNSEgress()),
+			expected: networkingV1.NetworkPolicy{
+				ObjectMeta: metaV1.ObjectMeta{Name: "web-egress"},
+				Spec: networkingV1.NetworkPolicySpec{
+					PodSelector: metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+					PolicyTypes: []networkingV1.PolicyType{networkingV1.PolicyTypeEgress},
+					Egress:      []networkingV1.NetworkPolicyEgressRule{mustEgress(t, AllowDNSEgress())},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pol, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(pol, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", pol, test.expected)
+			}
+		})
+	}
+}
+
+func TestNetworkPolicyInvalidDeployment(t *testing.T) {
+	invalid := deployment.Object(objmeta.Name("My_App")).Selector(map[string]string{"app": "web"})
+	tests := map[string]Builder{
+		"pod selector for": Object(objmeta.Name("web")).PodSelectorFor(invalid),
+		"allow from":       Object(objmeta.Name("web")).Ingress(AllowFrom(invalid)),
+		"allow to":         Object(objmeta.Name("web")).Egress(AllowTo(invalid)),
+		"empty selector":   Object(objmeta.Name("web")).Ingress(AllowFrom(deployment.Object(objmeta.Name("web")))),
+	}
+
+	for name, builder := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := builder.T(); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
+
+func mustEgress(t *testing.T, b EgressRuleBuilder) networkingV1.NetworkPolicyEgressRule {
+	rule, err := b.T()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rule
+}
@@ -0,0 +1,112 @@
+package networkpolicy
+
+import (
+	"fmt"
+
+	"github.com/vladimirvivien/kob/deployment"
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/internal/workload"
+	"github.com/vladimirvivien/kob/selector"
+	networkingV1 "k8s.io/api/networking/v1"
+)
+
+// PeerBuilder provides a way to build values of type networkingV1.NetworkPolicyPeer
+type PeerBuilder map[string]interface{}
+
+// PodSelector starts a peer matching pods with the labels in the policy's namespace
+func PodSelector(labels map[string]string) PeerBuilder {
+	return PeerBuilder{}.PodSelector(labels)
+}
+
+// NamespaceSelector starts a peer matching all pods in namespaces with the labels
+func NamespaceSelector(labels map[string]string) PeerBuilder {
+	return PeerBuilder{}.NamespaceSelector(labels)
+}
+
+// Namespace starts a peer matching all pods in the named namespace
+func Namespace(name string) PeerBuilder {
+	return NamespaceSelector(map[string]string{"kubernetes.io/metadata.name": name})
+}
+
+// IPBlock starts a peer matching the CIDR range minus the except ranges
+func IPBlock(cidr string, except ...string) PeerBuilder {
+	block := map[string]interface{}{"cidr": cidr}
+	if len(except) > 0 {
+		var slice []interface{}
+		for _, e := range except {
+			slice = append(slice, e)
+		}
+		block["except"] = slice
+	}
+	return PeerBuilder{"ipBlock": block}
+}
+
+// PodsOf starts a peer matching the pods managed by the deployment. The pod
+// selector, including its expressions, is copied from the deployment (see
+// deployment.PodSelector) and, when the deployment has a namespace, the peer is
+// restricted to it. An invalid deployment or an empty selector is recorded as an
+// error of the peer, rather than an empty selector that would match every pod.
+func PodsOf(dep deployment.Builder) PeerBuilder {
+	sel, ns, err := podSelector(dep)
+	if err != nil {
+		return PeerBuilder(unstruct.SetErr(map[string]interface{}{}, err))
+	}
+	peer := PeerBuilder{}.PodLabelSelector(sel)
+	if ns != "" {
+		peer = peer.NamespaceSelector(map[string]string{"kubernetes.io/metadata.name": ns})
+	}
+	return peer
+}
+
+// U returns the unstructured value of the builder
+func (b PeerBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b PeerBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b PeerBuilder) T() (networkingV1.NetworkPolicyPeer, error) {
+	var peer networkingV1.NetworkPolicyPeer
+	if err := unstruct.FromUnstructured(b, &peer); err != nil {
+		return networkingV1.NetworkPolicyPeer{}, err
+	}
+	return peer, nil
+}
+
+// PodSelector restricts the peer to pods with the labels
+func (b PeerBuilder) PodSelector(labels map[string]string) PeerBuilder {
+	b["podSelector"] = matchLabels(labels)
+	return b
+}
+
+// PodLabelSelector restricts the peer to pods matched by the selector
+func (b PeerBuilder) PodLabelSelector(sel selector.Builder) PeerBuilder {
+	b["podSelector"] = unstruct.Nest(sel)
+	return b
+}
+
+// NamespaceSelector restricts the peer to namespaces with the labels
+func (b PeerBuilder) NamespaceSelector(labels map[string]string) PeerBuilder {
+	b["namespaceSelector"] = matchLabels(labels)
+	return b
+}
+
+// NamespaceLabelSelector restricts the peer to namespaces matched by the selector
+func (b PeerBuilder) NamespaceLabelSelector(sel selector.Builder) PeerBuilder {
+	b["namespaceSelector"] = unstruct.Nest(sel)
+	return b
+}
+
+// podSelector returns the selector of the pods of the deployment
+// along with the deployment's namespace
+func podSelector(dep deployment.Builder) (selector.Builder, string, error) {
+	sel, err := dep.PodSelector()
+	if err != nil {
+		return nil, "", fmt.Errorf("networkpolicy: pods of deployment: %w", err)
+	}
+	return sel, workload.Namespace(dep), nil
+}
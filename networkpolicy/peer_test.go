@@ -0,0 +1,66 @@
+package networkpolicy
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/deployment"
+	"github.com/vladimirvivien/kob/objmeta"
+	"github.com/vladimirvivien/kob/selector"
+	networkingV1 "k8s.io/api/networking/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPeerStructured(t *testing.T) {
+	tests := map[string]struct {
+		builder  PeerBuilder
+		expected networkingV1.NetworkPolicyPeer
+	}{
+		"empty": {
+			builder:  PeerBuilder{},
+			expected: networkingV1.NetworkPolicyPeer{},
+		},
+		"pod selector": {
+			builder:  PodSelector(map[string]string{"app": "web"}),
+			expected: networkingV1.NetworkPolicyPeer{PodSelector: &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+		},
+		"all namespaces": {
+			builder:  NamespaceSelector(nil),
+			expected: networkingV1.NetworkPolicyPeer{NamespaceSelector: &metaV1.LabelSelector{}},
+		},
+		"namespace and pods": {
+			builder: Namespace("monitoring").PodSelector(map[string]string{"app": "prometheus"}),
+			expected: networkingV1.NetworkPolicyPeer{
+				NamespaceSelector: &metaV1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "monitoring"}},
+				PodSelector:       &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "prometheus"}},
+			},
+		},
+		"label selectors": {
+			builder: PeerBuilder{}.NamespaceLabelSelector(selector.In("env", "prod", "staging")).PodLabelSelector(selector.Exists("app")),
+			expected: networkingV1.NetworkPolicyPeer{
+				NamespaceSelector: &metaV1.LabelSelector{MatchExpressions: []metaV1.LabelSelectorRequirement{{Key: "env", Operator: metaV1.LabelSelectorOpIn, Values: []string{"prod", "staging"}}}},
+				PodSelector:       &metaV1.LabelSelector{MatchExpressions: []metaV1.LabelSelectorRequirement{{Key: "app", Operator: metaV1.LabelSelectorOpExists}}},
+			},
+		},
+		"ip block with except": {
+			builder:  IPBlock("10.0.0.0/8", "10.1.0.0/16", "10.2.0.0/16"),
+			expected: networkingV1.NetworkPolicyPeer{IPBlock: &networkingV1.IPBlock{CIDR: "10.0.0.0/8", Except: []string{"10.1.0.0/16", "10.2.0.0/16"}}},
+		},
+		"pods of deployment without namespace": {
+			builder:  PodsOf(deployment.Object(objmeta.Name("web")).PodSpecWithMetadata(objmeta.Name("web").Labels(map[string]string{"app": "web"}))),
+			expected: networkingV1.NetworkPolicyPeer{PodSelector: &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			peer, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(peer, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", peer, test.expected)
+			}
+		})
+	}
+}
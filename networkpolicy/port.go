@@ -0,0 +1,45 @@
+package networkpolicy
+
+import (
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	coreV1 "k8s.io/api/core/v1"
+	networkingV1 "k8s.io/api/networking/v1"
+)
+
+// PortBuilder provides a way to build values of type networkingV1.NetworkPolicyPort
+type PortBuilder map[string]interface{}
+
+// Port starts a new port builder with the protocol and port number
+func Port(proto coreV1.Protocol, port int32) PortBuilder {
+	return PortBuilder{"protocol": proto, "port": int64(port)}
+}
+
+// PortName starts a new port builder with the protocol and named port
+func PortName(proto coreV1.Protocol, name string) PortBuilder {
+	return PortBuilder{"protocol": proto, "port": name}
+}
+
+// U returns the unstructured value of the builder
+func (b PortBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b PortBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b PortBuilder) T() (networkingV1.NetworkPolicyPort, error) {
+	var port networkingV1.NetworkPolicyPort
+	if err := unstruct.FromUnstructured(b, &port); err != nil {
+		return networkingV1.NetworkPolicyPort{}, err
+	}
+	return port, nil
+}
+
+// EndPort turns the port into a range from the port number to end (inclusive)
+func (b PortBuilder) EndPort(end int32) PortBuilder {
+	b["endPort"] = int64(end)
+	return b
+}
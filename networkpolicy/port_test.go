@@ -0,0 +1,49 @@
+package networkpolicy
+
+import (
+	"reflect"
+	"testing"
+
+	coreV1 "k8s.io/api/core/v1"
+	networkingV1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestPortStructured(t *testing.T) {
+	tcp, udp := coreV1.ProtocolTCP, coreV1.ProtocolUDP
+	num, name := intstr.FromInt(32000), intstr.FromString("metrics")
+	end := int32(32768)
+	tests := map[string]struct {
+		builder  PortBuilder
+		expected networkingV1.NetworkPolicyPort
+	}{
+		"empty": {
+			builder:  PortBuilder{},
+			expected: networkingV1.NetworkPolicyPort{},
+		},
+		"port number": {
+			builder:  Port(coreV1.ProtocolTCP, 32000),
+			expected: networkingV1.NetworkPolicyPort{Protocol: &tcp, Port: &num},
+		},
+		"port name": {
+			builder:  PortName(coreV1.ProtocolUDP, "metrics"),
+			expected: networkingV1.NetworkPolicyPort{Protocol: &udp, Port: &name},
+		},
+		"port range": {
+			builder:  Port(coreV1.ProtocolTCP, 32000).EndPort(32768),
+			expected: networkingV1.NetworkPolicyPort{Protocol: &tcp, Port: &num, EndPort: &end},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			port, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(port, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", port, test.expected)
+			}
+		})
+	}
+}
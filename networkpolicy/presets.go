@@ -0,0 +1,34 @@
+package networkpolicy
+
+import (
+	"github.com/vladimirvivien/kob/deployment"
+	"github.com/vladimirvivien/kob/objmeta"
+	coreV1 "k8s.io/api/core/v1"
+	networkingV1 "k8s.io/api/networking/v1"
+)
+
+// DenyAll creates a default-deny policy, named default-deny-all, that
+// blocks all ingress and egress traffic for every pod in the namespace
+func DenyAll(ns string) Builder {
+	return Object(objmeta.Name("default-deny-all").Namespace(ns)).
+		PolicyTypes(networkingV1.PolicyTypeIngress, networkingV1.PolicyTypeEgress)
+}
+
+// AllowDNSEgress creates an egress rule that allows DNS lookups (port 53 over
+// UDP and TCP) against the cluster DNS pods in the kube-system namespace
+func AllowDNSEgress() EgressRuleBuilder {
+	return To(Namespace("kube-system").PodSelector(map[string]string{"k8s-app": "kube-dns"})).
+		Ports(Port(coreV1.ProtocolUDP, 53), Port(coreV1.ProtocolTCP, 53))
+}
+
+// AllowFrom creates an ingress rule that allows traffic from the pods
+// managed by the deployment
+func AllowFrom(dep deployment.Builder) IngressRuleBuilder {
+	return From(PodsOf(dep))
+}
+
+// AllowTo creates an egress rule that allows traffic to the pods
+// managed by the deployment
+func AllowTo(dep deployment.Builder) EgressRuleBuilder {
+	return To(PodsOf(dep))
+}
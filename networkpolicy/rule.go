@@ -0,0 +1,98 @@
+package networkpolicy
+
+import (
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	networkingV1 "k8s.io/api/networking/v1"
+)
+
+// IngressRuleBuilder provides a way to build values of type networkingV1.NetworkPolicyIngressRule
+type IngressRuleBuilder map[string]interface{}
+
+// From starts a new ingress rule allowing traffic from the peers
+// (no peers allows traffic from all sources)
+func From(peers ...PeerBuilder) IngressRuleBuilder {
+	b := IngressRuleBuilder{}
+	if len(peers) > 0 {
+		b["from"] = peerSlice(peers)
+	}
+	return b
+}
+
+// U returns the unstructured value of the builder
+func (b IngressRuleBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b IngressRuleBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b IngressRuleBuilder) T() (networkingV1.NetworkPolicyIngressRule, error) {
+	var rule networkingV1.NetworkPolicyIngressRule
+	if err := unstruct.FromUnstructured(b, &rule); err != nil {
+		return networkingV1.NetworkPolicyIngressRule{}, err
+	}
+	return rule, nil
+}
+
+// Ports restricts the rule to traffic on the ports
+func (b IngressRuleBuilder) Ports(ports ...PortBuilder) IngressRuleBuilder {
+	b["ports"] = portSlice(ports)
+	return b
+}
+
+// EgressRuleBuilder provides a way to build values of type networkingV1.NetworkPolicyEgressRule
+type EgressRuleBuilder map[string]interface{}
+
+// To starts a new egress rule allowing traffic to the peers
+// (no peers allows traffic to all destinations)
+func To(peers ...PeerBuilder) EgressRuleBuilder {
+	b := EgressRuleBuilder{}
+	if len(peers) > 0 {
+		b["to"] = peerSlice(peers)
+	}
+	return b
+}
+
+// U returns the unstructured value of the builder
+func (b EgressRuleBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b EgressRuleBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b EgressRuleBuilder) T() (networkingV1.NetworkPolicyEgressRule, error) {
+	var rule networkingV1.NetworkPolicyEgressRule
+	if err := unstruct.FromUnstructured(b, &rule); err != nil {
+		return networkingV1.NetworkPolicyEgressRule{}, err
+	}
+	return rule, nil
+}
+
+// Ports restricts the rule to traffic on the ports
+func (b EgressRuleBuilder) Ports(ports ...PortBuilder) EgressRuleBuilder {
+	b["ports"] = portSlice(ports)
+	return b
+}
+
+func peerSlice(peers []PeerBuilder) []interface{} {
+	var slice []interface{}
+	for _, p := range peers {
+		slice = append(slice, unstruct.Nest(p))
+	}
+	return slice
+}
+
+func portSlice(ports []PortBuilder) []interface{} {
+	var slice []interface{}
+	for _, p := range ports {
+		slice = append(slice, unstruct.Nest(p))
+	}
+	return slice
+}
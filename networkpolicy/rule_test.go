@@ -0,0 +1,83 @@
+package networkpolicy
+
+import (
+	"reflect"
+	"testing"
+
+	coreV1 "k8s.io/api/core/v1"
+	networkingV1 "k8s.io/api/networking/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestIngressRuleStructured(t *testing.T) {
+	tcp := coreV1.ProtocolTCP
+	port := intstr.FromInt(443)
+	tests := map[string]struct {
+		builder  IngressRuleBuilder
+		expected networkingV1.NetworkPolicyIngressRule
+	}{
+		"from all": {
+			builder:  From(),
+			expected: networkingV1.NetworkPolicyIngressRule{},
+		},
+		"from peers on port": {
+			builder: From(IPBlock("0.0.0.0/0"), PodSelector(map[string]string{"app": "lb"})).Ports(Port(coreV1.ProtocolTCP, 443)),
+			expected: networkingV1.NetworkPolicyIngressRule{
+				From: []networkingV1.NetworkPolicyPeer{
+					{IPBlock: &networkingV1.IPBlock{CIDR: "0.0.0.0/0"}},
+					{PodSelector: &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "lb"}}},
+				},
+				Ports: []networkingV1.NetworkPolicyPort{{Protocol: &tcp, Port: &port}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rule, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(rule, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", rule, test.expected)
+			}
+		})
+	}
+}
+
+func TestEgressRuleStructured(t *testing.T) {
+	udp, tcp := coreV1.ProtocolUDP, coreV1.ProtocolTCP
+	dns := intstr.FromInt(53)
+	tests := map[string]struct {
+		builder  EgressRuleBuilder
+		expected networkingV1.NetworkPolicyEgressRule
+	}{
+		"to all": {
+			builder:  To(),
+			expected: networkingV1.NetworkPolicyEgressRule{},
+		},
+		"allow dns egress": {
+			builder: AllowDNSEgress(),
+			expected: networkingV1.NetworkPolicyEgressRule{
+				To: []networkingV1.NetworkPolicyPeer{{
+					NamespaceSelector: &metaV1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "kube-system"}},
+					PodSelector:       &metaV1.LabelSelector{MatchLabels: map[string]string{"k8s-app": "kube-dns"}},
+				}},
+				Ports: []networkingV1.NetworkPolicyPort{{Protocol: &udp, Port: &dns}, {Protocol: &tcp, Port: &dns}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rule, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(rule, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", rule, test.expected)
+			}
+		})
+	}
+}
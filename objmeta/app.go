@@ -0,0 +1,66 @@
+package objmeta
+
+import "strings"
+
+// Recommended labels shared by the objects of an application, see
+// https://kubernetes.io/docs/concepts/overview/working-with-objects/common-labels/
+const (
+	AppLabelPrefix = "app.kubernetes.io/"
+	LabelName      = AppLabelPrefix + "name"
+	LabelInstance  = AppLabelPrefix + "instance"
+	LabelVersion   = AppLabelPrefix + "version"
+	LabelComponent = AppLabelPrefix + "component"
+	LabelPartOf    = AppLabelPrefix + "part-of"
+	LabelManagedBy = AppLabelPrefix + "managed-by"
+)
+
+// StableAppLabels are the recommended labels that identify an application for
+// its whole life, unlike the version and managed-by labels. They are the labels
+// inherited by default by workload pod templates and selectors, which are
+// immutable for most workloads.
+var StableAppLabels = []string{LabelName, LabelInstance, LabelComponent, LabelPartOf}
+
+// App adds the app.kubernetes.io recommended labels, empty values are skipped
+func (b Builder) App(name, instance, version, component, partOf, managedBy string) Builder {
+	labels := map[string]string{}
+	for key, val := range map[string]string{
+		LabelName:      name,
+		LabelInstance:  instance,
+		LabelVersion:   version,
+		LabelComponent: component,
+		LabelPartOf:    partOf,
+		LabelManagedBy: managedBy,
+	} {
+		if val != "" {
+			labels[key] = val
+		}
+	}
+	return b.AddLabels(labels)
+}
+
+// AppLabels returns the app.kubernetes.io recommended labels found in labels
+func AppLabels(labels map[string]string) map[string]string {
+	result := map[string]string{}
+	for key, val := range labels {
+		if strings.HasPrefix(key, AppLabelPrefix) {
+			result[key] = val
+		}
+	}
+	return result
+}
+
+// SelectorLabels returns a copy of labels without the recommended labels,
+// such as the version, that are not in StableAppLabels
+func SelectorLabels(labels map[string]string) map[string]string {
+	stable := map[string]bool{}
+	for _, key := range StableAppLabels {
+		stable[key] = true
+	}
+	result := make(map[string]string, len(labels))
+	for key, val := range labels {
+		if !strings.HasPrefix(key, AppLabelPrefix) || stable[key] {
+			result[key] = val
+		}
+	}
+	return result
+}
@@ -0,0 +1,70 @@
+package objmeta
+
+import (
+	"reflect"
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestObjectMetaApp(t *testing.T) {
+	tests := map[string]struct {
+		builder  Builder
+		expected metaV1.ObjectMeta
+	}{
+		"all labels": {
+			builder: Name("shop-web").App("web", "shop-web", "1.2.0", "frontend", "shop", "kob"),
+			expected: metaV1.ObjectMeta{Name: "shop-web", Labels: map[string]string{
+				LabelName:      "web",
+				LabelInstance:  "shop-web",
+				LabelVersion:   "1.2.0",
+				LabelComponent: "frontend",
+				LabelPartOf:    "shop",
+				LabelManagedBy: "kob",
+			}},
+		},
+		"empty values skipped": {
+			builder:  Name("shop-web").App("web", "", "", "", "", ""),
+			expected: metaV1.ObjectMeta{Name: "shop-web", Labels: map[string]string{LabelName: "web"}},
+		},
+		"merged with labels": {
+			builder:  Name("shop-web").Labels(map[string]string{"tier": "web"}).App("web", "shop-web", "", "", "", ""),
+			expected: metaV1.ObjectMeta{Name: "shop-web", Labels: map[string]string{"tier": "web", LabelName: "web", LabelInstance: "shop-web"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := test.builder.Err(); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			objMeta := test.builder.T()
+			if !reflect.DeepEqual(objMeta, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", objMeta, test.expected)
+			}
+		})
+	}
+
+	if err := Name("shop-web").App("web", "", "1.2.0+build", "", "", "").Err(); err == nil {
+		t.Error("expecting error, got none")
+	}
+}
+
+func TestAppAndSelectorLabels(t *testing.T) {
+	labels := map[string]string{"tier": "web", LabelName: "web", LabelVersion: "1.2.0", LabelManagedBy: "kob"}
+
+	app := AppLabels(labels)
+	expectedApp := map[string]string{LabelName: "web", LabelVersion: "1.2.0", LabelManagedBy: "kob"}
+	if !reflect.DeepEqual(app, expectedApp) {
+		t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", app, expectedApp)
+	}
+
+	selector := SelectorLabels(labels)
+	expectedSelector := map[string]string{"tier": "web", LabelName: "web"}
+	if !reflect.DeepEqual(selector, expectedSelector) {
+		t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", selector, expectedSelector)
+	}
+	if len(labels) != 4 {
+		t.Errorf("labels modified: %#v", labels)
+	}
+}
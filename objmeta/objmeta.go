@@ -2,10 +2,15 @@
 package objmeta
 
 import (
+	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/yaml"
 )
 
@@ -16,13 +21,31 @@ var (
 	ObjectMetaNone   = metaV1.ObjectMeta{}
 )
 
-// Builder provides a way to build values of type coreV1.ObjectMeta
+// ConflictPolicy determines how merged labels or annotations are applied
+// when a key is already present with a different value
+type ConflictPolicy int
+
+const (
+	// Overwrite replaces the existing value
+	Overwrite ConflictPolicy = iota
+	// KeepExisting keeps the existing value
+	KeepExisting
+	// FailOnConflict records an error on the builder
+	FailOnConflict
+)
+
+// Builder provides a way to build values of type coreV1.ObjectMeta.
+// The first invalid label, annotation, finalizer or owner is recorded and
+// returned by U and Err.
 type Builder struct {
 	obj metaV1.ObjectMeta
+	err error
 }
 
-// From creates a new builder using the provided metaV1.ObjectMeta as its base
+// From creates a new builder using the provided metaV1.ObjectMeta as its base,
+// managed fields are dropped as they are maintained by the API server
 func From(obj metaV1.ObjectMeta) Builder {
+	obj.ManagedFields = nil
 	return Builder{obj: obj}
 }
 
@@ -33,7 +56,7 @@ func FromUnstructured(unstruct map[string]any) (Builder, error) {
 	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstruct, &obj); err != nil {
 		return Builder{}, err
 	}
-	return Builder{obj: obj}, nil
+	return From(obj), nil
 }
 
 // FromString attempts to convert the provided YAML or JSON string fragment
@@ -43,7 +66,7 @@ func FromString(str string) (Builder, error) {
 	if err := yaml.NewYAMLOrJSONDecoder(strings.NewReader(str), 1024).Decode(&obj); err != nil {
 		return Builder{}, err
 	}
-	return Builder{obj: obj}, nil
+	return From(obj), nil
 }
 
 // Name starts a new builer by setting the Objectmeta name of the object
@@ -51,13 +74,15 @@ func Name(name string) Builder {
 	return Builder{obj: metaV1.ObjectMeta{Name: name}}
 }
 
-// U converts the value of the builder to an unstructured map[string]any value
+// U converts the value of the builder to an unstructured map[string]any value.
+// The metadata is returned along with the error recorded by the builder, which
+// map builders record in turn (see Object constructors).
 func (b Builder) U() (map[string]any, error) {
-	unstruct, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&b.obj)
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&b.obj)
 	if err != nil {
 		return nil, err
 	}
-	return unstruct, nil
+	return unstruct.Prune(u), b.err
 }
 
 // T returns the typed value of the builder of metaV1.ObjectMeta
@@ -71,14 +96,151 @@ func (b Builder) Namespace(ns string) Builder {
 	return b
 }
 
-// Labels value setter
+// Err returns the first error recorded by the builder
+func (b Builder) Err() error {
+	return b.err
+}
+
+// Validate returns the first error recorded by the builder, or an error
+// when the name is not valid for objects of the kind (see naming.Validate)
+func (b Builder) Validate(kind string) error {
+	if b.err != nil {
+		return b.err
+	}
+	return naming.Validate(kind, b.obj.Name)
+}
+
+// Labels value setter, replaces all existing labels
 func (b Builder) Labels(labels map[string]string) Builder {
+	for _, key := range sortedKeys(labels) {
+		b = b.checkLabel(key, labels[key])
+	}
 	b.obj.Labels = labels
 	return b
 }
 
-// Annotations setter
+// AddLabel adds a label, replacing the value of an existing key
+func (b Builder) AddLabel(key, val string) Builder {
+	return b.MergeLabels(map[string]string{key: val}, Overwrite)
+}
+
+// AddLabels adds labels, replacing the values of existing keys
+func (b Builder) AddLabels(labels map[string]string) Builder {
+	return b.MergeLabels(labels, Overwrite)
+}
+
+// MergeLabels adds labels, resolving existing keys with the provided policy
+func (b Builder) MergeLabels(labels map[string]string, policy ConflictPolicy) Builder {
+	for _, key := range sortedKeys(labels) {
+		b = b.checkLabel(key, labels[key])
+	}
+	b.obj.Labels, b.err = merge(b.obj.Labels, labels, policy, "label", b.err)
+	return b
+}
+
+// RemoveLabel removes the label with the provided key
+func (b Builder) RemoveLabel(key string) Builder {
+	b.obj.Labels = remove(b.obj.Labels, key)
+	return b
+}
+
+// Annotations setter, replaces all existing annotations
 func (b Builder) Annotations(annotations map[string]string) Builder {
+	for _, key := range sortedKeys(annotations) {
+		b = b.checkKey("annotation", key)
+	}
 	b.obj.Annotations = annotations
 	return b
 }
+
+// AddAnnotation adds an annotation, replacing the value of an existing key
+func (b Builder) AddAnnotation(key, val string) Builder {
+	return b.MergeAnnotations(map[string]string{key: val}, Overwrite)
+}
+
+// AddAnnotations adds annotations, replacing the values of existing keys
+func (b Builder) AddAnnotations(annotations map[string]string) Builder {
+	return b.MergeAnnotations(annotations, Overwrite)
+}
+
+// MergeAnnotations adds annotations, resolving existing keys with the provided policy
+func (b Builder) MergeAnnotations(annotations map[string]string, policy ConflictPolicy) Builder {
+	for _, key := range sortedKeys(annotations) {
+		b = b.checkKey("annotation", key)
+	}
+	b.obj.Annotations, b.err = merge(b.obj.Annotations, annotations, policy, "annotation", b.err)
+	return b
+}
+
+// RemoveAnnotation removes the annotation with the provided key
+func (b Builder) RemoveAnnotation(key string) Builder {
+	b.obj.Annotations = remove(b.obj.Annotations, key)
+	return b
+}
+
+// checkLabel records an error for a key that is not a qualified name
+// or a value that is not a valid label value
+func (b Builder) checkLabel(key, val string) Builder {
+	b = b.checkKey("label", key)
+	if errs := validation.IsValidLabelValue(val); len(errs) > 0 && b.err == nil {
+		b.err = fmt.Errorf("objmeta: label %s: invalid value %q: %s", key, val, strings.Join(errs, "; "))
+	}
+	return b
+}
+
+// checkKey records an error for a key that is not an optional DNS subdomain
+// prefix followed by a name segment of at most 63 characters
+func (b Builder) checkKey(kind, key string) Builder {
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 && b.err == nil {
+		b.err = fmt.Errorf("objmeta: %s: invalid key %q: %s", kind, key, strings.Join(errs, "; "))
+	}
+	return b
+}
+
+// merge returns a copy of dst with the values of src applied,
+// dst is copied so builders sharing the same map are not affected
+func merge(dst, src map[string]string, policy ConflictPolicy, kind string, err error) (map[string]string, error) {
+	result := make(map[string]string, len(dst)+len(src))
+	for key, val := range dst {
+		result[key] = val
+	}
+	for _, key := range sortedKeys(src) {
+		val := src[key]
+		existing, found := result[key]
+		if found && existing != val {
+			switch policy {
+			case KeepExisting:
+				continue
+			case FailOnConflict:
+				if err == nil {
+					err = fmt.Errorf("objmeta: %s %s: conflicting values %q and %q", kind, key, existing, val)
+				}
+				continue
+			}
+		}
+		result[key] = val
+	}
+	return result, err
+}
+
+func remove(src map[string]string, key string) map[string]string {
+	if _, found := src[key]; !found {
+		return src
+	}
+	result := make(map[string]string, len(src))
+	for k, val := range src {
+		if k != key {
+			result[k] = val
+		}
+	}
+	return result
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -2,6 +2,7 @@ package objmeta
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -58,3 +59,102 @@ func TestObjectMeta(t *testing.T) {
 		})
 	}
 }
+
+func TestObjectMetaLabelsAndAnnotations(t *testing.T) {
+	base := Name("simple-name").Labels(map[string]string{"tier": "web", "app": "shop"})
+	tests := map[string]struct {
+		builder  Builder
+		expected metaV1.ObjectMeta
+	}{
+		"add label": {
+			builder:  Name("simple-name").AddLabel("tier", "web"),
+			expected: metaV1.ObjectMeta{Name: "simple-name", Labels: map[string]string{"tier": "web"}},
+		},
+		"add labels overwrite": {
+			builder:  base.AddLabels(map[string]string{"tier": "db", "example.com/team": "a"}),
+			expected: metaV1.ObjectMeta{Name: "simple-name", Labels: map[string]string{"tier": "db", "app": "shop", "example.com/team": "a"}},
+		},
+		"merge labels keep existing": {
+			builder:  base.MergeLabels(map[string]string{"tier": "db", "env": "prod"}, KeepExisting),
+			expected: metaV1.ObjectMeta{Name: "simple-name", Labels: map[string]string{"tier": "web", "app": "shop", "env": "prod"}},
+		},
+		"merge labels same value no conflict": {
+			builder:  base.MergeLabels(map[string]string{"tier": "web"}, FailOnConflict),
+			expected: metaV1.ObjectMeta{Name: "simple-name", Labels: map[string]string{"tier": "web", "app": "shop"}},
+		},
+		"remove label": {
+			builder:  base.RemoveLabel("tier").RemoveLabel("missing"),
+			expected: metaV1.ObjectMeta{Name: "simple-name", Labels: map[string]string{"app": "shop"}},
+		},
+		"empty label value": {
+			builder:  Name("simple-name").AddLabel("tier", ""),
+			expected: metaV1.ObjectMeta{Name: "simple-name", Labels: map[string]string{"tier": ""}},
+		},
+		"add annotations": {
+			builder: Name("simple-name").Annotations(map[string]string{"status": "ready"}).
+				AddAnnotation("example.com/note", "any value, including spaces").
+				MergeAnnotations(map[string]string{"status": "done"}, KeepExisting).
+				AddAnnotations(map[string]string{"owner": "ops"}).RemoveAnnotation("owner"),
+			expected: metaV1.ObjectMeta{Name: "simple-name", Annotations: map[string]string{"status": "ready", "example.com/note": "any value, including spaces"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := test.builder.Err(); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			objMeta := test.builder.T()
+			if !reflect.DeepEqual(objMeta, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", objMeta, test.expected)
+			}
+		})
+	}
+
+	// merging must not modify the labels of the builder it started from
+	if labels := base.T().Labels; len(labels) != 2 || labels["tier"] != "web" {
+		t.Errorf("base builder labels modified: %#v", labels)
+	}
+}
+
+func TestObjectMetaInvalid(t *testing.T) {
+	tests := map[string]Builder{
+		"label key too long":      Name("simple-name").AddLabel(strings.Repeat("a", 64), "web"),
+		"label key bad prefix":    Name("simple-name").AddLabel("Example_com/tier", "web"),
+		"label key empty name":    Name("simple-name").AddLabel("example.com/", "web"),
+		"label value invalid":     Name("simple-name").Labels(map[string]string{"tier": "web tier"}),
+		"label value too long":    Name("simple-name").AddLabel("tier", strings.Repeat("a", 64)),
+		"annotation key invalid":  Name("simple-name").AddAnnotation("not a key", "value"),
+		"annotations key invalid": Name("simple-name").Annotations(map[string]string{"-bad": "value"}),
+		"label conflict":          Name("simple-name").AddLabel("tier", "web").MergeLabels(map[string]string{"tier": "db"}, FailOnConflict),
+		"annotation conflict":     Name("simple-name").AddAnnotation("status", "ready").MergeAnnotations(map[string]string{"status": "done"}, FailOnConflict),
+		"error kept":              Name("simple-name").AddLabel("bad key", "web").AddLabel("tier", "web"),
+	}
+
+	for name, builder := range tests {
+		t.Run(name, func(t *testing.T) {
+			if builder.Err() == nil {
+				t.Fatal("expecting error, got none")
+			}
+			meta, err := builder.U()
+			if err == nil {
+				t.Fatal("expecting error from U, got none")
+			}
+			if meta["name"] != "simple-name" {
+				t.Errorf("metadata not kept with the error: %#v", meta)
+			}
+		})
+	}
+}
+
+func TestObjectMetaValidate(t *testing.T) {
+	if err := Name("web").Validate("Service"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := Name("My_Service").Validate("Service"); err == nil {
+		t.Error("expecting error, got none")
+	}
+	if err := Name("web").AddLabel("bad key", "web").Validate("Service"); err == nil {
+		t.Error("expecting error, got none")
+	}
+}
@@ -0,0 +1,88 @@
+package objmeta
+
+import (
+	"fmt"
+
+	"github.com/vladimirvivien/kob/internal/scheme"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// UID setter, usually only needed to build owners of other objects
+func (b Builder) UID(uid types.UID) Builder {
+	b.obj.UID = uid
+	return b
+}
+
+// GenerateName sets the prefix used by the API server to generate a unique name
+func (b Builder) GenerateName(prefix string) Builder {
+	b.obj.GenerateName = prefix
+	return b
+}
+
+// OwnedBy adds a controller owner reference to owner, which is either a kob
+// builder (any value with a T method) or a typed API object. The owner must
+// have a name and a uid, and an object can only have one controller.
+func (b Builder) OwnedBy(owner any) Builder {
+	if b.err != nil {
+		return b
+	}
+	obj, err := scheme.ObjectOf(owner)
+	if err != nil {
+		b.err = fmt.Errorf("objmeta: owner: %w", err)
+		return b
+	}
+	gvk, err := scheme.GVK(obj)
+	if err != nil {
+		b.err = fmt.Errorf("objmeta: owner: %w", err)
+		return b
+	}
+	if obj.GetName() == "" || obj.GetUID() == "" {
+		b.err = fmt.Errorf("objmeta: owner %s: name and uid are required", gvk.Kind)
+		return b
+	}
+	if ref := metaV1.GetControllerOfNoCopy(&b.obj); ref != nil && ref.UID != obj.GetUID() {
+		b.err = fmt.Errorf("objmeta: owner %s/%s: already controlled by %s/%s", gvk.Kind, obj.GetName(), ref.Kind, ref.Name)
+		return b
+	}
+
+	refs := make([]metaV1.OwnerReference, 0, len(b.obj.OwnerReferences)+1)
+	for _, ref := range b.obj.OwnerReferences {
+		if ref.UID != obj.GetUID() {
+			refs = append(refs, ref)
+		}
+	}
+	b.obj.OwnerReferences = append(refs, *metaV1.NewControllerRef(obj, gvk))
+	return b
+}
+
+// Finalizers setter, replaces all existing finalizers
+func (b Builder) Finalizers(finalizers ...string) Builder {
+	for _, f := range finalizers {
+		b = b.checkFinalizer(f)
+	}
+	b.obj.Finalizers = finalizers
+	return b
+}
+
+// AddFinalizer adds a finalizer when not already present
+func (b Builder) AddFinalizer(finalizer string) Builder {
+	b = b.checkFinalizer(finalizer)
+	for _, f := range b.obj.Finalizers {
+		if f == finalizer {
+			return b
+		}
+	}
+	finalizers := make([]string, len(b.obj.Finalizers), len(b.obj.Finalizers)+1)
+	copy(finalizers, b.obj.Finalizers)
+	b.obj.Finalizers = append(finalizers, finalizer)
+	return b
+}
+
+func (b Builder) checkFinalizer(finalizer string) Builder {
+	if errs := validation.IsQualifiedName(finalizer); len(errs) > 0 && b.err == nil {
+		b.err = fmt.Errorf("objmeta: finalizer: invalid name %q: %v", finalizer, errs)
+	}
+	return b
+}
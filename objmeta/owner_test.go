@@ -0,0 +1,98 @@
+package objmeta
+
+import (
+	"reflect"
+	"testing"
+
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// deploymentBuilder stands in for kob's map builders, which import objmeta
+type deploymentBuilder struct {
+	obj appsV1.Deployment
+}
+
+func (b deploymentBuilder) T() (appsV1.Deployment, error) {
+	return b.obj, nil
+}
+
+func TestObjectMetaOwner(t *testing.T) {
+	depRef := metaV1.OwnerReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "web", UID: "dep-uid", Controller: boolPtr(true), BlockOwnerDeletion: boolPtr(true)}
+	dep := appsV1.Deployment{ObjectMeta: metaV1.ObjectMeta{Name: "web", UID: "dep-uid"}}
+
+	tests := map[string]struct {
+		builder  Builder
+		expected metaV1.ObjectMeta
+	}{
+		"owned by typed object": {
+			builder:  Name("web-1").OwnedBy(&dep),
+			expected: metaV1.ObjectMeta{Name: "web-1", OwnerReferences: []metaV1.OwnerReference{depRef}},
+		},
+		"owned by typed value": {
+			builder:  Name("web-1").OwnedBy(dep),
+			expected: metaV1.ObjectMeta{Name: "web-1", OwnerReferences: []metaV1.OwnerReference{depRef}},
+		},
+		"owned by builder": {
+			builder:  Name("web-1").OwnedBy(deploymentBuilder{obj: dep}),
+			expected: metaV1.ObjectMeta{Name: "web-1", OwnerReferences: []metaV1.OwnerReference{depRef}},
+		},
+		"owned twice by same owner": {
+			builder:  Name("web-1").OwnedBy(&dep).OwnedBy(&dep),
+			expected: metaV1.ObjectMeta{Name: "web-1", OwnerReferences: []metaV1.OwnerReference{depRef}},
+		},
+		"owned by core object": {
+			builder: Name("web-1").OwnedBy(&coreV1.ConfigMap{ObjectMeta: metaV1.ObjectMeta{Name: "cfg", UID: "cfg-uid"}}),
+			expected: metaV1.ObjectMeta{Name: "web-1", OwnerReferences: []metaV1.OwnerReference{
+				{APIVersion: "v1", Kind: "ConfigMap", Name: "cfg", UID: "cfg-uid", Controller: boolPtr(true), BlockOwnerDeletion: boolPtr(true)},
+			}},
+		},
+		"generate name and finalizers": {
+			builder:  Name("").GenerateName("web-").Finalizers("example.com/cleanup").AddFinalizer("kubernetes").AddFinalizer("example.com/cleanup"),
+			expected: metaV1.ObjectMeta{GenerateName: "web-", Finalizers: []string{"example.com/cleanup", "kubernetes"}},
+		},
+		"from drops managed fields": {
+			builder:  From(metaV1.ObjectMeta{Name: "web", ManagedFields: []metaV1.ManagedFieldsEntry{{Manager: "kubectl"}}}),
+			expected: metaV1.ObjectMeta{Name: "web"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := test.builder.Err(); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			objMeta := test.builder.T()
+			if !reflect.DeepEqual(objMeta, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", objMeta, test.expected)
+			}
+		})
+	}
+}
+
+func TestObjectMetaOwnerInvalid(t *testing.T) {
+	dep := &appsV1.Deployment{ObjectMeta: metaV1.ObjectMeta{Name: "web", UID: "dep-uid"}}
+	other := &appsV1.Deployment{ObjectMeta: metaV1.ObjectMeta{Name: "api", UID: "api-uid"}}
+
+	tests := map[string]Builder{
+		"nil owner":          Name("web-1").OwnedBy(nil),
+		"not an object":      Name("web-1").OwnedBy(Name("web")),
+		"missing uid":        Name("web-1").OwnedBy(&appsV1.Deployment{ObjectMeta: metaV1.ObjectMeta{Name: "web"}}),
+		"second controller":  Name("web-1").OwnedBy(dep).OwnedBy(other),
+		"invalid finalizer":  Name("web-1").AddFinalizer("not a finalizer"),
+		"invalid finalizers": Name("web-1").Finalizers("kubernetes", "-bad"),
+	}
+
+	for name, builder := range tests {
+		t.Run(name, func(t *testing.T) {
+			if builder.Err() == nil {
+				t.Fatal("expecting error, got none")
+			}
+		})
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,162 @@
+// Package pdb contains builder types to build values of type policyV1.PodDisruptionBudget
+package pdb
+
+import (
+	"fmt"
+
+	"github.com/vladimirvivien/kob/deployment"
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	"github.com/vladimirvivien/kob/selector"
+	policyV1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Builder provides a way to build values of type policyV1.PodDisruptionBudget
+type Builder map[string]interface{}
+
+// Object starts a new PodDisruptionBudget builder using the provided object metadata
+func Object(metadata objmeta.Builder) Builder {
+	meta, err := metadata.U()
+	return Builder(unstruct.SetErr(map[string]interface{}{"metadata": meta}, err))
+}
+
+// For starts a new PodDisruptionBudget builder, named after the deployment
+// (see naming.Name) in the deployment's namespace, selecting the pods managed
+// by the deployment with a copy of its selector (see deployment.PodSelector).
+// An invalid deployment or an empty selector is recorded as an error returned
+// by T.
+func For(dep deployment.Builder) Builder {
+	obj, err := dep.T()
+	if err != nil {
+		return Builder(unstruct.SetErr(map[string]interface{}{}, fmt.Errorf("pdb: target deployment: %w", err)))
+	}
+	sel, err := dep.PodSelector()
+	if err != nil {
+		return Builder(unstruct.SetErr(map[string]interface{}{}, fmt.Errorf("pdb: target deployment: %w", err)))
+	}
+	return Object(objmeta.Name(naming.Name(obj.Name, "pdb")).Namespace(obj.Namespace)).LabelSelector(sel)
+}
+
+// U returns the unstructured value of the builder
+func (b Builder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b Builder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder. An error is returned if both
+// minAvailable and maxUnavailable are set.
+func (b Builder) T() (policyV1.PodDisruptionBudget, error) {
+	var pdb policyV1.PodDisruptionBudget
+	if err := unstruct.FromUnstructured(b, &pdb); err != nil {
+		return policyV1.PodDisruptionBudget{}, err
+	}
+	if err := naming.Validate("PodDisruptionBudget", pdb.Name); err != nil {
+		return policyV1.PodDisruptionBudget{}, err
+	}
+	if pdb.Spec.MinAvailable != nil && pdb.Spec.MaxUnavailable != nil {
+		return policyV1.PodDisruptionBudget{}, fmt.Errorf("pdb: minAvailable and maxUnavailable are mutually exclusive")
+	}
+	return pdb, nil
+}
+
+// Selector sets the labels of the pods covered by the budget
+func (b Builder) Selector(labels map[string]string) Builder {
+	match := map[string]interface{}{}
+	for k, v := range labels {
+		match[k] = v
+	}
+	return b.setSpec("selector", map[string]interface{}{"matchLabels": match})
+}
+
+// LabelSelector sets the selector of the pods covered by the budget,
+// supporting label expressions
+func (b Builder) LabelSelector(sel selector.Builder) Builder {
+	return b.setSpec("selector", unstruct.Nest(sel))
+}
+
+// MinAvailable sets the number of pods that must remain available after an eviction
+func (b Builder) MinAvailable(n int) Builder {
+	return b.setSpec("minAvailable", int64(n))
+}
+
+// MinAvailablePercent sets the percentage of pods that must remain available after an eviction
+func (b Builder) MinAvailablePercent(pct int) Builder {
+	return b.setSpec("minAvailable", fmt.Sprintf("%d%%", pct))
+}
+
+// MaxUnavailable sets the number of pods that can be unavailable after an eviction
+func (b Builder) MaxUnavailable(n int) Builder {
+	return b.setSpec("maxUnavailable", int64(n))
+}
+
+// MaxUnavailablePercent sets the percentage of pods that can be unavailable after an eviction
+func (b Builder) MaxUnavailablePercent(pct int) Builder {
+	return b.setSpec("maxUnavailable", fmt.Sprintf("%d%%", pct))
+}
+
+// UnhealthyPodEvictionPolicy sets when running but not ready pods may be
+// evicted (IfHealthyBudget or AlwaysAllow)
+func (b Builder) UnhealthyPodEvictionPolicy(pol policyV1.UnhealthyPodEvictionPolicyType) Builder {
+	return b.setSpec("unhealthyPodEvictionPolicy", pol)
+}
+
+// Warnings returns a list of issues found when checking the budget against
+// the target deployment. Specifically, it reports budgets that can never be
+// satisfied, which would block every voluntary eviction (i.e. node drains).
+func (b Builder) Warnings(target deployment.Builder) []string {
+	pdb, err := b.T()
+	if err != nil {
+		return []string{err.Error()}
+	}
+	dep, err := target.T()
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	replicas := 1
+	if dep.Spec.Replicas != nil {
+		replicas = int(*dep.Spec.Replicas)
+	}
+
+	var warnings []string
+	if pdb.Spec.MinAvailable != nil {
+		minAvail, err := intstr.GetScaledValueFromIntOrPercent(pdb.Spec.MinAvailable, replicas, true)
+		if err != nil {
+			return append(warnings, fmt.Sprintf("pdb: invalid minAvailable: %s", err))
+		}
+		if minAvail >= replicas {
+			warnings = append(warnings, fmt.Sprintf("pdb: minAvailable %s is not less than %d replicas, no pod can be evicted", pdb.Spec.MinAvailable, replicas))
+		}
+	}
+	if pdb.Spec.MaxUnavailable != nil {
+		maxUnavail, err := intstr.GetScaledValueFromIntOrPercent(pdb.Spec.MaxUnavailable, replicas, true)
+		if err != nil {
+			return append(warnings, fmt.Sprintf("pdb: invalid maxUnavailable: %s", err))
+		}
+		if maxUnavail == 0 {
+			warnings = append(warnings, fmt.Sprintf("pdb: maxUnavailable %s allows no disruption, no pod can be evicted", pdb.Spec.MaxUnavailable))
+		}
+	}
+	return warnings
+}
+
+func (b Builder) setSpec(key string, val interface{}) Builder {
+	spec := b.getPDBSpec()
+	spec[key] = val
+	b["spec"] = spec
+	return b
+}
+
+func (b Builder) getPDBSpec() map[string]interface{} {
+	specIface, ok := b["spec"]
+	if !ok {
+		specIface = map[string]interface{}{}
+	}
+	return specIface.(map[string]interface{})
+}
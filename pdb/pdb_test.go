@@ -0,0 +1,151 @@
+package pdb
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vladimirvivien/kob/container"
+	"github.com/vladimirvivien/kob/deployment"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	"github.com/vladimirvivien/kob/selector"
+	policyV1 "k8s.io/api/policy/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestPDBTyped(t *testing.T) {
+	two, half := intstr.FromInt(2), intstr.FromString("50%")
+	alwaysAllow := policyV1.AlwaysAllow
+	tests := map[string]struct {
+		builder  Builder
+		expected policyV1.PodDisruptionBudget
+	}{
+		"empty": {
+			builder:  Builder{},
+			expected: policyV1.PodDisruptionBudget{},
+		},
+		"object meta only": {
+			builder:  Object(objmeta.Name("simple-pdb").Namespace("default")),
+			expected: policyV1.PodDisruptionBudget{ObjectMeta: metaV1.ObjectMeta{Name: "simple-pdb", Namespace: "default"}},
+		},
+		"min available with selector": {
+			builder: Object(objmeta.Name("simple-pdb")).Selector(map[string]string{"app": "web"}).MinAvailable(2),
+			expected: policyV1.PodDisruptionBudget{
+				ObjectMeta: metaV1.ObjectMeta{Name: "simple-pdb"},
+				Spec: policyV1.PodDisruptionBudgetSpec{
+					Selector:     &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+					MinAvailable: &two,
+				},
+			},
+		},
+		"label selector": {
+			builder: Object(objmeta.Name("simple-pdb")).LabelSelector(selector.MatchLabels(map[string]string{"app": "web"}).NotIn("track", "canary")).MinAvailable(2),
+			expected: policyV1.PodDisruptionBudget{
+				ObjectMeta: metaV1.ObjectMeta{Name: "simple-pdb"},
+				Spec: policyV1.PodDisruptionBudgetSpec{
+					Selector: &metaV1.LabelSelector{
+						MatchLabels:      map[string]string{"app": "web"},
+						MatchExpressions: []metaV1.LabelSelectorRequirement{{Key: "track", Operator: metaV1.LabelSelectorOpNotIn, Values: []string{"canary"}}},
+					},
+					MinAvailable: &two,
+				},
+			},
+		},
+		"for deployment": {
+			builder: For(deployment.Object(objmeta.Name("web").Namespace("default")).
+				PodSpecWithMetadata(objmeta.Name("web").Labels(map[string]string{"app": "web"}), container.Name("web"))).
+				MaxUnavailablePercent(50).UnhealthyPodEvictionPolicy(policyV1.AlwaysAllow),
+			expected: policyV1.PodDisruptionBudget{
+				ObjectMeta: metaV1.ObjectMeta{Name: naming.Name("web", "pdb"), Namespace: "default"},
+				Spec: policyV1.PodDisruptionBudgetSpec{
+					Selector:                   &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+					MaxUnavailable:             &half,
+					UnhealthyPodEvictionPolicy: &alwaysAllow,
+				},
+			},
+		},
+		"for deployment with selector expressions": {
+			builder: For(deployment.Object(objmeta.Name("web")).
+				LabelSelector(selector.MatchLabels(map[string]string{"app": "web"}).In("track", "stable", "canary"))).
+				MinAvailable(2),
+			expected: policyV1.PodDisruptionBudget{
+				ObjectMeta: metaV1.ObjectMeta{Name: naming.Name("web", "pdb")},
+				Spec: policyV1.PodDisruptionBudgetSpec{
+					Selector: &metaV1.LabelSelector{
+						MatchLabels:      map[string]string{"app": "web"},
+						MatchExpressions: []metaV1.LabelSelectorRequirement{{Key: "track", Operator: metaV1.LabelSelectorOpIn, Values: []string{"stable", "canary"}}},
+					},
+					MinAvailable: &two,
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pdb, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(pdb, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", pdb, test.expected)
+			}
+		})
+	}
+}
+
+func TestPDBForLongName(t *testing.T) {
+	name := strings.Repeat("web.", 60) + "app"
+	pdb, err := For(deployment.Object(objmeta.Name(name)).Selector(map[string]string{"app": "web"})).MinAvailable(1).T()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pdb.Name) > naming.MaxLength || pdb.Name != naming.Name(name, "pdb") {
+		t.Errorf("unexpected name %q", pdb.Name)
+	}
+}
+
+func TestPDBInvalid(t *testing.T) {
+	tests := map[string]Builder{
+		"min available and max unavailable": Object(objmeta.Name("simple-pdb")).MinAvailable(1).MaxUnavailable(1),
+		"invalid deployment":                For(deployment.Object(objmeta.Name("My_App"))).MinAvailable(1),
+		"empty selector":                    For(deployment.Object(objmeta.Name("web"))).MinAvailable(1),
+	}
+
+	for name, builder := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := builder.T(); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
+
+func TestPDBWarnings(t *testing.T) {
+	dep := deployment.Object(objmeta.Name("web")).Replicas(3).Selector(map[string]string{"app": "web"})
+	tests := map[string]struct {
+		builder  Builder
+		target   deployment.Builder
+		warnings int
+	}{
+		"min available equals replicas":    {builder: For(dep).MinAvailable(3), target: dep, warnings: 1},
+		"min available above replicas":     {builder: For(dep).MinAvailable(5), target: dep, warnings: 1},
+		"min available below replicas":     {builder: For(dep).MinAvailable(2), target: dep, warnings: 0},
+		"min available hundred percent":    {builder: For(dep).MinAvailablePercent(100), target: dep, warnings: 1},
+		"min available percent rounded up": {builder: For(dep).MinAvailablePercent(90), target: dep, warnings: 1},
+		"max unavailable zero":             {builder: For(dep).MaxUnavailable(0), target: dep, warnings: 1},
+		"max unavailable percent":          {builder: For(dep).MaxUnavailablePercent(34), target: dep, warnings: 0},
+		"default single replica":           {builder: Object(objmeta.Name("web")).MinAvailable(1), target: deployment.Object(objmeta.Name("web")), warnings: 1},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			warnings := test.builder.Warnings(test.target)
+			if len(warnings) != test.warnings {
+				t.Errorf("expecting %d warnings, got %d: %v", test.warnings, len(warnings), warnings)
+			}
+		})
+	}
+}
@@ -1,31 +1,185 @@
 package pod
 
 import (
+	"strings"
+
 	"github.com/vladimirvivien/kob/container"
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
 	"github.com/vladimirvivien/kob/objmeta"
+	"github.com/vladimirvivien/kob/selector"
 	coreV1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
 )
 
 type Builder map[string]interface{}
 
 func Object(metadata objmeta.Builder) Builder {
-	return Builder{"metadata": metadata.U()}
+	meta, err := metadata.U()
+	return Builder(unstruct.SetErr(map[string]interface{}{"metadata": meta}, err))
+}
+
+// From creates a new builder using the provided pod as its base
+func From(obj coreV1.Pod) Builder {
+	u, _ := runtime.DefaultUnstructuredConverter.ToUnstructured(&obj)
+	return Builder(u)
+}
+
+// FromUnstructured creates a builder from an unstructured value, which
+// must be convertible to a coreV1.Pod
+func FromUnstructured(unstruct map[string]any) (Builder, error) {
+	var obj coreV1.Pod
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstruct, &obj); err != nil {
+		return Builder{}, err
+	}
+	return From(obj), nil
+}
+
+// FromString creates a builder from a valid YAML or JSON pod manifest
+func FromString(str string) (Builder, error) {
+	var obj coreV1.Pod
+	if err := yaml.NewYAMLOrJSONDecoder(strings.NewReader(str), 1024).Decode(&obj); err != nil {
+		return Builder{}, err
+	}
+	return From(obj), nil
 }
 
 func (b Builder) U() map[string]interface{} {
-	return b
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b Builder) Err() error {
+	return unstruct.Err(b)
 }
 
 func (b Builder) T() (coreV1.Pod, error) {
 	var pod coreV1.Pod
-	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(b, &pod); err != nil {
+	if err := unstruct.FromUnstructured(b, &pod); err != nil {
+		return coreV1.Pod{}, err
+	}
+	if err := naming.Validate("Pod", pod.Name); err != nil {
 		return coreV1.Pod{}, err
 	}
 	return pod, nil
 }
 
 func (b Builder) Spec(containers ...container.Builder) Builder {
-	b["spec"] = Spec(containers...).U()
+	b["spec"] = unstruct.Nest(Spec(containers...))
+	return b
+}
+
+// Containers returns builders for the containers of the pod (see SpecBuilder.Containers)
+func (b Builder) Containers() ([]container.Builder, error) {
+	spec, _ := b["spec"].(map[string]interface{})
+	return SpecBuilder(spec).Containers()
+}
+
+// SetContainers replaces the containers of the pod, leaving the rest of its spec as is
+func (b Builder) SetContainers(containers ...container.Builder) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.SetContainers(containers...) })
+}
+
+// WithSpec sets the pod spec built by spec
+func (b Builder) WithSpec(spec SpecBuilder) Builder {
+	b["spec"] = unstruct.Nest(spec)
+	return b
+}
+
+// InitContainers sets the containers run to completion before the pod's containers start
+func (b Builder) InitContainers(containers ...container.Builder) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.InitContainers(containers...) })
+}
+
+// AddContainer adds a container to the pod
+func (b Builder) AddContainer(c container.Builder) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.AddContainer(c) })
+}
+
+// AddInitContainer adds an init container to the pod
+func (b Builder) AddInitContainer(c container.Builder) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.AddInitContainer(c) })
+}
+
+// RestartPolicy sets the restart policy for all containers in the pod
+func (b Builder) RestartPolicy(pol coreV1.RestartPolicy) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.RestartPolicy(pol) })
+}
+
+// Volumes sets the volumes that can be mounted by the pod's containers
+func (b Builder) Volumes(vols ...VolumeBuilder) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.Volumes(vols...) })
+}
+
+// AddVolume adds a volume to the pod
+func (b Builder) AddVolume(vol VolumeBuilder) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.AddVolume(vol) })
+}
+
+// PriorityClassName sets the name of the PriorityClass used to schedule the pod
+func (b Builder) PriorityClassName(name string) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.PriorityClassName(name) })
+}
+
+// RuntimeClassName sets the name of the RuntimeClass used to run the pod
+func (b Builder) RuntimeClassName(name string) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.RuntimeClassName(name) })
+}
+
+// NodeSelector restricts the pod to nodes with the labels
+func (b Builder) NodeSelector(labels map[string]string) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.NodeSelector(labels) })
+}
+
+// Affinity sets the node and pod (anti-)affinity scheduling constraints of the pod
+func (b Builder) Affinity(affinity coreV1.Affinity) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.Affinity(affinity) })
+}
+
+// AddPodAffinity requires the pod to be scheduled in the same topology domain
+// as the pods selected by sel (see SpecBuilder.AddPodAffinity)
+func (b Builder) AddPodAffinity(topologyKey string, sel selector.Builder) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.AddPodAffinity(topologyKey, sel) })
+}
+
+// AddPodAntiAffinity requires the pod not to be scheduled in the same
+// topology domain as the pods selected by sel
+func (b Builder) AddPodAntiAffinity(topologyKey string, sel selector.Builder) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.AddPodAntiAffinity(topologyKey, sel) })
+}
+
+// AddTopologySpread adds a constraint spreading the pods selected by sel
+// across the topology domains of topologyKey (see SpecBuilder.AddTopologySpread)
+func (b Builder) AddTopologySpread(maxSkew int32, topologyKey string, whenUnsatisfiable coreV1.UnsatisfiableConstraintAction, sel selector.Builder) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder {
+		return spec.AddTopologySpread(maxSkew, topologyKey, whenUnsatisfiable, sel)
+	})
+}
+
+// Tolerations sets the node taints tolerated by the pod
+func (b Builder) Tolerations(tolerations ...coreV1.Toleration) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.Tolerations(tolerations...) })
+}
+
+// ServiceAccountName sets the service account the pod runs as
+func (b Builder) ServiceAccountName(name string) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.ServiceAccountName(name) })
+}
+
+// TerminationGracePeriodSeconds sets how long the pod is given to shut down gracefully
+func (b Builder) TerminationGracePeriodSeconds(secs int64) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.TerminationGracePeriodSeconds(secs) })
+}
+
+// withSpec applies the SpecBuilder setter f to the pod's spec. The setters of
+// SpecBuilder declared by hand are forwarded above, the generated ones are
+// forwarded by kob-builder-gen (see the -forward flag in podspec.go)
+func (b Builder) withSpec(f func(SpecBuilder) SpecBuilder) Builder {
+	spec, ok := b["spec"].(map[string]interface{})
+	if !ok {
+		spec = map[string]interface{}{}
+	}
+	b["spec"] = unstruct.Nest(f(spec))
 	return b
 }
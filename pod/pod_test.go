@@ -3,11 +3,15 @@ package pod
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/vladimirvivien/kob/container"
 	"github.com/vladimirvivien/kob/objmeta"
 	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func TestPodUnstructured(t *testing.T) {
@@ -72,6 +76,66 @@ func TestPodStructured(t *testing.T) {
 			builder:  Object(objmeta.Name("simple-pod")).Spec(container.Name("simple-container").Image("simple-image")),
 			expected: coreV1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "simple-pod"}, Spec: coreV1.PodSpec{Containers: []coreV1.Container{{Name: "simple-container", Image: "simple-image"}}}},
 		},
+		"pod with spec setters": {
+			builder: Object(objmeta.Name("simple-pod")).
+				AddContainer(container.Name("app")).
+				AddInitContainer(container.Name("init")).
+				RestartPolicy(coreV1.RestartPolicyNever).
+				Volumes(Volume("data").EmptyDir()).
+				AddVolume(Volume("config").ConfigMap("app-config")).
+				PriorityClassName("high-priority").
+				RuntimeClassName("gvisor").
+				NodeSelector(map[string]string{"disk": "ssd"}).
+				Affinity(coreV1.Affinity{NodeAffinity: &coreV1.NodeAffinity{PreferredDuringSchedulingIgnoredDuringExecution: []coreV1.PreferredSchedulingTerm{{
+					Weight:     10,
+					Preference: coreV1.NodeSelectorTerm{MatchExpressions: []coreV1.NodeSelectorRequirement{{Key: "zone", Operator: coreV1.NodeSelectorOpIn, Values: []string{"a"}}}},
+				}}}}).
+				Tolerations(coreV1.Toleration{Key: "dedicated", Operator: coreV1.TolerationOpEqual, Value: "web", Effect: coreV1.TaintEffectNoSchedule}).
+				ServiceAccountName("app").
+				TerminationGracePeriodSeconds(10),
+			expected: coreV1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "simple-pod"}, Spec: coreV1.PodSpec{
+				Containers:     []coreV1.Container{{Name: "app"}},
+				InitContainers: []coreV1.Container{{Name: "init"}},
+				RestartPolicy:  coreV1.RestartPolicyNever,
+				Volumes: []coreV1.Volume{
+					{Name: "data", VolumeSource: coreV1.VolumeSource{EmptyDir: &coreV1.EmptyDirVolumeSource{}}},
+					{Name: "config", VolumeSource: coreV1.VolumeSource{ConfigMap: &coreV1.ConfigMapVolumeSource{LocalObjectReference: coreV1.LocalObjectReference{Name: "app-config"}}}},
+				},
+				PriorityClassName: "high-priority",
+				RuntimeClassName:  strPtr("gvisor"),
+				NodeSelector:      map[string]string{"disk": "ssd"},
+				Affinity: &coreV1.Affinity{NodeAffinity: &coreV1.NodeAffinity{PreferredDuringSchedulingIgnoredDuringExecution: []coreV1.PreferredSchedulingTerm{{
+					Weight:     10,
+					Preference: coreV1.NodeSelectorTerm{MatchExpressions: []coreV1.NodeSelectorRequirement{{Key: "zone", Operator: coreV1.NodeSelectorOpIn, Values: []string{"a"}}}},
+				}}}},
+				Tolerations:                   []coreV1.Toleration{{Key: "dedicated", Operator: coreV1.TolerationOpEqual, Value: "web", Effect: coreV1.TaintEffectNoSchedule}},
+				ServiceAccountName:            "app",
+				TerminationGracePeriodSeconds: int64Ptr(10),
+			}},
+		},
+		"pod with generated spec setters": {
+			builder: Object(objmeta.Name("simple-pod")).Spec(container.Name("app")).
+				EphemeralContainers(coreV1.EphemeralContainer{EphemeralContainerCommon: coreV1.EphemeralContainerCommon{Name: "debug"}}).
+				DNSPolicy(coreV1.DNSClusterFirst).
+				HostNetwork(true).
+				SecurityContext(SecurityContextBuilder{}.RunAsNonRoot(true)).
+				AddToleration(coreV1.Toleration{Key: "dedicated", Operator: coreV1.TolerationOpExists}),
+			expected: coreV1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "simple-pod"}, Spec: coreV1.PodSpec{
+				Containers:          []coreV1.Container{{Name: "app"}},
+				EphemeralContainers: []coreV1.EphemeralContainer{{EphemeralContainerCommon: coreV1.EphemeralContainerCommon{Name: "debug"}}},
+				DNSPolicy:           coreV1.DNSClusterFirst,
+				HostNetwork:         true,
+				SecurityContext:     &coreV1.PodSecurityContext{RunAsNonRoot: boolPtr(true)},
+				Tolerations:         []coreV1.Toleration{{Key: "dedicated", Operator: coreV1.TolerationOpExists}},
+			}},
+		},
+		"pod with spec": {
+			builder: Object(objmeta.Name("simple-pod")).WithSpec(Spec(container.Name("app")).InitContainers(container.Name("init"))),
+			expected: coreV1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "simple-pod"}, Spec: coreV1.PodSpec{
+				Containers:     []coreV1.Container{{Name: "app"}},
+				InitContainers: []coreV1.Container{{Name: "init"}},
+			}},
+		},
 	}
 
 	for name, test := range tests {
@@ -86,3 +150,193 @@ func TestPodStructured(t *testing.T) {
 		})
 	}
 }
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+// fullPod returns a pod with most of its fields populated, including the
+// metadata and status set by the API server
+func fullPod() coreV1.Pod {
+	created := metaV1.NewTime(time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC).Local())
+	return coreV1.Pod{
+		TypeMeta: metaV1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:              "web-0",
+			Namespace:         "shop",
+			UID:               "pod-uid",
+			ResourceVersion:   "42",
+			CreationTimestamp: created,
+			Labels:            map[string]string{"app": "web"},
+			Annotations:       map[string]string{"team": "a"},
+			OwnerReferences:   []metaV1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web", UID: "rs-uid", Controller: boolPtr(true)}},
+			Finalizers:        []string{"example.com/cleanup"},
+		},
+		Spec: coreV1.PodSpec{
+			InitContainers: []coreV1.Container{{Name: "init", Image: "busybox", Command: []string{"sh", "-c", "true"}}},
+			Containers: []coreV1.Container{{
+				Name:    "web",
+				Image:   "nginx:1.25",
+				Args:    []string{"-g", "daemon off;"},
+				Ports:   []coreV1.ContainerPort{{Name: "http", ContainerPort: 8080, Protocol: coreV1.ProtocolTCP}},
+				Env:     []coreV1.EnvVar{{Name: "MODE", Value: "fast"}},
+				EnvFrom: []coreV1.EnvFromSource{{ConfigMapRef: &coreV1.ConfigMapEnvSource{LocalObjectReference: coreV1.LocalObjectReference{Name: "web-config"}}}},
+				Resources: coreV1.ResourceRequirements{
+					Limits:   coreV1.ResourceList{coreV1.ResourceMemory: resource.MustParse("128Mi")},
+					Requests: coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("100m")},
+				},
+				VolumeMounts: []coreV1.VolumeMount{{Name: "data", MountPath: "/data", ReadOnly: true}},
+				ReadinessProbe: &coreV1.Probe{
+					ProbeHandler:  coreV1.ProbeHandler{HTTPGet: &coreV1.HTTPGetAction{Path: "/healthz", Port: intstr.FromString("http")}},
+					PeriodSeconds: 10,
+				},
+				ImagePullPolicy: coreV1.PullIfNotPresent,
+				SecurityContext: &coreV1.SecurityContext{RunAsNonRoot: boolPtr(true)},
+			}},
+			Volumes:                       []coreV1.Volume{{Name: "data", VolumeSource: coreV1.VolumeSource{EmptyDir: &coreV1.EmptyDirVolumeSource{}}}},
+			RestartPolicy:                 coreV1.RestartPolicyAlways,
+			TerminationGracePeriodSeconds: int64Ptr(30),
+			NodeSelector:                  map[string]string{"disk": "ssd"},
+			ServiceAccountName:            "web",
+			Tolerations:                   []coreV1.Toleration{{Key: "dedicated", Operator: coreV1.TolerationOpEqual, Value: "web", Effect: coreV1.TaintEffectNoSchedule}},
+			PriorityClassName:             "high",
+			RuntimeClassName:              strPtr("gvisor"),
+		},
+		Status: coreV1.PodStatus{
+			Phase:      coreV1.PodRunning,
+			PodIP:      "10.0.0.5",
+			Conditions: []coreV1.PodCondition{{Type: coreV1.PodReady, Status: coreV1.ConditionTrue, LastTransitionTime: created}},
+		},
+	}
+}
+
+func TestPodFrom(t *testing.T) {
+	full := fullPod()
+	tests := map[string]struct {
+		builder  func() (Builder, error)
+		expected coreV1.Pod
+	}{
+		"from empty": {
+			builder:  func() (Builder, error) { return From(coreV1.Pod{}), nil },
+			expected: coreV1.Pod{},
+		},
+		"from full": {
+			builder:  func() (Builder, error) { return From(full), nil },
+			expected: full,
+		},
+		"from unstructured": {
+			builder: func() (Builder, error) {
+				u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&full)
+				if err != nil {
+					return nil, err
+				}
+				return FromUnstructured(u)
+			},
+			expected: full,
+		},
+		"from string": {
+			builder: func() (Builder, error) {
+				return FromString(`
+metadata:
+  name: web
+spec:
+  containers:
+  - name: web
+    image: nginx
+`)
+			},
+			expected: coreV1.Pod{
+				ObjectMeta: metaV1.ObjectMeta{Name: "web"},
+				Spec:       coreV1.PodSpec{Containers: []coreV1.Container{{Name: "web", Image: "nginx"}}},
+			},
+		},
+		"from and modify": {
+			builder: func() (Builder, error) {
+				return From(coreV1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "web"}}).RestartPolicy(coreV1.RestartPolicyNever), nil
+			},
+			expected: coreV1.Pod{
+				ObjectMeta: metaV1.ObjectMeta{Name: "web"},
+				Spec:       coreV1.PodSpec{RestartPolicy: coreV1.RestartPolicyNever},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder, err := test.builder()
+			if err != nil {
+				t.Fatalf("failed to create builder: %s", err)
+			}
+			pod, err := builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(pod, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", pod, test.expected)
+			}
+		})
+	}
+
+	if _, err := FromString("spec: [invalid"); err == nil {
+		t.Error("expecting error, got none")
+	}
+	if _, err := FromUnstructured(map[string]any{"spec": "invalid"}); err == nil {
+		t.Error("expecting error, got none")
+	}
+}
+
+func TestPodContainers(t *testing.T) {
+	p := From(fullPod())
+	containers, err := p.Containers()
+	if err != nil {
+		t.Fatalf("failed to get containers: %s", err)
+	}
+	if len(containers) != 1 {
+		t.Fatalf("expecting 1 container, got %d", len(containers))
+	}
+
+	obj, err := p.SetContainers(containers[0].Image("nginx:1.26"), container.Name("sidecar")).T()
+	if err != nil {
+		t.Fatalf("failed to convert to typed value: %s", err)
+	}
+	expected := fullPod()
+	expected.Spec.Containers[0].Image = "nginx:1.26"
+	expected.Spec.Containers = append(expected.Spec.Containers, coreV1.Container{Name: "sidecar"})
+	if !reflect.DeepEqual(obj, expected) {
+		t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", obj, expected)
+	}
+
+	if containers, err := (Builder{}).Containers(); err != nil || len(containers) != 0 {
+		t.Errorf("expecting no containers, got %v (%v)", containers, err)
+	}
+	if _, err := Object(objmeta.Name("web")).WithSpec(SpecBuilder{"containers": []interface{}{map[string]interface{}{"ports": "invalid"}}}).Containers(); err == nil {
+		t.Error("expecting error, got none")
+	}
+}
+
+// TestPodForwardsSpecSetters checks the pod builder has a setter for each
+// setter of its spec, generated or declared by hand
+func TestPodForwardsSpecSetters(t *testing.T) {
+	pod, spec := reflect.TypeOf(Builder{}), reflect.TypeOf(SpecBuilder{})
+	for i := 0; i < spec.NumMethod(); i++ {
+		setter := spec.Method(i)
+		if setter.Type.NumOut() != 1 || setter.Type.Out(0) != spec {
+			continue
+		}
+		forward, ok := pod.MethodByName(setter.Name)
+		if !ok {
+			t.Errorf("pod builder has no %s setter", setter.Name)
+			continue
+		}
+		for j := 1; j < setter.Type.NumIn(); j++ {
+			if forward.Type.NumIn() != setter.Type.NumIn() || forward.Type.In(j) != setter.Type.In(j) {
+				t.Errorf("pod builder %s setter has type %s, expecting the parameters of %s", setter.Name, forward.Type, setter.Type)
+				break
+			}
+		}
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -1,77 +1,264 @@
 package pod
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/vladimirvivien/kob/container"
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/selector"
 	coreV1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
 )
 
+//go:generate go run ../cmd/kob-builder-gen -pkg k8s.io/api/core/v1 -forward Builder=SpecBuilder PodSpec=SpecBuilder PodSecurityContext=SecurityContextBuilder
+
 type SpecBuilder map[string]interface{}
 
 func Spec(containers ...container.Builder) SpecBuilder {
 	var slice []interface{}
 	for _, c := range containers {
-		slice = append(slice, c.U())
+		u, _ := c.U()
+		slice = append(slice, u)
 	}
 	return SpecBuilder{
 		"containers": slice,
 	}
 }
 
+// SpecFrom creates a new spec builder using the provided pod spec as its base
+func SpecFrom(obj coreV1.PodSpec) SpecBuilder {
+	u, _ := runtime.DefaultUnstructuredConverter.ToUnstructured(&obj)
+	return SpecBuilder(u)
+}
+
+// SpecFromUnstructured creates a spec builder from an unstructured value,
+// which must be convertible to a coreV1.PodSpec
+func SpecFromUnstructured(unstruct map[string]any) (SpecBuilder, error) {
+	var obj coreV1.PodSpec
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstruct, &obj); err != nil {
+		return SpecBuilder{}, err
+	}
+	return SpecFrom(obj), nil
+}
+
+// SpecFromString creates a spec builder from a valid YAML or JSON pod spec fragment
+func SpecFromString(str string) (SpecBuilder, error) {
+	var obj coreV1.PodSpec
+	if err := yaml.NewYAMLOrJSONDecoder(strings.NewReader(str), 1024).Decode(&obj); err != nil {
+		return SpecBuilder{}, err
+	}
+	return SpecFrom(obj), nil
+}
+
 func (b SpecBuilder) U() map[string]interface{} {
-	return map[string]interface{}(b)
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b SpecBuilder) Err() error {
+	return unstruct.Err(b)
 }
 
 func (b SpecBuilder) T() (coreV1.PodSpec, error) {
 	var spec coreV1.PodSpec
-	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(b, &spec); err != nil {
+	if err := unstruct.FromUnstructured(b, &spec); err != nil {
 		return coreV1.PodSpec{}, err
 	}
 	return spec, nil
 }
 
+// Containers returns builders for the containers of the spec, which can be
+// modified and set back with SetContainers
+func (b SpecBuilder) Containers() ([]container.Builder, error) {
+	containers, _ := b["containers"].([]interface{})
+	var builders []container.Builder
+	for _, c := range containers {
+		u, _ := c.(map[string]interface{})
+		builder, err := container.FromUnstructured(u)
+		if err != nil {
+			return nil, err
+		}
+		builders = append(builders, builder)
+	}
+	return builders, nil
+}
+
+// SetContainers replaces the containers of the spec
+func (b SpecBuilder) SetContainers(containers ...container.Builder) SpecBuilder {
+	var slice []interface{}
+	for _, c := range containers {
+		u, _ := c.U()
+		slice = append(slice, u)
+	}
+	b["containers"] = slice
+	return b
+}
+
+// InitContainers sets the containers run to completion before the pod's containers start
 func (b SpecBuilder) InitContainers(containers ...container.Builder) SpecBuilder {
 	var slice []interface{}
 	for _, c := range containers {
-		slice = append(slice, c.U())
+		u, _ := c.U()
+		slice = append(slice, u)
 	}
-	return map[string]interface{}{
-		"initContainers": slice,
+	b["initContainers"] = slice
+	return b
+}
+
+// AddContainer adds a container to the pod spec
+func (b SpecBuilder) AddContainer(c container.Builder) SpecBuilder {
+	containers, _ := b["containers"].([]interface{})
+	u, _ := c.U()
+	b["containers"] = append(containers, u)
+	return b
+}
+
+// AddInitContainer adds an init container to the pod spec
+func (b SpecBuilder) AddInitContainer(c container.Builder) SpecBuilder {
+	containers, _ := b["initContainers"].([]interface{})
+	u, _ := c.U()
+	b["initContainers"] = append(containers, u)
+	return b
+}
+
+// RestartPolicy sets the restart policy for all containers in the pod
+func (b SpecBuilder) RestartPolicy(pol coreV1.RestartPolicy) SpecBuilder {
+	b["restartPolicy"] = pol
+	return b
+}
+
+// Volumes sets the volumes that can be mounted by the pod's containers
+func (b SpecBuilder) Volumes(vols ...VolumeBuilder) SpecBuilder {
+	var slice []interface{}
+	for _, v := range vols {
+		slice = append(slice, unstruct.Nest(v))
 	}
+	b["volumes"] = slice
+	return b
 }
 
-// func (b *PodSpecBuilder) Volumes(vols ...coreV1.Volume) *PodSpecBuilder {
-// 	b.spec.Volumes = vols
-// 	return b
-// }
+// AddVolume adds a volume to the pod spec
+func (b SpecBuilder) AddVolume(vol VolumeBuilder) SpecBuilder {
+	vols, _ := b["volumes"].([]interface{})
+	b["volumes"] = append(vols, unstruct.Nest(vol))
+	return b
+}
 
-// func (b *PodSpecBuilder) AddVolume(vol coreV1.Volume) *PodSpecBuilder {
-// 	b.spec.Volumes = append(b.spec.Volumes, vol)
-// 	return b
-// }
+// PriorityClassName sets the name of the PriorityClass used to schedule the pod
+func (b SpecBuilder) PriorityClassName(name string) SpecBuilder {
+	b["priorityClassName"] = name
+	return b
+}
 
-// func (b *PodSpecBuilder) InitContainers(containers ...coreV1.Container) *PodSpecBuilder {
-// 	b.spec.InitContainers = containers
-// 	return b
-// }
+// RuntimeClassName sets the name of the RuntimeClass used to run the pod
+func (b SpecBuilder) RuntimeClassName(name string) SpecBuilder {
+	b["runtimeClassName"] = name
+	return b
+}
 
-// func (b *PodSpecBuilder) AddInitContainer(container coreV1.Container) *PodSpecBuilder {
-// 	b.spec.InitContainers = append(b.spec.InitContainers, container)
-// 	return b
-// }
+// NodeSelector restricts the pod to nodes with the labels
+func (b SpecBuilder) NodeSelector(labels map[string]string) SpecBuilder {
+	sel := map[string]interface{}{}
+	for k, v := range labels {
+		sel[k] = v
+	}
+	b["nodeSelector"] = sel
+	return b
+}
 
-// func (b *PodSpecBuilder) Containers(containers ...coreV1.Container) *PodSpecBuilder {
-// 	b.spec.Containers = containers
-// 	return b
-// }
+// Affinity sets the node and pod (anti-)affinity scheduling constraints of the pod
+func (b SpecBuilder) Affinity(affinity coreV1.Affinity) SpecBuilder {
+	u, _ := runtime.DefaultUnstructuredConverter.ToUnstructured(&affinity)
+	b["affinity"] = u
+	return b
+}
 
-// func (b *PodSpecBuilder) AddContainer(container coreV1.Container) *PodSpecBuilder {
-// 	b.spec.Containers = append(b.spec.Containers, container)
-// 	return b
-// }
+// AddPodAffinity requires the pod to be scheduled in the same topology domain
+// (i.e. node or zone, named by the node label topologyKey) as the pods
+// selected by sel. An invalid selector is recorded as an error.
+func (b SpecBuilder) AddPodAffinity(topologyKey string, sel selector.Builder) SpecBuilder {
+	return b.addAffinityTerm("podAffinity", topologyKey, sel)
+}
+
+// AddPodAntiAffinity requires the pod not to be scheduled in the same
+// topology domain as the pods selected by sel. An invalid selector is
+// recorded as an error.
+func (b SpecBuilder) AddPodAntiAffinity(topologyKey string, sel selector.Builder) SpecBuilder {
+	return b.addAffinityTerm("podAntiAffinity", topologyKey, sel)
+}
+
+// AddTopologySpread adds a constraint spreading the pods selected by sel
+// across the topology domains of topologyKey, with at most maxSkew pods of
+// difference between domains. An invalid selector is recorded as an error.
+func (b SpecBuilder) AddTopologySpread(maxSkew int32, topologyKey string, whenUnsatisfiable coreV1.UnsatisfiableConstraintAction, sel selector.Builder) SpecBuilder {
+	labels, err := sel.T()
+	if err != nil {
+		unstruct.SetErr(b, fmt.Errorf("pod: topology spread: %w", err))
+		return b
+	}
+	return b.AddTopologySpreadConstraint(coreV1.TopologySpreadConstraint{
+		MaxSkew:           maxSkew,
+		TopologyKey:       topologyKey,
+		WhenUnsatisfiable: whenUnsatisfiable,
+		LabelSelector:     &labels,
+	})
+}
+
+// addAffinityTerm adds a required term, selecting pods with sel, to the pod
+// affinity or anti-affinity (kind) of the pod
+func (b SpecBuilder) addAffinityTerm(kind, topologyKey string, sel selector.Builder) SpecBuilder {
+	labels, err := sel.T()
+	if err != nil {
+		unstruct.SetErr(b, fmt.Errorf("pod: %s: %w", kind, err))
+		return b
+	}
+	term, err := unstruct.JSONValue(coreV1.PodAffinityTerm{LabelSelector: &labels, TopologyKey: topologyKey})
+	if err != nil {
+		unstruct.SetErr(b, fmt.Errorf("pod: %s: %w", kind, err))
+		return b
+	}
+	affinity, ok := b["affinity"].(map[string]interface{})
+	if !ok {
+		affinity = map[string]interface{}{}
+	}
+	rules, ok := affinity[kind].(map[string]interface{})
+	if !ok {
+		rules = map[string]interface{}{}
+	}
+	terms, _ := rules["requiredDuringSchedulingIgnoredDuringExecution"].([]interface{})
+	rules["requiredDuringSchedulingIgnoredDuringExecution"] = append(terms, term)
+	affinity[kind] = rules
+	b["affinity"] = affinity
+	return b
+}
+
+// Tolerations sets the node taints tolerated by the pod
+func (b SpecBuilder) Tolerations(tolerations ...coreV1.Toleration) SpecBuilder {
+	var slice []interface{}
+	for i := range tolerations {
+		u, _ := runtime.DefaultUnstructuredConverter.ToUnstructured(&tolerations[i])
+		slice = append(slice, u)
+	}
+	b["tolerations"] = slice
+	return b
+}
+
+// ServiceAccountName sets the service account the pod runs as
+func (b SpecBuilder) ServiceAccountName(name string) SpecBuilder {
+	b["serviceAccountName"] = name
+	return b
+}
 
-// func (b *PodSpecBuilder) RestartPolicy(pol coreV1.RestartPolicy) *PodSpecBuilder {
-// 	b.spec.RestartPolicy = pol
+// TerminationGracePeriodSeconds sets how long the pod is given to shut down gracefully
+func (b SpecBuilder) TerminationGracePeriodSeconds(secs int64) SpecBuilder {
+	b["terminationGracePeriodSeconds"] = secs
+	return b
+}
+
+// func (b *PodSpecBuilder) Containers(containers ...coreV1.Container) *PodSpecBuilder {
+// 	b.spec.Containers = containers
 // 	return b
 // }
 
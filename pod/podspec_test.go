@@ -5,7 +5,10 @@ import (
 	"testing"
 
 	"github.com/vladimirvivien/kob/container"
+	"github.com/vladimirvivien/kob/selector"
 	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestPodSpecUnstructured(t *testing.T) {
@@ -29,6 +32,10 @@ func TestPodSpecUnstructured(t *testing.T) {
 			builder:  Spec(container.Name("simple-name").Image("simple-image")),
 			expected: map[string]interface{}{"containers": []interface{}{map[string]interface{}{"name": "simple-name", "image": "simple-image"}}},
 		},
+		"spec with restart policy": {
+			builder:  Spec(container.Name("simple-name")).RestartPolicy(coreV1.RestartPolicyNever),
+			expected: map[string]interface{}{"containers": []interface{}{map[string]interface{}{"name": "simple-name"}}, "restartPolicy": coreV1.RestartPolicyNever},
+		},
 	}
 
 	for name, test := range tests {
@@ -62,6 +69,108 @@ func TestPodSpecStructured(t *testing.T) {
 			builder:  Spec(container.Name("container-name").Image("image-name")),
 			expected: coreV1.PodSpec{Containers: []coreV1.Container{{Name: "container-name", Image: "image-name"}}},
 		},
+		"spec with restart policy": {
+			builder:  Spec(container.Name("container-name")).RestartPolicy(coreV1.RestartPolicyOnFailure),
+			expected: coreV1.PodSpec{Containers: []coreV1.Container{{Name: "container-name"}}, RestartPolicy: coreV1.RestartPolicyOnFailure},
+		},
+		"spec with volumes": {
+			builder: Spec(container.Name("container-name")).Volumes(Volume("data").EmptyDir()).AddVolume(Volume("config").ConfigMap("app-config")),
+			expected: coreV1.PodSpec{
+				Containers: []coreV1.Container{{Name: "container-name"}},
+				Volumes: []coreV1.Volume{
+					{Name: "data", VolumeSource: coreV1.VolumeSource{EmptyDir: &coreV1.EmptyDirVolumeSource{}}},
+					{Name: "config", VolumeSource: coreV1.VolumeSource{ConfigMap: &coreV1.ConfigMapVolumeSource{LocalObjectReference: coreV1.LocalObjectReference{Name: "app-config"}}}},
+				},
+			},
+		},
+		"spec with priority and runtime class": {
+			builder: Spec(container.Name("container-name")).PriorityClassName("high-priority").RuntimeClassName("gvisor"),
+			expected: coreV1.PodSpec{
+				Containers:        []coreV1.Container{{Name: "container-name"}},
+				PriorityClassName: "high-priority",
+				RuntimeClassName:  strPtr("gvisor"),
+			},
+		},
+		"spec with init containers": {
+			builder: Spec(container.Name("container-name")).InitContainers(container.Name("init-1")).AddInitContainer(container.Name("init-2")).AddContainer(container.Name("sidecar")),
+			expected: coreV1.PodSpec{
+				Containers:     []coreV1.Container{{Name: "container-name"}, {Name: "sidecar"}},
+				InitContainers: []coreV1.Container{{Name: "init-1"}, {Name: "init-2"}},
+			},
+		},
+		"generated setters": {
+			builder: Spec(container.Name("container-name")).DNSPolicy(coreV1.DNSClusterFirst).ActiveDeadlineSeconds(30).
+				HostNetwork(true).AddImagePullSecret(coreV1.LocalObjectReference{Name: "registry"}).
+				NodeSelector(map[string]string{"disk": "ssd"}).MergeNodeSelector(map[string]string{"zone": "a"}),
+			expected: coreV1.PodSpec{
+				Containers:            []coreV1.Container{{Name: "container-name"}},
+				DNSPolicy:             coreV1.DNSClusterFirst,
+				ActiveDeadlineSeconds: int64Ptr(30),
+				HostNetwork:           true,
+				ImagePullSecrets:      []coreV1.LocalObjectReference{{Name: "registry"}},
+				NodeSelector:          map[string]string{"disk": "ssd", "zone": "a"},
+			},
+		},
+		"security context builder and overhead": {
+			builder: Spec(container.Name("container-name")).
+				SecurityContext(SecurityContextBuilder{}.RunAsUser(1000).AddSupplementalGroup(2000)).
+				Overhead(coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("250m")}),
+			expected: coreV1.PodSpec{
+				Containers:      []coreV1.Container{{Name: "container-name"}},
+				SecurityContext: &coreV1.PodSecurityContext{RunAsUser: int64Ptr(1000), SupplementalGroups: []int64{2000}},
+				Overhead:        coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("250m")},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			spec, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to create typed value: %s", err)
+			}
+			if !reflect.DeepEqual(spec, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", spec, test.expected)
+			}
+		})
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestPodSpecFrom(t *testing.T) {
+	full := fullPod().Spec
+	fromString, err := SpecFromString(`{"containers": [{"name": "web", "image": "nginx"}], "restartPolicy": "Never"}`)
+	if err != nil {
+		t.Fatalf("failed to create builder: %s", err)
+	}
+	fromUnstructured, err := SpecFromUnstructured(map[string]any{"containers": []any{map[string]any{"name": "web"}}})
+	if err != nil {
+		t.Fatalf("failed to create builder: %s", err)
+	}
+
+	tests := map[string]struct {
+		builder  SpecBuilder
+		expected coreV1.PodSpec
+	}{
+		"from empty": {
+			builder:  SpecFrom(coreV1.PodSpec{}),
+			expected: coreV1.PodSpec{},
+		},
+		"from full": {
+			builder:  SpecFrom(full),
+			expected: full,
+		},
+		"from string": {
+			builder:  fromString,
+			expected: coreV1.PodSpec{Containers: []coreV1.Container{{Name: "web", Image: "nginx"}}, RestartPolicy: coreV1.RestartPolicyNever},
+		},
+		"from unstructured and modify": {
+			builder:  fromUnstructured.AddContainer(container.Name("sidecar")),
+			expected: coreV1.PodSpec{Containers: []coreV1.Container{{Name: "web"}, {Name: "sidecar"}}},
+		},
 	}
 
 	for name, test := range tests {
@@ -75,4 +184,95 @@ func TestPodSpecStructured(t *testing.T) {
 			}
 		})
 	}
+
+	if _, err := SpecFromString(`{"containers": "invalid"}`); err == nil {
+		t.Error("expecting error, got none")
+	}
+}
+
+func TestPodSpecContainers(t *testing.T) {
+	spec := Spec(container.Name("web").Image("nginx")).Volumes(Volume("data").EmptyDir())
+	containers, err := spec.Containers()
+	if err != nil {
+		t.Fatalf("failed to get containers: %s", err)
+	}
+	obj, err := spec.SetContainers(containers[0].AddEnv("MODE", "fast")).T()
+	if err != nil {
+		t.Fatalf("failed to create typed value: %s", err)
+	}
+	expected := coreV1.PodSpec{
+		Containers: []coreV1.Container{{Name: "web", Image: "nginx", Env: []coreV1.EnvVar{{Name: "MODE", Value: "fast"}}}},
+		Volumes:    []coreV1.Volume{{Name: "data", VolumeSource: coreV1.VolumeSource{EmptyDir: &coreV1.EmptyDirVolumeSource{}}}},
+	}
+	if !reflect.DeepEqual(obj, expected) {
+		t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", obj, expected)
+	}
+}
+
+func TestPodSpecFieldError(t *testing.T) {
+	invalid := make(chan int)
+	tests := map[string]SpecBuilder{
+		"set field":   Spec(container.Name("web")).setField("nodeName", invalid),
+		"add field":   Spec(container.Name("web")).addField("tolerations", invalid),
+		"merge field": Spec(container.Name("web")).mergeField("nodeSelector", invalid),
+	}
+
+	for name, spec := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := spec.T(); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
+
+func TestPodSpecSelectors(t *testing.T) {
+	web := &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+	db := &metaV1.LabelSelector{MatchExpressions: []metaV1.LabelSelectorRequirement{{Key: "app", Operator: metaV1.LabelSelectorOpIn, Values: []string{"db"}}}}
+	spec, err := Spec(container.Name("web")).
+		AddPodAffinity("topology.kubernetes.io/zone", selector.In("app", "db")).
+		AddPodAntiAffinity("kubernetes.io/hostname", selector.MatchLabels(map[string]string{"app": "web"})).
+		AddPodAntiAffinity("topology.kubernetes.io/zone", selector.In("app", "db")).
+		AddTopologySpread(1, "topology.kubernetes.io/zone", coreV1.DoNotSchedule, selector.MatchLabels(map[string]string{"app": "web"})).
+		T()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := coreV1.PodSpec{
+		Containers: []coreV1.Container{{Name: "web"}},
+		Affinity: &coreV1.Affinity{
+			PodAffinity: &coreV1.PodAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []coreV1.PodAffinityTerm{{LabelSelector: db, TopologyKey: "topology.kubernetes.io/zone"}},
+			},
+			PodAntiAffinity: &coreV1.PodAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []coreV1.PodAffinityTerm{
+					{LabelSelector: web, TopologyKey: "kubernetes.io/hostname"},
+					{LabelSelector: db, TopologyKey: "topology.kubernetes.io/zone"},
+				},
+			},
+		},
+		TopologySpreadConstraints: []coreV1.TopologySpreadConstraint{{
+			MaxSkew:           1,
+			TopologyKey:       "topology.kubernetes.io/zone",
+			WhenUnsatisfiable: coreV1.DoNotSchedule,
+			LabelSelector:     web,
+		}},
+	}
+	if !reflect.DeepEqual(spec, expected) {
+		t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", spec, expected)
+	}
+
+	invalid := selector.In("app")
+	tests := map[string]SpecBuilder{
+		"pod affinity":      Spec(container.Name("web")).AddPodAffinity("kubernetes.io/hostname", invalid),
+		"pod anti affinity": Spec(container.Name("web")).AddPodAntiAffinity("kubernetes.io/hostname", invalid),
+		"topology spread":   Spec(container.Name("web")).AddTopologySpread(1, "kubernetes.io/hostname", coreV1.ScheduleAnyway, invalid),
+	}
+	for name, spec := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := spec.T(); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
 }
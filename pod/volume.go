@@ -0,0 +1,61 @@
+package pod
+
+import (
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	coreV1 "k8s.io/api/core/v1"
+)
+
+// VolumeBuilder provides a way to build values of type coreV1.Volume
+type VolumeBuilder map[string]interface{}
+
+// Volume starts a new volume builder with the volume's name
+func Volume(name string) VolumeBuilder {
+	return VolumeBuilder{"name": name}
+}
+
+// U returns the unstructured value of the builder
+func (b VolumeBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b VolumeBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b VolumeBuilder) T() (coreV1.Volume, error) {
+	var vol coreV1.Volume
+	if err := unstruct.FromUnstructured(b, &vol); err != nil {
+		return coreV1.Volume{}, err
+	}
+	return vol, nil
+}
+
+// PersistentVolumeClaim sources the volume from the named claim
+func (b VolumeBuilder) PersistentVolumeClaim(claimName string, readOnly bool) VolumeBuilder {
+	src := map[string]interface{}{"claimName": claimName}
+	if readOnly {
+		src["readOnly"] = true
+	}
+	b["persistentVolumeClaim"] = src
+	return b
+}
+
+// ConfigMap sources the volume from the named config map
+func (b VolumeBuilder) ConfigMap(name string) VolumeBuilder {
+	b["configMap"] = map[string]interface{}{"name": name}
+	return b
+}
+
+// Secret sources the volume from the named secret
+func (b VolumeBuilder) Secret(name string) VolumeBuilder {
+	b["secret"] = map[string]interface{}{"secretName": name}
+	return b
+}
+
+// EmptyDir sources the volume from an empty directory that shares the pod's lifetime
+func (b VolumeBuilder) EmptyDir() VolumeBuilder {
+	b["emptyDir"] = map[string]interface{}{}
+	return b
+}
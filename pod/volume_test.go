@@ -0,0 +1,48 @@
+package pod
+
+import (
+	"reflect"
+	"testing"
+
+	coreV1 "k8s.io/api/core/v1"
+)
+
+func TestVolumeStructured(t *testing.T) {
+	tests := map[string]struct {
+		builder  VolumeBuilder
+		expected coreV1.Volume
+	}{
+		"empty": {
+			builder:  VolumeBuilder{},
+			expected: coreV1.Volume{},
+		},
+		"name only": {
+			builder:  Volume("data"),
+			expected: coreV1.Volume{Name: "data"},
+		},
+		"persistent volume claim": {
+			builder:  Volume("data").PersistentVolumeClaim("data-claim", true),
+			expected: coreV1.Volume{Name: "data", VolumeSource: coreV1.VolumeSource{PersistentVolumeClaim: &coreV1.PersistentVolumeClaimVolumeSource{ClaimName: "data-claim", ReadOnly: true}}},
+		},
+		"secret": {
+			builder:  Volume("certs").Secret("tls-certs"),
+			expected: coreV1.Volume{Name: "certs", VolumeSource: coreV1.VolumeSource{Secret: &coreV1.SecretVolumeSource{SecretName: "tls-certs"}}},
+		},
+		"empty dir": {
+			builder:  Volume("scratch").EmptyDir(),
+			expected: coreV1.Volume{Name: "scratch", VolumeSource: coreV1.VolumeSource{EmptyDir: &coreV1.EmptyDirVolumeSource{}}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			vol, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(vol, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", vol, test.expected)
+			}
+		})
+	}
+}
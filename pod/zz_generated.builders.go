@@ -0,0 +1,523 @@
+// Code generated by kob-builder-gen. DO NOT EDIT.
+
+package pod
+
+import (
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// EphemeralContainers sets the ephemeralContainers field
+func (b SpecBuilder) EphemeralContainers(vals ...coreV1.EphemeralContainer) SpecBuilder {
+	return b.setField("ephemeralContainers", vals)
+}
+
+// AddEphemeralContainer adds v to the ephemeralContainers field
+func (b SpecBuilder) AddEphemeralContainer(v coreV1.EphemeralContainer) SpecBuilder {
+	return b.addField("ephemeralContainers", v)
+}
+
+// ActiveDeadlineSeconds sets the activeDeadlineSeconds field
+func (b SpecBuilder) ActiveDeadlineSeconds(v int64) SpecBuilder {
+	return b.setField("activeDeadlineSeconds", v)
+}
+
+// DNSPolicy sets the dnsPolicy field
+func (b SpecBuilder) DNSPolicy(v coreV1.DNSPolicy) SpecBuilder {
+	return b.setField("dnsPolicy", v)
+}
+
+// MergeNodeSelector adds the entries of m to the nodeSelector field, replacing existing keys
+func (b SpecBuilder) MergeNodeSelector(m map[string]string) SpecBuilder {
+	return b.mergeField("nodeSelector", m)
+}
+
+// AutomountServiceAccountToken sets the automountServiceAccountToken field
+func (b SpecBuilder) AutomountServiceAccountToken(v bool) SpecBuilder {
+	return b.setField("automountServiceAccountToken", v)
+}
+
+// NodeName sets the nodeName field
+func (b SpecBuilder) NodeName(v string) SpecBuilder {
+	return b.setField("nodeName", v)
+}
+
+// HostNetwork sets the hostNetwork field
+func (b SpecBuilder) HostNetwork(v bool) SpecBuilder {
+	return b.setField("hostNetwork", v)
+}
+
+// HostPID sets the hostPID field
+func (b SpecBuilder) HostPID(v bool) SpecBuilder {
+	return b.setField("hostPID", v)
+}
+
+// HostIPC sets the hostIPC field
+func (b SpecBuilder) HostIPC(v bool) SpecBuilder {
+	return b.setField("hostIPC", v)
+}
+
+// ShareProcessNamespace sets the shareProcessNamespace field
+func (b SpecBuilder) ShareProcessNamespace(v bool) SpecBuilder {
+	return b.setField("shareProcessNamespace", v)
+}
+
+// SecurityContext sets the securityContext field to the value built by v
+func (b SpecBuilder) SecurityContext(v SecurityContextBuilder) SpecBuilder {
+	return b.setField("securityContext", v)
+}
+
+// ImagePullSecrets sets the imagePullSecrets field
+func (b SpecBuilder) ImagePullSecrets(vals ...coreV1.LocalObjectReference) SpecBuilder {
+	return b.setField("imagePullSecrets", vals)
+}
+
+// AddImagePullSecret adds v to the imagePullSecrets field
+func (b SpecBuilder) AddImagePullSecret(v coreV1.LocalObjectReference) SpecBuilder {
+	return b.addField("imagePullSecrets", v)
+}
+
+// Hostname sets the hostname field
+func (b SpecBuilder) Hostname(v string) SpecBuilder {
+	return b.setField("hostname", v)
+}
+
+// Subdomain sets the subdomain field
+func (b SpecBuilder) Subdomain(v string) SpecBuilder {
+	return b.setField("subdomain", v)
+}
+
+// SchedulerName sets the schedulerName field
+func (b SpecBuilder) SchedulerName(v string) SpecBuilder {
+	return b.setField("schedulerName", v)
+}
+
+// AddToleration adds v to the tolerations field
+func (b SpecBuilder) AddToleration(v coreV1.Toleration) SpecBuilder {
+	return b.addField("tolerations", v)
+}
+
+// HostAliases sets the hostAliases field
+func (b SpecBuilder) HostAliases(vals ...coreV1.HostAlias) SpecBuilder {
+	return b.setField("hostAliases", vals)
+}
+
+// AddHostAlias adds v to the hostAliases field
+func (b SpecBuilder) AddHostAlias(v coreV1.HostAlias) SpecBuilder {
+	return b.addField("hostAliases", v)
+}
+
+// Priority sets the priority field
+func (b SpecBuilder) Priority(v int32) SpecBuilder {
+	return b.setField("priority", v)
+}
+
+// DNSConfig sets the dnsConfig field
+func (b SpecBuilder) DNSConfig(v coreV1.PodDNSConfig) SpecBuilder {
+	return b.setField("dnsConfig", v)
+}
+
+// ReadinessGates sets the readinessGates field
+func (b SpecBuilder) ReadinessGates(vals ...coreV1.PodReadinessGate) SpecBuilder {
+	return b.setField("readinessGates", vals)
+}
+
+// AddReadinessGate adds v to the readinessGates field
+func (b SpecBuilder) AddReadinessGate(v coreV1.PodReadinessGate) SpecBuilder {
+	return b.addField("readinessGates", v)
+}
+
+// EnableServiceLinks sets the enableServiceLinks field
+func (b SpecBuilder) EnableServiceLinks(v bool) SpecBuilder {
+	return b.setField("enableServiceLinks", v)
+}
+
+// PreemptionPolicy sets the preemptionPolicy field
+func (b SpecBuilder) PreemptionPolicy(v coreV1.PreemptionPolicy) SpecBuilder {
+	return b.setField("preemptionPolicy", v)
+}
+
+// Overhead sets the overhead field
+func (b SpecBuilder) Overhead(m coreV1.ResourceList) SpecBuilder {
+	return b.setField("overhead", m)
+}
+
+// MergeOverhead adds the entries of m to the overhead field, replacing existing keys
+func (b SpecBuilder) MergeOverhead(m coreV1.ResourceList) SpecBuilder {
+	return b.mergeField("overhead", m)
+}
+
+// TopologySpreadConstraints sets the topologySpreadConstraints field
+func (b SpecBuilder) TopologySpreadConstraints(vals ...coreV1.TopologySpreadConstraint) SpecBuilder {
+	return b.setField("topologySpreadConstraints", vals)
+}
+
+// AddTopologySpreadConstraint adds v to the topologySpreadConstraints field
+func (b SpecBuilder) AddTopologySpreadConstraint(v coreV1.TopologySpreadConstraint) SpecBuilder {
+	return b.addField("topologySpreadConstraints", v)
+}
+
+// SetHostnameAsFQDN sets the setHostnameAsFQDN field
+func (b SpecBuilder) SetHostnameAsFQDN(v bool) SpecBuilder {
+	return b.setField("setHostnameAsFQDN", v)
+}
+
+// OS sets the os field
+func (b SpecBuilder) OS(v coreV1.PodOS) SpecBuilder {
+	return b.setField("os", v)
+}
+
+// HostUsers sets the hostUsers field
+func (b SpecBuilder) HostUsers(v bool) SpecBuilder {
+	return b.setField("hostUsers", v)
+}
+
+// SchedulingGates sets the schedulingGates field
+func (b SpecBuilder) SchedulingGates(vals ...coreV1.PodSchedulingGate) SpecBuilder {
+	return b.setField("schedulingGates", vals)
+}
+
+// AddSchedulingGate adds v to the schedulingGates field
+func (b SpecBuilder) AddSchedulingGate(v coreV1.PodSchedulingGate) SpecBuilder {
+	return b.addField("schedulingGates", v)
+}
+
+// ResourceClaims sets the resourceClaims field
+func (b SpecBuilder) ResourceClaims(vals ...coreV1.PodResourceClaim) SpecBuilder {
+	return b.setField("resourceClaims", vals)
+}
+
+// AddResourceClaim adds v to the resourceClaims field
+func (b SpecBuilder) AddResourceClaim(v coreV1.PodResourceClaim) SpecBuilder {
+	return b.addField("resourceClaims", v)
+}
+
+// SecurityContextBuilder provides a way to build values of type coreV1.PodSecurityContext
+type SecurityContextBuilder struct {
+	obj coreV1.PodSecurityContext
+}
+
+// SecurityContextFrom creates a new builder using the provided object
+func SecurityContextFrom(obj coreV1.PodSecurityContext) SecurityContextBuilder {
+	return SecurityContextBuilder{obj: obj}
+}
+
+// U returns an unstructured value of builder's object
+func (b SecurityContextBuilder) U() (map[string]any, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&b.obj)
+	if err != nil {
+		return nil, err
+	}
+	return unstruct.Prune(u), nil
+}
+
+// T returns a typed value of builder's object
+func (b SecurityContextBuilder) T() coreV1.PodSecurityContext {
+	return b.obj
+}
+
+// SELinuxOptions sets the seLinuxOptions field
+func (b SecurityContextBuilder) SELinuxOptions(v coreV1.SELinuxOptions) SecurityContextBuilder {
+	b.obj.SELinuxOptions = &v
+	return b
+}
+
+// WindowsOptions sets the windowsOptions field
+func (b SecurityContextBuilder) WindowsOptions(v coreV1.WindowsSecurityContextOptions) SecurityContextBuilder {
+	b.obj.WindowsOptions = &v
+	return b
+}
+
+// RunAsUser sets the runAsUser field
+func (b SecurityContextBuilder) RunAsUser(v int64) SecurityContextBuilder {
+	b.obj.RunAsUser = &v
+	return b
+}
+
+// RunAsGroup sets the runAsGroup field
+func (b SecurityContextBuilder) RunAsGroup(v int64) SecurityContextBuilder {
+	b.obj.RunAsGroup = &v
+	return b
+}
+
+// RunAsNonRoot sets the runAsNonRoot field
+func (b SecurityContextBuilder) RunAsNonRoot(v bool) SecurityContextBuilder {
+	b.obj.RunAsNonRoot = &v
+	return b
+}
+
+// SupplementalGroups sets the supplementalGroups field
+func (b SecurityContextBuilder) SupplementalGroups(vals ...int64) SecurityContextBuilder {
+	b.obj.SupplementalGroups = vals
+	return b
+}
+
+// AddSupplementalGroup adds v to the supplementalGroups field
+func (b SecurityContextBuilder) AddSupplementalGroup(v int64) SecurityContextBuilder {
+	b.obj.SupplementalGroups = append(b.obj.SupplementalGroups, v)
+	return b
+}
+
+// FSGroup sets the fsGroup field
+func (b SecurityContextBuilder) FSGroup(v int64) SecurityContextBuilder {
+	b.obj.FSGroup = &v
+	return b
+}
+
+// Sysctls sets the sysctls field
+func (b SecurityContextBuilder) Sysctls(vals ...coreV1.Sysctl) SecurityContextBuilder {
+	b.obj.Sysctls = vals
+	return b
+}
+
+// AddSysctl adds v to the sysctls field
+func (b SecurityContextBuilder) AddSysctl(v coreV1.Sysctl) SecurityContextBuilder {
+	b.obj.Sysctls = append(b.obj.Sysctls, v)
+	return b
+}
+
+// FSGroupChangePolicy sets the fsGroupChangePolicy field
+func (b SecurityContextBuilder) FSGroupChangePolicy(v coreV1.PodFSGroupChangePolicy) SecurityContextBuilder {
+	b.obj.FSGroupChangePolicy = &v
+	return b
+}
+
+// SeccompProfile sets the seccompProfile field
+func (b SecurityContextBuilder) SeccompProfile(v coreV1.SeccompProfile) SecurityContextBuilder {
+	b.obj.SeccompProfile = &v
+	return b
+}
+
+// EphemeralContainers sets the ephemeralContainers field of the spec
+func (b Builder) EphemeralContainers(vals ...coreV1.EphemeralContainer) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.EphemeralContainers(vals...) })
+}
+
+// AddEphemeralContainer adds v to the ephemeralContainers field of the spec
+func (b Builder) AddEphemeralContainer(v coreV1.EphemeralContainer) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.AddEphemeralContainer(v) })
+}
+
+// ActiveDeadlineSeconds sets the activeDeadlineSeconds field of the spec
+func (b Builder) ActiveDeadlineSeconds(v int64) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.ActiveDeadlineSeconds(v) })
+}
+
+// DNSPolicy sets the dnsPolicy field of the spec
+func (b Builder) DNSPolicy(v coreV1.DNSPolicy) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.DNSPolicy(v) })
+}
+
+// MergeNodeSelector adds the entries of m to the nodeSelector field of the spec, replacing existing keys
+func (b Builder) MergeNodeSelector(m map[string]string) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.MergeNodeSelector(m) })
+}
+
+// AutomountServiceAccountToken sets the automountServiceAccountToken field of the spec
+func (b Builder) AutomountServiceAccountToken(v bool) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.AutomountServiceAccountToken(v) })
+}
+
+// NodeName sets the nodeName field of the spec
+func (b Builder) NodeName(v string) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.NodeName(v) })
+}
+
+// HostNetwork sets the hostNetwork field of the spec
+func (b Builder) HostNetwork(v bool) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.HostNetwork(v) })
+}
+
+// HostPID sets the hostPID field of the spec
+func (b Builder) HostPID(v bool) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.HostPID(v) })
+}
+
+// HostIPC sets the hostIPC field of the spec
+func (b Builder) HostIPC(v bool) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.HostIPC(v) })
+}
+
+// ShareProcessNamespace sets the shareProcessNamespace field of the spec
+func (b Builder) ShareProcessNamespace(v bool) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.ShareProcessNamespace(v) })
+}
+
+// SecurityContext sets the securityContext field of the spec to the value built by v
+func (b Builder) SecurityContext(v SecurityContextBuilder) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.SecurityContext(v) })
+}
+
+// ImagePullSecrets sets the imagePullSecrets field of the spec
+func (b Builder) ImagePullSecrets(vals ...coreV1.LocalObjectReference) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.ImagePullSecrets(vals...) })
+}
+
+// AddImagePullSecret adds v to the imagePullSecrets field of the spec
+func (b Builder) AddImagePullSecret(v coreV1.LocalObjectReference) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.AddImagePullSecret(v) })
+}
+
+// Hostname sets the hostname field of the spec
+func (b Builder) Hostname(v string) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.Hostname(v) })
+}
+
+// Subdomain sets the subdomain field of the spec
+func (b Builder) Subdomain(v string) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.Subdomain(v) })
+}
+
+// SchedulerName sets the schedulerName field of the spec
+func (b Builder) SchedulerName(v string) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.SchedulerName(v) })
+}
+
+// AddToleration adds v to the tolerations field of the spec
+func (b Builder) AddToleration(v coreV1.Toleration) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.AddToleration(v) })
+}
+
+// HostAliases sets the hostAliases field of the spec
+func (b Builder) HostAliases(vals ...coreV1.HostAlias) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.HostAliases(vals...) })
+}
+
+// AddHostAlias adds v to the hostAliases field of the spec
+func (b Builder) AddHostAlias(v coreV1.HostAlias) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.AddHostAlias(v) })
+}
+
+// Priority sets the priority field of the spec
+func (b Builder) Priority(v int32) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.Priority(v) })
+}
+
+// DNSConfig sets the dnsConfig field of the spec
+func (b Builder) DNSConfig(v coreV1.PodDNSConfig) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.DNSConfig(v) })
+}
+
+// ReadinessGates sets the readinessGates field of the spec
+func (b Builder) ReadinessGates(vals ...coreV1.PodReadinessGate) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.ReadinessGates(vals...) })
+}
+
+// AddReadinessGate adds v to the readinessGates field of the spec
+func (b Builder) AddReadinessGate(v coreV1.PodReadinessGate) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.AddReadinessGate(v) })
+}
+
+// EnableServiceLinks sets the enableServiceLinks field of the spec
+func (b Builder) EnableServiceLinks(v bool) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.EnableServiceLinks(v) })
+}
+
+// PreemptionPolicy sets the preemptionPolicy field of the spec
+func (b Builder) PreemptionPolicy(v coreV1.PreemptionPolicy) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.PreemptionPolicy(v) })
+}
+
+// Overhead sets the overhead field of the spec
+func (b Builder) Overhead(m coreV1.ResourceList) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.Overhead(m) })
+}
+
+// MergeOverhead adds the entries of m to the overhead field of the spec, replacing existing keys
+func (b Builder) MergeOverhead(m coreV1.ResourceList) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.MergeOverhead(m) })
+}
+
+// TopologySpreadConstraints sets the topologySpreadConstraints field of the spec
+func (b Builder) TopologySpreadConstraints(vals ...coreV1.TopologySpreadConstraint) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.TopologySpreadConstraints(vals...) })
+}
+
+// AddTopologySpreadConstraint adds v to the topologySpreadConstraints field of the spec
+func (b Builder) AddTopologySpreadConstraint(v coreV1.TopologySpreadConstraint) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.AddTopologySpreadConstraint(v) })
+}
+
+// SetHostnameAsFQDN sets the setHostnameAsFQDN field of the spec
+func (b Builder) SetHostnameAsFQDN(v bool) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.SetHostnameAsFQDN(v) })
+}
+
+// OS sets the os field of the spec
+func (b Builder) OS(v coreV1.PodOS) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.OS(v) })
+}
+
+// HostUsers sets the hostUsers field of the spec
+func (b Builder) HostUsers(v bool) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.HostUsers(v) })
+}
+
+// SchedulingGates sets the schedulingGates field of the spec
+func (b Builder) SchedulingGates(vals ...coreV1.PodSchedulingGate) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.SchedulingGates(vals...) })
+}
+
+// AddSchedulingGate adds v to the schedulingGates field of the spec
+func (b Builder) AddSchedulingGate(v coreV1.PodSchedulingGate) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.AddSchedulingGate(v) })
+}
+
+// ResourceClaims sets the resourceClaims field of the spec
+func (b Builder) ResourceClaims(vals ...coreV1.PodResourceClaim) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.ResourceClaims(vals...) })
+}
+
+// AddResourceClaim adds v to the resourceClaims field of the spec
+func (b Builder) AddResourceClaim(v coreV1.PodResourceClaim) Builder {
+	return b.withSpec(func(spec SpecBuilder) SpecBuilder { return spec.AddResourceClaim(v) })
+}
+
+// setField sets the key to the unstructured value of val, an error
+// converting val is recorded and returned by T
+func (b SpecBuilder) setField(key string, val any) SpecBuilder {
+	u, err := unstruct.JSONValue(val)
+	if err != nil {
+		unstruct.SetErr(b, err)
+		return b
+	}
+	b[key] = u
+	return b
+}
+
+// addField appends the unstructured value of val to the slice at key, an
+// error converting val is recorded and returned by T
+func (b SpecBuilder) addField(key string, val any) SpecBuilder {
+	u, err := unstruct.JSONValue(val)
+	if err != nil {
+		unstruct.SetErr(b, err)
+		return b
+	}
+	slice, _ := b[key].([]interface{})
+	b[key] = append(slice, u)
+	return b
+}
+
+// mergeField adds the entries of the unstructured value of val to the map at
+// key, an error converting val is recorded and returned by T
+func (b SpecBuilder) mergeField(key string, val any) SpecBuilder {
+	u, err := unstruct.JSONValue(val)
+	if err != nil {
+		unstruct.SetErr(b, err)
+		return b
+	}
+	merged := map[string]interface{}{}
+	if m, ok := b[key].(map[string]interface{}); ok {
+		for k, v := range m {
+			merged[k] = v
+		}
+	}
+	if m, ok := u.(map[string]interface{}); ok {
+		for k, v := range m {
+			merged[k] = v
+		}
+	}
+	b[key] = merged
+	return b
+}
@@ -0,0 +1,83 @@
+// Package priorityclass contains builder types to build values of type schedulingV1.PriorityClass
+package priorityclass
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	coreV1 "k8s.io/api/core/v1"
+	schedulingV1 "k8s.io/api/scheduling/v1"
+)
+
+// HighestUserDefinable is the highest priority value of classes not prefixed with system-
+const HighestUserDefinable int32 = 1000000000
+
+// Builder provides a way to build values of type schedulingV1.PriorityClass
+type Builder map[string]interface{}
+
+// Object starts a new PriorityClass builder using the provided object metadata and priority value
+func Object(metadata objmeta.Builder, value int32) Builder {
+	meta, err := metadata.U()
+	return Builder(unstruct.SetErr(map[string]interface{}{"metadata": meta, "value": int64(value)}, err))
+}
+
+// U returns the unstructured value of the builder
+func (b Builder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b Builder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder. An error is returned if the
+// value is above HighestUserDefinable for a class not prefixed with system-.
+func (b Builder) T() (schedulingV1.PriorityClass, error) {
+	var class schedulingV1.PriorityClass
+	if err := unstruct.FromUnstructured(b, &class); err != nil {
+		return schedulingV1.PriorityClass{}, err
+	}
+	if err := naming.Validate("PriorityClass", class.Name); err != nil {
+		return schedulingV1.PriorityClass{}, err
+	}
+	if class.Value > HighestUserDefinable && !strings.HasPrefix(class.Name, "system-") {
+		return schedulingV1.PriorityClass{}, fmt.Errorf("priorityclass: %s value %d is above the highest user definable priority %d", class.Name, class.Value, HighestUserDefinable)
+	}
+	return class, nil
+}
+
+// Value sets the priority of pods using the class, higher values being scheduled first
+func (b Builder) Value(value int32) Builder {
+	b["value"] = int64(value)
+	return b
+}
+
+// GlobalDefault sets whether the class is used by pods without a priority class name
+func (b Builder) GlobalDefault(def bool) Builder {
+	b["globalDefault"] = def
+	return b
+}
+
+// PreemptionPolicy sets whether pods using the class preempt lower priority pods
+// (PreemptLowerPriority or Never)
+func (b Builder) PreemptionPolicy(policy coreV1.PreemptionPolicy) Builder {
+	b["preemptionPolicy"] = string(policy)
+	return b
+}
+
+// Description sets when the class should be used
+func (b Builder) Description(desc string) Builder {
+	b["description"] = desc
+	return b
+}
+
+// Name returns the name of the class, as referenced by pod specs
+func (b Builder) Name() string {
+	meta, _ := b["metadata"].(map[string]interface{})
+	name, _ := meta["name"].(string)
+	return name
+}
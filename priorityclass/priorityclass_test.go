@@ -0,0 +1,61 @@
+package priorityclass
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/objmeta"
+	coreV1 "k8s.io/api/core/v1"
+	schedulingV1 "k8s.io/api/scheduling/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPriorityClassTyped(t *testing.T) {
+	never := coreV1.PreemptNever
+	tests := map[string]struct {
+		builder  Builder
+		expected schedulingV1.PriorityClass
+	}{
+		"empty": {
+			builder:  Builder{},
+			expected: schedulingV1.PriorityClass{},
+		},
+		"value only": {
+			builder:  Object(objmeta.Name("high-priority"), 1000),
+			expected: schedulingV1.PriorityClass{ObjectMeta: metaV1.ObjectMeta{Name: "high-priority"}, Value: 1000},
+		},
+		"all fields": {
+			builder: Object(objmeta.Name("batch"), 0).Value(-10).GlobalDefault(true).
+				PreemptionPolicy(coreV1.PreemptNever).Description("batch jobs"),
+			expected: schedulingV1.PriorityClass{
+				ObjectMeta:       metaV1.ObjectMeta{Name: "batch"},
+				Value:            -10,
+				GlobalDefault:    true,
+				PreemptionPolicy: &never,
+				Description:      "batch jobs",
+			},
+		},
+		"system class above user range": {
+			builder:  Object(objmeta.Name("system-platform-critical"), 1500000000),
+			expected: schedulingV1.PriorityClass{ObjectMeta: metaV1.ObjectMeta{Name: "system-platform-critical"}, Value: 1500000000},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			class, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(class, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", class, test.expected)
+			}
+		})
+	}
+}
+
+func TestPriorityClassInvalid(t *testing.T) {
+	if _, err := Object(objmeta.Name("too-high"), HighestUserDefinable+1).T(); err == nil {
+		t.Error("expecting error, got none")
+	}
+}
@@ -0,0 +1,137 @@
+package rbac
+
+import (
+	"fmt"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	rbacV1 "k8s.io/api/rbac/v1"
+)
+
+// RoleBindingBuilder provides a way to build values of type rbacV1.RoleBinding
+type RoleBindingBuilder map[string]interface{}
+
+// RoleBinding starts a new namespaced RoleBinding builder using the provided object metadata
+func RoleBinding(metadata objmeta.Builder) RoleBindingBuilder {
+	meta, err := metadata.U()
+	return RoleBindingBuilder(unstruct.SetErr(map[string]interface{}{"metadata": meta}, err))
+}
+
+// U returns the unstructured value of the builder
+func (b RoleBindingBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b RoleBindingBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b RoleBindingBuilder) T() (rbacV1.RoleBinding, error) {
+	var binding rbacV1.RoleBinding
+	if err := unstruct.FromUnstructured(b, &binding); err != nil {
+		return rbacV1.RoleBinding{}, err
+	}
+	if err := naming.Validate("RoleBinding", binding.Name); err != nil {
+		return rbacV1.RoleBinding{}, err
+	}
+	return binding, nil
+}
+
+// RoleRef sets the referenced role by kind (Role or ClusterRole) and name
+func (b RoleBindingBuilder) RoleRef(kind, name string) RoleBindingBuilder {
+	b["roleRef"] = roleRef(kind, name)
+	return b
+}
+
+// Role sets the referenced role to the role built by role. An error
+// recorded on the role is recorded on the binding.
+func (b RoleBindingBuilder) Role(role RoleBuilder) RoleBindingBuilder {
+	name, _ := nameAndNamespace(role)
+	unstruct.SetErr(b, roleErr("role", name, role))
+	return b.RoleRef("Role", name)
+}
+
+// ClusterRole sets the referenced role to the cluster role built by role,
+// granting its permissions within the binding's namespace only. An error
+// recorded on the cluster role is recorded on the binding.
+func (b RoleBindingBuilder) ClusterRole(role ClusterRoleBuilder) RoleBindingBuilder {
+	name, _ := nameAndNamespace(role)
+	unstruct.SetErr(b, roleErr("cluster role", name, role))
+	return b.RoleRef("ClusterRole", name)
+}
+
+// Subjects sets the subjects bound to the role
+func (b RoleBindingBuilder) Subjects(subjects ...SubjectBuilder) RoleBindingBuilder {
+	b["subjects"] = subjectSlice(subjects)
+	return b
+}
+
+// ClusterRoleBindingBuilder provides a way to build values of type rbacV1.ClusterRoleBinding
+type ClusterRoleBindingBuilder map[string]interface{}
+
+// ClusterRoleBinding starts a new ClusterRoleBinding builder using the provided object metadata
+func ClusterRoleBinding(metadata objmeta.Builder) ClusterRoleBindingBuilder {
+	meta, err := metadata.U()
+	return ClusterRoleBindingBuilder(unstruct.SetErr(map[string]interface{}{"metadata": meta}, err))
+}
+
+// U returns the unstructured value of the builder
+func (b ClusterRoleBindingBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b ClusterRoleBindingBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b ClusterRoleBindingBuilder) T() (rbacV1.ClusterRoleBinding, error) {
+	var binding rbacV1.ClusterRoleBinding
+	if err := unstruct.FromUnstructured(b, &binding); err != nil {
+		return rbacV1.ClusterRoleBinding{}, err
+	}
+	if err := naming.Validate("ClusterRoleBinding", binding.Name); err != nil {
+		return rbacV1.ClusterRoleBinding{}, err
+	}
+	return binding, nil
+}
+
+// ClusterRole sets the referenced cluster role to the role built by role.
+// An error recorded on the cluster role is recorded on the binding.
+func (b ClusterRoleBindingBuilder) ClusterRole(role ClusterRoleBuilder) ClusterRoleBindingBuilder {
+	name, _ := nameAndNamespace(role)
+	unstruct.SetErr(b, roleErr("cluster role", name, role))
+	b["roleRef"] = roleRef("ClusterRole", name)
+	return b
+}
+
+// Subjects sets the subjects bound to the cluster role
+func (b ClusterRoleBindingBuilder) Subjects(subjects ...SubjectBuilder) ClusterRoleBindingBuilder {
+	b["subjects"] = subjectSlice(subjects)
+	return b
+}
+
+func roleRef(kind, name string) map[string]interface{} {
+	return map[string]interface{}{"apiGroup": rbacV1.GroupName, "kind": kind, "name": name}
+}
+
+// roleErr returns the error recorded on the referenced role, if any
+func roleErr(kind, name string, role map[string]interface{}) error {
+	if err := unstruct.Err(role); err != nil {
+		return fmt.Errorf("rbac: %s %q: %w", kind, name, err)
+	}
+	return nil
+}
+
+// nameAndNamespace reads the object name and namespace directly from the
+// unstructured metadata, so that roles with invalid rules can still be referenced
+func nameAndNamespace(obj map[string]interface{}) (string, string) {
+	meta, _ := obj["metadata"].(map[string]interface{})
+	name, _ := meta["name"].(string)
+	ns, _ := meta["namespace"].(string)
+	return name, ns
+}
@@ -0,0 +1,82 @@
+package rbac
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/objmeta"
+	rbacV1 "k8s.io/api/rbac/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRoleBindingTyped(t *testing.T) {
+	tests := map[string]struct {
+		builder  RoleBindingBuilder
+		expected rbacV1.RoleBinding
+	}{
+		"empty": {
+			builder:  RoleBindingBuilder{},
+			expected: rbacV1.RoleBinding{},
+		},
+		"role ref": {
+			builder: RoleBinding(objmeta.Name("read-pods").Namespace("default")).RoleRef("Role", "pod-reader").Subjects(User("jane")),
+			expected: rbacV1.RoleBinding{
+				ObjectMeta: metaV1.ObjectMeta{Name: "read-pods", Namespace: "default"},
+				RoleRef:    rbacV1.RoleRef{APIGroup: rbacV1.GroupName, Kind: "Role", Name: "pod-reader"},
+				Subjects:   []rbacV1.Subject{{Kind: rbacV1.UserKind, APIGroup: rbacV1.GroupName, Name: "jane"}},
+			},
+		},
+		"cluster role in namespace": {
+			builder: RoleBinding(objmeta.Name("view").Namespace("default")).ClusterRole(ClusterRole(objmeta.Name("view"))).Subjects(Group("devs")),
+			expected: rbacV1.RoleBinding{
+				ObjectMeta: metaV1.ObjectMeta{Name: "view", Namespace: "default"},
+				RoleRef:    rbacV1.RoleRef{APIGroup: rbacV1.GroupName, Kind: "ClusterRole", Name: "view"},
+				Subjects:   []rbacV1.Subject{{Kind: rbacV1.GroupKind, APIGroup: rbacV1.GroupName, Name: "devs"}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			binding, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(binding, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", binding, test.expected)
+			}
+		})
+	}
+}
+
+func TestClusterRoleBindingTyped(t *testing.T) {
+	tests := map[string]struct {
+		builder  ClusterRoleBindingBuilder
+		expected rbacV1.ClusterRoleBinding
+	}{
+		"empty": {
+			builder:  ClusterRoleBindingBuilder{},
+			expected: rbacV1.ClusterRoleBinding{},
+		},
+		"cluster role": {
+			builder: ClusterRoleBinding(objmeta.Name("health")).ClusterRole(ClusterRole(objmeta.Name("health-reader"))).Subjects(ServiceAccount("probe", "monitoring")),
+			expected: rbacV1.ClusterRoleBinding{
+				ObjectMeta: metaV1.ObjectMeta{Name: "health"},
+				RoleRef:    rbacV1.RoleRef{APIGroup: rbacV1.GroupName, Kind: "ClusterRole", Name: "health-reader"},
+				Subjects:   []rbacV1.Subject{{Kind: rbacV1.ServiceAccountKind, Name: "probe", Namespace: "monitoring"}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			binding, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(binding, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", binding, test.expected)
+			}
+		})
+	}
+}
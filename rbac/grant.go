@@ -0,0 +1,43 @@
+package rbac
+
+import (
+	"fmt"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	"github.com/vladimirvivien/kob/serviceaccount"
+)
+
+// Grant creates a RoleBinding that binds the service account to the role.
+// The binding is named <role>-<service account>-<hash> (see naming.Name) and
+// is created in the namespace of the role. An invalid service account, or an
+// error recorded on the role, is recorded as an error returned by T.
+func Grant(sa serviceaccount.Builder, role RoleBuilder) RoleBindingBuilder {
+	saObj, err := sa.T()
+	if err != nil {
+		return RoleBindingBuilder(unstruct.SetErr(map[string]interface{}{}, fmt.Errorf("rbac: grant: %w", err)))
+	}
+	roleName, ns := nameAndNamespace(role)
+	if ns == "" {
+		ns = saObj.Namespace
+	}
+	return RoleBinding(objmeta.Name(naming.Name(roleName, saObj.Name)).Namespace(ns)).
+		Role(role).
+		Subjects(ServiceAccountFor(sa))
+}
+
+// GrantCluster creates a ClusterRoleBinding, named
+// <cluster role>-<service account>-<hash>, that binds the service account to
+// the cluster role. An invalid service account, or an error recorded on the
+// cluster role, is recorded as an error returned by T.
+func GrantCluster(sa serviceaccount.Builder, role ClusterRoleBuilder) ClusterRoleBindingBuilder {
+	saObj, err := sa.T()
+	if err != nil {
+		return ClusterRoleBindingBuilder(unstruct.SetErr(map[string]interface{}{}, fmt.Errorf("rbac: grant: %w", err)))
+	}
+	roleName, _ := nameAndNamespace(role)
+	return ClusterRoleBinding(objmeta.Name(naming.Name(roleName, saObj.Name))).
+		ClusterRole(role).
+		Subjects(ServiceAccountFor(sa))
+}
@@ -0,0 +1,119 @@
+package rbac
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	"github.com/vladimirvivien/kob/serviceaccount"
+	rbacV1 "k8s.io/api/rbac/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGrant(t *testing.T) {
+	sa := serviceaccount.Object(objmeta.Name("operator").Namespace("system"))
+	tests := map[string]struct {
+		builder  RoleBindingBuilder
+		expected rbacV1.RoleBinding
+	}{
+		"role in service account namespace": {
+			builder: Grant(sa, Role(objmeta.Name("leader-election").Namespace("system"))),
+			expected: rbacV1.RoleBinding{
+				ObjectMeta: metaV1.ObjectMeta{Name: naming.Name("leader-election", "operator"), Namespace: "system"},
+				RoleRef:    rbacV1.RoleRef{APIGroup: rbacV1.GroupName, Kind: "Role", Name: "leader-election"},
+				Subjects:   []rbacV1.Subject{{Kind: rbacV1.ServiceAccountKind, Name: "operator", Namespace: "system"}},
+			},
+		},
+		"role in another namespace": {
+			builder: Grant(sa, Role(objmeta.Name("config-reader").Namespace("apps"))),
+			expected: rbacV1.RoleBinding{
+				ObjectMeta: metaV1.ObjectMeta{Name: naming.Name("config-reader", "operator"), Namespace: "apps"},
+				RoleRef:    rbacV1.RoleRef{APIGroup: rbacV1.GroupName, Kind: "Role", Name: "config-reader"},
+				Subjects:   []rbacV1.Subject{{Kind: rbacV1.ServiceAccountKind, Name: "operator", Namespace: "system"}},
+			},
+		},
+		"role without namespace": {
+			builder: Grant(sa, Role(objmeta.Name("config-reader"))),
+			expected: rbacV1.RoleBinding{
+				ObjectMeta: metaV1.ObjectMeta{Name: naming.Name("config-reader", "operator"), Namespace: "system"},
+				RoleRef:    rbacV1.RoleRef{APIGroup: rbacV1.GroupName, Kind: "Role", Name: "config-reader"},
+				Subjects:   []rbacV1.Subject{{Kind: rbacV1.ServiceAccountKind, Name: "operator", Namespace: "system"}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			binding, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(binding, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", binding, test.expected)
+			}
+		})
+	}
+}
+
+func TestGrantCluster(t *testing.T) {
+	sa := serviceaccount.Object(objmeta.Name("operator").Namespace("system"))
+	binding, err := GrantCluster(sa, ClusterRole(objmeta.Name("crd-manager"))).T()
+	if err != nil {
+		t.Fatalf("failed to convert to typed value: %s", err)
+	}
+	expected := rbacV1.ClusterRoleBinding{
+		ObjectMeta: metaV1.ObjectMeta{Name: naming.Name("crd-manager", "operator")},
+		RoleRef:    rbacV1.RoleRef{APIGroup: rbacV1.GroupName, Kind: "ClusterRole", Name: "crd-manager"},
+		Subjects:   []rbacV1.Subject{{Kind: rbacV1.ServiceAccountKind, Name: "operator", Namespace: "system"}},
+	}
+	if !reflect.DeepEqual(binding, expected) {
+		t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", binding, expected)
+	}
+}
+
+func TestGrantLongNames(t *testing.T) {
+	sa := serviceaccount.Object(objmeta.Name(strings.Repeat("s", 200)).Namespace("system"))
+	role := strings.Repeat("r", 200)
+	binding, err := Grant(sa, Role(objmeta.Name(role))).T()
+	if err != nil {
+		t.Fatal(err)
+	}
+	clusterBinding, err := GrantCluster(sa, ClusterRole(objmeta.Name(role))).T()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{binding.Name, clusterBinding.Name} {
+		if len(name) > naming.MaxLength || !strings.HasPrefix(name, role[:naming.MaxLength-9]) {
+			t.Errorf("unexpected binding name %q", name)
+		}
+	}
+}
+
+func TestGrantInvalidServiceAccount(t *testing.T) {
+	sa := serviceaccount.Object(objmeta.Name("My_Operator").Namespace("system"))
+	if _, err := Grant(sa, Role(objmeta.Name("config-reader"))).T(); err == nil {
+		t.Error("expecting error, got none")
+	}
+	if _, err := GrantCluster(sa, ClusterRole(objmeta.Name("crd-manager"))).T(); err == nil {
+		t.Error("expecting error, got none")
+	}
+	if _, err := RoleBinding(objmeta.Name("operator")).Role(Role(objmeta.Name("config-reader"))).Subjects(ServiceAccountFor(sa)).T(); err == nil {
+		t.Error("expecting error, got none")
+	}
+}
+
+func TestGrantInvalidRole(t *testing.T) {
+	sa := serviceaccount.Object(objmeta.Name("operator").Namespace("system"))
+	meta := objmeta.Name("config-reader").AddLabel("app", "invalid value")
+	if _, err := Grant(sa, Role(meta)).T(); err == nil {
+		t.Error("expecting error, got none")
+	}
+	if _, err := GrantCluster(sa, ClusterRole(meta)).T(); err == nil {
+		t.Error("expecting error, got none")
+	}
+	if _, err := RoleBinding(objmeta.Name("operator")).ClusterRole(ClusterRole(meta)).T(); err == nil {
+		t.Error("expecting error, got none")
+	}
+}
@@ -0,0 +1,127 @@
+package rbac
+
+import (
+	"fmt"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	rbacV1 "k8s.io/api/rbac/v1"
+)
+
+// RoleBuilder provides a way to build values of type rbacV1.Role
+type RoleBuilder map[string]interface{}
+
+// Role starts a new namespaced Role builder using the provided object metadata
+func Role(metadata objmeta.Builder) RoleBuilder {
+	meta, err := metadata.U()
+	return RoleBuilder(unstruct.SetErr(map[string]interface{}{"metadata": meta}, err))
+}
+
+// U returns the unstructured value of the builder
+func (b RoleBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b RoleBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder. An error is returned if a rule
+// is invalid or uses non-resource URLs, which are only valid for ClusterRoles.
+func (b RoleBuilder) T() (rbacV1.Role, error) {
+	var role rbacV1.Role
+	if err := unstruct.FromUnstructured(b, &role); err != nil {
+		return rbacV1.Role{}, err
+	}
+	if err := naming.Validate("Role", role.Name); err != nil {
+		return rbacV1.Role{}, err
+	}
+	for _, rule := range role.Rules {
+		if err := validateRule(rule); err != nil {
+			return rbacV1.Role{}, err
+		}
+		if len(rule.NonResourceURLs) > 0 {
+			return rbacV1.Role{}, fmt.Errorf("rbac: role %s cannot use non-resource URLs", role.Name)
+		}
+	}
+	return role, nil
+}
+
+// Rules sets the policy rules of the role
+func (b RoleBuilder) Rules(rules ...PolicyRuleBuilder) RoleBuilder {
+	b["rules"] = ruleSlice(rules)
+	return b
+}
+
+// AddRule adds a policy rule to the role
+func (b RoleBuilder) AddRule(rule PolicyRuleBuilder) RoleBuilder {
+	rules, _ := b["rules"].([]interface{})
+	b["rules"] = append(rules, unstruct.Nest(rule))
+	return b
+}
+
+// ClusterRoleBuilder provides a way to build values of type rbacV1.ClusterRole
+type ClusterRoleBuilder map[string]interface{}
+
+// ClusterRole starts a new cluster-wide ClusterRole builder using the provided object metadata
+func ClusterRole(metadata objmeta.Builder) ClusterRoleBuilder {
+	meta, err := metadata.U()
+	return ClusterRoleBuilder(unstruct.SetErr(map[string]interface{}{"metadata": meta}, err))
+}
+
+// U returns the unstructured value of the builder
+func (b ClusterRoleBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b ClusterRoleBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder. An error is returned if a rule is invalid.
+func (b ClusterRoleBuilder) T() (rbacV1.ClusterRole, error) {
+	var role rbacV1.ClusterRole
+	if err := unstruct.FromUnstructured(b, &role); err != nil {
+		return rbacV1.ClusterRole{}, err
+	}
+	if err := naming.Validate("ClusterRole", role.Name); err != nil {
+		return rbacV1.ClusterRole{}, err
+	}
+	for _, rule := range role.Rules {
+		if err := validateRule(rule); err != nil {
+			return rbacV1.ClusterRole{}, err
+		}
+	}
+	return role, nil
+}
+
+// Rules sets the policy rules of the cluster role
+func (b ClusterRoleBuilder) Rules(rules ...PolicyRuleBuilder) ClusterRoleBuilder {
+	b["rules"] = ruleSlice(rules)
+	return b
+}
+
+// AddRule adds a policy rule to the cluster role
+func (b ClusterRoleBuilder) AddRule(rule PolicyRuleBuilder) ClusterRoleBuilder {
+	rules, _ := b["rules"].([]interface{})
+	b["rules"] = append(rules, unstruct.Nest(rule))
+	return b
+}
+
+// AggregateFrom sets an aggregation rule so that the rules of the cluster
+// roles matching any of the label sets are combined into this role
+func (b ClusterRoleBuilder) AggregateFrom(labels ...map[string]string) ClusterRoleBuilder {
+	var selectors []interface{}
+	for _, l := range labels {
+		match := map[string]interface{}{}
+		for k, v := range l {
+			match[k] = v
+		}
+		selectors = append(selectors, map[string]interface{}{"matchLabels": match})
+	}
+	b["aggregationRule"] = map[string]interface{}{"clusterRoleSelectors": selectors}
+	return b
+}
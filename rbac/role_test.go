@@ -0,0 +1,102 @@
+package rbac
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/objmeta"
+	rbacV1 "k8s.io/api/rbac/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRoleTyped(t *testing.T) {
+	tests := map[string]struct {
+		builder  RoleBuilder
+		expected rbacV1.Role
+	}{
+		"empty": {
+			builder:  RoleBuilder{},
+			expected: rbacV1.Role{},
+		},
+		"with rules": {
+			builder: Role(objmeta.Name("pod-reader").Namespace("default")).
+				Rules(ReadOnly().APIGroups("").Resources("pods")).
+				AddRule(Verbs("get").APIGroups("").Resources("pods/log")),
+			expected: rbacV1.Role{
+				ObjectMeta: metaV1.ObjectMeta{Name: "pod-reader", Namespace: "default"},
+				Rules: []rbacV1.PolicyRule{
+					{Verbs: []string{"get", "list", "watch"}, APIGroups: []string{""}, Resources: []string{"pods"}},
+					{Verbs: []string{"get"}, APIGroups: []string{""}, Resources: []string{"pods/log"}},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			role, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(role, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", role, test.expected)
+			}
+		})
+	}
+}
+
+func TestRoleInvalid(t *testing.T) {
+	tests := map[string]RoleBuilder{
+		"invalid rule":      Role(objmeta.Name("pod-reader")).Rules(PolicyRuleBuilder{}.Resources("pods")),
+		"non resource urls": Role(objmeta.Name("health")).Rules(Verbs("get").NonResourceURLs("/healthz")),
+		"invalid name":      Role(objmeta.Name("pods/reader")).Rules(Verbs("get").Resources("pods")),
+	}
+
+	for name, builder := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := builder.T(); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
+
+func TestClusterRoleTyped(t *testing.T) {
+	tests := map[string]struct {
+		builder  ClusterRoleBuilder
+		expected rbacV1.ClusterRole
+	}{
+		"empty": {
+			builder:  ClusterRoleBuilder{},
+			expected: rbacV1.ClusterRole{},
+		},
+		"non resource urls": {
+			builder: ClusterRole(objmeta.Name("health-reader")).Rules(Verbs("get").NonResourceURLs("/healthz")),
+			expected: rbacV1.ClusterRole{
+				ObjectMeta: metaV1.ObjectMeta{Name: "health-reader"},
+				Rules:      []rbacV1.PolicyRule{{Verbs: []string{"get"}, NonResourceURLs: []string{"/healthz"}}},
+			},
+		},
+		"aggregated": {
+			builder: ClusterRole(objmeta.Name("monitoring")).AggregateFrom(map[string]string{"rbac.example.com/aggregate-to-monitoring": "true"}),
+			expected: rbacV1.ClusterRole{
+				ObjectMeta: metaV1.ObjectMeta{Name: "monitoring"},
+				AggregationRule: &rbacV1.AggregationRule{
+					ClusterRoleSelectors: []metaV1.LabelSelector{{MatchLabels: map[string]string{"rbac.example.com/aggregate-to-monitoring": "true"}}},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			role, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(role, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", role, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Package rbac contains builder types to build RBAC objects of the rbac.authorization.k8s.io/v1 API
+package rbac
+
+import (
+	"fmt"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	rbacV1 "k8s.io/api/rbac/v1"
+)
+
+// PolicyRuleBuilder provides a way to build values of type rbacV1.PolicyRule
+type PolicyRuleBuilder map[string]interface{}
+
+// Verbs starts a new policy rule allowing the verbs (i.e. get, list, watch)
+func Verbs(verbs ...string) PolicyRuleBuilder {
+	return PolicyRuleBuilder{}.Verbs(verbs...)
+}
+
+// ReadOnly is a shortcut for a rule allowing get, list and watch
+func ReadOnly() PolicyRuleBuilder {
+	return Verbs("get", "list", "watch")
+}
+
+// U returns the unstructured value of the builder
+func (b PolicyRuleBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b PolicyRuleBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder. An error is returned if the rule
+// has no verbs or mixes resources with non-resource URLs.
+func (b PolicyRuleBuilder) T() (rbacV1.PolicyRule, error) {
+	var rule rbacV1.PolicyRule
+	if err := unstruct.FromUnstructured(b, &rule); err != nil {
+		return rbacV1.PolicyRule{}, err
+	}
+	if err := validateRule(rule); err != nil {
+		return rbacV1.PolicyRule{}, err
+	}
+	return rule, nil
+}
+
+// Verbs sets the verbs allowed by the rule
+func (b PolicyRuleBuilder) Verbs(verbs ...string) PolicyRuleBuilder {
+	b["verbs"] = stringSlice(verbs)
+	return b
+}
+
+// APIGroups sets the API groups of the resources ("" is the core group)
+func (b PolicyRuleBuilder) APIGroups(groups ...string) PolicyRuleBuilder {
+	b["apiGroups"] = stringSlice(groups)
+	return b
+}
+
+// Resources sets the resources (i.e. pods, deployments/scale) the rule applies to
+func (b PolicyRuleBuilder) Resources(resources ...string) PolicyRuleBuilder {
+	b["resources"] = stringSlice(resources)
+	return b
+}
+
+// ResourceNames restricts the rule to the named resources
+func (b PolicyRuleBuilder) ResourceNames(names ...string) PolicyRuleBuilder {
+	b["resourceNames"] = stringSlice(names)
+	return b
+}
+
+// NonResourceURLs sets the partial URLs (i.e. /healthz) the rule applies to.
+// Only valid in ClusterRoles.
+func (b PolicyRuleBuilder) NonResourceURLs(urls ...string) PolicyRuleBuilder {
+	b["nonResourceURLs"] = stringSlice(urls)
+	return b
+}
+
+func validateRule(rule rbacV1.PolicyRule) error {
+	if len(rule.Verbs) == 0 {
+		return fmt.Errorf("rbac: policy rule must have at least one verb")
+	}
+	if len(rule.NonResourceURLs) > 0 && (len(rule.Resources) > 0 || len(rule.APIGroups) > 0) {
+		return fmt.Errorf("rbac: policy rule cannot mix resources and non-resource URLs")
+	}
+	return nil
+}
+
+func stringSlice(strs []string) []interface{} {
+	slice := []interface{}{}
+	for _, s := range strs {
+		slice = append(slice, s)
+	}
+	return slice
+}
+
+func ruleSlice(rules []PolicyRuleBuilder) []interface{} {
+	slice := []interface{}{}
+	for _, r := range rules {
+		slice = append(slice, unstruct.Nest(r))
+	}
+	return slice
+}
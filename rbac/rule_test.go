@@ -0,0 +1,59 @@
+package rbac
+
+import (
+	"reflect"
+	"testing"
+
+	rbacV1 "k8s.io/api/rbac/v1"
+)
+
+func TestPolicyRuleStructured(t *testing.T) {
+	tests := map[string]struct {
+		builder  PolicyRuleBuilder
+		expected rbacV1.PolicyRule
+	}{
+		"verbs only": {
+			builder:  Verbs("get"),
+			expected: rbacV1.PolicyRule{Verbs: []string{"get"}},
+		},
+		"read only core resources": {
+			builder:  ReadOnly().APIGroups("").Resources("pods", "services"),
+			expected: rbacV1.PolicyRule{Verbs: []string{"get", "list", "watch"}, APIGroups: []string{""}, Resources: []string{"pods", "services"}},
+		},
+		"named resources": {
+			builder:  Verbs("update").APIGroups("apps").Resources("deployments/scale").ResourceNames("web"),
+			expected: rbacV1.PolicyRule{Verbs: []string{"update"}, APIGroups: []string{"apps"}, Resources: []string{"deployments/scale"}, ResourceNames: []string{"web"}},
+		},
+		"non resource urls": {
+			builder:  Verbs("get").NonResourceURLs("/healthz", "/metrics"),
+			expected: rbacV1.PolicyRule{Verbs: []string{"get"}, NonResourceURLs: []string{"/healthz", "/metrics"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rule, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(rule, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", rule, test.expected)
+			}
+		})
+	}
+}
+
+func TestPolicyRuleInvalid(t *testing.T) {
+	tests := map[string]PolicyRuleBuilder{
+		"no verbs":            PolicyRuleBuilder{}.Resources("pods"),
+		"mixed resource urls": Verbs("get").Resources("pods").NonResourceURLs("/healthz"),
+	}
+
+	for name, builder := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := builder.T(); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
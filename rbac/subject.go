@@ -0,0 +1,64 @@
+package rbac
+
+import (
+	"fmt"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/serviceaccount"
+	rbacV1 "k8s.io/api/rbac/v1"
+)
+
+// SubjectBuilder provides a way to build values of type rbacV1.Subject
+type SubjectBuilder map[string]interface{}
+
+// ServiceAccount creates a subject for the service account in the namespace
+func ServiceAccount(name, namespace string) SubjectBuilder {
+	return SubjectBuilder{"kind": rbacV1.ServiceAccountKind, "name": name, "namespace": namespace}
+}
+
+// ServiceAccountFor creates a subject for the service account built by sa,
+// an invalid service account is recorded as an error returned by T
+func ServiceAccountFor(sa serviceaccount.Builder) SubjectBuilder {
+	obj, err := sa.T()
+	if err != nil {
+		return SubjectBuilder(unstruct.SetErr(map[string]interface{}{}, fmt.Errorf("rbac: subject service account: %w", err)))
+	}
+	return ServiceAccount(obj.Name, obj.Namespace)
+}
+
+// User creates a subject for the named user
+func User(name string) SubjectBuilder {
+	return SubjectBuilder{"kind": rbacV1.UserKind, "apiGroup": rbacV1.GroupName, "name": name}
+}
+
+// Group creates a subject for the named group
+func Group(name string) SubjectBuilder {
+	return SubjectBuilder{"kind": rbacV1.GroupKind, "apiGroup": rbacV1.GroupName, "name": name}
+}
+
+// U returns the unstructured value of the builder
+func (b SubjectBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b SubjectBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b SubjectBuilder) T() (rbacV1.Subject, error) {
+	var subject rbacV1.Subject
+	if err := unstruct.FromUnstructured(b, &subject); err != nil {
+		return rbacV1.Subject{}, err
+	}
+	return subject, nil
+}
+
+func subjectSlice(subjects []SubjectBuilder) []interface{} {
+	var slice []interface{}
+	for _, s := range subjects {
+		slice = append(slice, unstruct.Nest(s))
+	}
+	return slice
+}
@@ -0,0 +1,46 @@
+package rbac
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/objmeta"
+	"github.com/vladimirvivien/kob/serviceaccount"
+	rbacV1 "k8s.io/api/rbac/v1"
+)
+
+func TestSubjectStructured(t *testing.T) {
+	tests := map[string]struct {
+		builder  SubjectBuilder
+		expected rbacV1.Subject
+	}{
+		"service account": {
+			builder:  ServiceAccount("operator", "system"),
+			expected: rbacV1.Subject{Kind: rbacV1.ServiceAccountKind, Name: "operator", Namespace: "system"},
+		},
+		"service account builder": {
+			builder:  ServiceAccountFor(serviceaccount.Object(objmeta.Name("operator").Namespace("system"))),
+			expected: rbacV1.Subject{Kind: rbacV1.ServiceAccountKind, Name: "operator", Namespace: "system"},
+		},
+		"user": {
+			builder:  User("jane"),
+			expected: rbacV1.Subject{Kind: rbacV1.UserKind, APIGroup: rbacV1.GroupName, Name: "jane"},
+		},
+		"group": {
+			builder:  Group("admins"),
+			expected: rbacV1.Subject{Kind: rbacV1.GroupKind, APIGroup: rbacV1.GroupName, Name: "admins"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			subject, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(subject, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", subject, test.expected)
+			}
+		})
+	}
+}
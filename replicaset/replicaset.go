@@ -0,0 +1,152 @@
+// Package replicaset contains builder types to build values of type appsV1.ReplicaSet
+package replicaset
+
+import (
+	"fmt"
+
+	"github.com/vladimirvivien/kob/container"
+	"github.com/vladimirvivien/kob/deployment"
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/internal/workload"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	"github.com/vladimirvivien/kob/pod"
+	"github.com/vladimirvivien/kob/selector"
+	appsV1 "k8s.io/api/apps/v1"
+)
+
+// Builder provides a way to build values of type appsV1.ReplicaSet
+type Builder map[string]interface{}
+
+// Object starts a new ReplicaSet builder using the provided object metadata
+func Object(metadata objmeta.Builder) Builder {
+	meta, err := metadata.U()
+	return Builder(unstruct.SetErr(map[string]interface{}{"metadata": meta}, err))
+}
+
+// FromPod starts a new replica set builder, with the name and namespace of the
+// pod built by p, whose pods mirror it (see PodTemplate)
+func FromPod(p pod.Builder) Builder {
+	return Object(objmeta.Name(workload.Name(p)).Namespace(workload.Namespace(p))).PodTemplate(p)
+}
+
+// FromDeployment starts a new replica set builder with the name, namespace,
+// replicas, selector and pod template of the deployment built by dep. An
+// invalid deployment is recorded as an error returned by T.
+func FromDeployment(dep deployment.Builder) Builder {
+	obj, err := dep.T()
+	if err != nil {
+		return Builder(unstruct.SetErr(map[string]interface{}{}, fmt.Errorf("replicaset: from deployment: %w", err)))
+	}
+	b := Object(objmeta.Name(workload.Name(dep)).Namespace(workload.Namespace(dep)))
+	if obj.Spec.Replicas != nil {
+		b.Replicas(int(*obj.Spec.Replicas))
+	}
+	spec, _ := dep["spec"].(map[string]interface{})
+	for _, key := range []string{"selector", "template", "minReadySeconds"} {
+		if val, ok := spec[key]; ok {
+			b.setSpec(key, val)
+		}
+	}
+	b["spec"] = unstruct.DeepCopy(b.getReplicaSetSpec())
+	return b
+}
+
+// U returns the unstructured value of the builder
+func (b Builder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b Builder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b Builder) T() (appsV1.ReplicaSet, error) {
+	var rs appsV1.ReplicaSet
+	if err := unstruct.FromUnstructured(b, &rs); err != nil {
+		return appsV1.ReplicaSet{}, err
+	}
+	if err := naming.Validate("ReplicaSet", rs.Name); err != nil {
+		return appsV1.ReplicaSet{}, err
+	}
+	return rs, nil
+}
+
+// Replicas sets the number of desired pods
+func (b Builder) Replicas(r int) Builder {
+	return b.setSpec("replicas", int64(r))
+}
+
+// MinReadySeconds sets how long a new pod must be ready before being considered available
+func (b Builder) MinReadySeconds(secs int32) Builder {
+	return b.setSpec("minReadySeconds", int64(secs))
+}
+
+// Selector sets the labels of the pods managed by the replica set
+func (b Builder) Selector(labels map[string]string) Builder {
+	workload.SetSelector(b, labels)
+	return b
+}
+
+// LabelSelector sets the selector of the pods managed by the replica set,
+// supporting label expressions
+func (b Builder) LabelSelector(sel selector.Builder) Builder {
+	workload.SetLabelSelector(b, unstruct.Nest(sel))
+	return b
+}
+
+// PodSpec sets the pod template with the containers, inheriting the
+// replica set's stable recommended labels (see deployment.Builder.PodSpec)
+func (b Builder) PodSpec(containers ...container.Builder) Builder {
+	workload.SetTemplate(b, nil, unstruct.Nest(pod.Spec(containers...)))
+	workload.InheritLabels(b, objmeta.StableAppLabels)
+	return b
+}
+
+// PodSpecWithMetadata sets the pod template with the pod metadata and containers,
+// inheriting the replica set's stable recommended labels
+func (b Builder) PodSpecWithMetadata(metadata objmeta.Builder, containers ...container.Builder) Builder {
+	meta, err := metadata.U()
+	unstruct.SetErr(b, err)
+	workload.SetTemplate(b, meta, unstruct.Nest(pod.Spec(containers...)))
+	workload.InheritLabels(b, objmeta.StableAppLabels)
+	return b
+}
+
+// InheritLabels copies the labels of the replica set with the keys into its
+// pod template and, when no selector is set, selects them
+// (see deployment.Builder.InheritLabels)
+func (b Builder) InheritLabels(keys ...string) Builder {
+	workload.InheritLabels(b, keys)
+	return b
+}
+
+// PodTemplate sets the pod template from the pod built by p, keeping only the
+// labels and annotations of its metadata, and selects the pod's labels
+func (b Builder) PodTemplate(p pod.Builder) Builder {
+	workload.SetTemplateFromPod(b, p)
+	return b
+}
+
+// Pod returns a builder for a standalone pod, with the name, mirroring the
+// replica set's pod template in the replica set's namespace
+func (b Builder) Pod(name string) pod.Builder {
+	return pod.Builder(workload.Pod(b, name))
+}
+
+func (b Builder) setSpec(key string, val interface{}) Builder {
+	spec := b.getReplicaSetSpec()
+	spec[key] = val
+	b["spec"] = spec
+	return b
+}
+
+func (b Builder) getReplicaSetSpec() map[string]interface{} {
+	specIface, ok := b["spec"]
+	if !ok {
+		specIface = map[string]interface{}{}
+	}
+	return specIface.(map[string]interface{})
+}
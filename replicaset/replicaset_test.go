@@ -0,0 +1,151 @@
+package replicaset
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/container"
+	"github.com/vladimirvivien/kob/deployment"
+	"github.com/vladimirvivien/kob/objmeta"
+	"github.com/vladimirvivien/kob/pod"
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReplicaSetTyped(t *testing.T) {
+	web := map[string]string{"app": "web"}
+	tests := map[string]struct {
+		builder  Builder
+		expected appsV1.ReplicaSet
+	}{
+		"empty": {
+			builder:  Builder{},
+			expected: appsV1.ReplicaSet{},
+		},
+		"object meta only": {
+			builder:  Object(objmeta.Name("web").Namespace("default")),
+			expected: appsV1.ReplicaSet{ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"}},
+		},
+		"with podspec": {
+			builder: Object(objmeta.Name("web")).Replicas(3).MinReadySeconds(5).PodSpec(container.Name("web")),
+			expected: appsV1.ReplicaSet{
+				ObjectMeta: metaV1.ObjectMeta{Name: "web"},
+				Spec: appsV1.ReplicaSetSpec{
+					Replicas:        int32Ptr(3),
+					MinReadySeconds: 5,
+					Template:        coreV1.PodTemplateSpec{Spec: coreV1.PodSpec{Containers: []coreV1.Container{{Name: "web"}}}},
+				},
+			},
+		},
+		"with podspec metadata and selector": {
+			builder: Object(objmeta.Name("web")).Selector(web).PodSpecWithMetadata(objmeta.Name("").Labels(web), container.Name("web")),
+			expected: appsV1.ReplicaSet{
+				ObjectMeta: metaV1.ObjectMeta{Name: "web"},
+				Spec: appsV1.ReplicaSetSpec{
+					Selector: &metaV1.LabelSelector{MatchLabels: web},
+					Template: coreV1.PodTemplateSpec{
+						ObjectMeta: metaV1.ObjectMeta{Labels: web},
+						Spec:       coreV1.PodSpec{Containers: []coreV1.Container{{Name: "web"}}},
+					},
+				},
+			},
+		},
+		"from pod": {
+			builder: FromPod(pod.Object(objmeta.Name("web").Namespace("shop").Labels(web)).Spec(container.Name("web"))),
+			expected: appsV1.ReplicaSet{
+				ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "shop"},
+				Spec: appsV1.ReplicaSetSpec{
+					Selector: &metaV1.LabelSelector{MatchLabels: web},
+					Template: coreV1.PodTemplateSpec{
+						ObjectMeta: metaV1.ObjectMeta{Labels: web},
+						Spec:       coreV1.PodSpec{Containers: []coreV1.Container{{Name: "web"}}},
+					},
+				},
+			},
+		},
+		"with recommended labels": {
+			builder: Object(objmeta.Name("web").App("web", "", "1.2.0", "", "", "")).PodSpec(container.Name("web")).InheritLabels(objmeta.LabelVersion),
+			expected: appsV1.ReplicaSet{
+				ObjectMeta: metaV1.ObjectMeta{Name: "web", Labels: map[string]string{objmeta.LabelName: "web", objmeta.LabelVersion: "1.2.0"}},
+				Spec: appsV1.ReplicaSetSpec{
+					Selector: &metaV1.LabelSelector{MatchLabels: map[string]string{objmeta.LabelName: "web"}},
+					Template: coreV1.PodTemplateSpec{
+						ObjectMeta: metaV1.ObjectMeta{Labels: map[string]string{objmeta.LabelName: "web", objmeta.LabelVersion: "1.2.0"}},
+						Spec:       coreV1.PodSpec{Containers: []coreV1.Container{{Name: "web"}}},
+					},
+				},
+			},
+		},
+		"from deployment": {
+			builder: FromDeployment(deployment.Object(objmeta.Name("web").Namespace("shop")).Replicas(2).
+				Selector(web).PodSpecWithMetadata(objmeta.Name("").Labels(web), container.Name("web"))),
+			expected: appsV1.ReplicaSet{
+				ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "shop"},
+				Spec: appsV1.ReplicaSetSpec{
+					Replicas: int32Ptr(2),
+					Selector: &metaV1.LabelSelector{MatchLabels: web},
+					Template: coreV1.PodTemplateSpec{
+						ObjectMeta: metaV1.ObjectMeta{Labels: web},
+						Spec:       coreV1.PodSpec{Containers: []coreV1.Container{{Name: "web"}}},
+					},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rs, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(rs, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", rs, test.expected)
+			}
+		})
+	}
+}
+
+func TestReplicaSetPod(t *testing.T) {
+	rs := Object(objmeta.Name("web").Namespace("shop")).PodSpecWithMetadata(objmeta.Name("").Labels(map[string]string{"app": "web"}), container.Name("web"))
+	p, err := rs.Pod("web-0").T()
+	if err != nil {
+		t.Fatalf("failed to convert to typed value: %s", err)
+	}
+	expected := coreV1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "web-0", Namespace: "shop", Labels: map[string]string{"app": "web"}},
+		Spec:       coreV1.PodSpec{Containers: []coreV1.Container{{Name: "web"}}},
+	}
+	if !reflect.DeepEqual(p, expected) {
+		t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", p, expected)
+	}
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func TestReplicaSetOwnedByDeployment(t *testing.T) {
+	dep := deployment.Object(objmeta.Name("web").UID("dep-uid")).Replicas(2)
+	meta := objmeta.Name("web-5d4f").OwnedBy(dep)
+	if err := meta.Err(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	rs, err := Object(meta).T()
+	if err != nil {
+		t.Fatalf("failed to convert to typed value: %s", err)
+	}
+	controller := true
+	expected := []metaV1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: "web", UID: "dep-uid", Controller: &controller, BlockOwnerDeletion: &controller}}
+	if !reflect.DeepEqual(rs.OwnerReferences, expected) {
+		t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", rs.OwnerReferences, expected)
+	}
+}
+
+func TestReplicaSetFromInvalidDeployment(t *testing.T) {
+	rs := FromDeployment(deployment.Object(objmeta.Name("My_App")).PodSpec(container.Name("web")))
+	if _, err := rs.T(); err == nil {
+		t.Error("expecting error, got none")
+	}
+}
@@ -0,0 +1,103 @@
+// Package resourcequota contains builder types to build values of type coreV1.ResourceQuota
+package resourcequota
+
+import (
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	coreV1 "k8s.io/api/core/v1"
+)
+
+// Builder provides a way to build values of type coreV1.ResourceQuota
+type Builder map[string]interface{}
+
+// Object starts a new ResourceQuota builder using the provided object metadata
+func Object(metadata objmeta.Builder) Builder {
+	meta, err := metadata.U()
+	return Builder(unstruct.SetErr(map[string]interface{}{"metadata": meta}, err))
+}
+
+// U returns the unstructured value of the builder
+func (b Builder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b Builder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b Builder) T() (coreV1.ResourceQuota, error) {
+	var quota coreV1.ResourceQuota
+	if err := unstruct.FromUnstructured(b, &quota); err != nil {
+		return coreV1.ResourceQuota{}, err
+	}
+	if err := naming.Validate("ResourceQuota", quota.Name); err != nil {
+		return coreV1.ResourceQuota{}, err
+	}
+	return quota, nil
+}
+
+// Hard sets the enforced hard limits (i.e. requests.cpu, pods) of the quota
+func (b Builder) Hard(limits coreV1.ResourceList) Builder {
+	hard := map[string]interface{}{}
+	for name, qty := range limits {
+		hard[string(name)] = qty.String()
+	}
+	return b.setSpec("hard", hard)
+}
+
+// AddHard adds an enforced hard limit to the quota
+func (b Builder) AddHard(name coreV1.ResourceName, qty string) Builder {
+	hard, ok := b.getQuotaSpec()["hard"].(map[string]interface{})
+	if !ok {
+		hard = map[string]interface{}{}
+	}
+	hard[string(name)] = qty
+	return b.setSpec("hard", hard)
+}
+
+// Scopes restricts the quota to objects matching all the scopes (i.e. BestEffort)
+func (b Builder) Scopes(scopes ...coreV1.ResourceQuotaScope) Builder {
+	var slice []interface{}
+	for _, s := range scopes {
+		slice = append(slice, s)
+	}
+	return b.setSpec("scopes", slice)
+}
+
+// AddScopeSelector adds a scope selector expression restricting the quota
+// (i.e. PriorityClass In high)
+func (b Builder) AddScopeSelector(scope coreV1.ResourceQuotaScope, op coreV1.ScopeSelectorOperator, values ...string) Builder {
+	sel, ok := b.getQuotaSpec()["scopeSelector"].(map[string]interface{})
+	if !ok {
+		sel = map[string]interface{}{}
+	}
+	expr := map[string]interface{}{"scopeName": scope, "operator": op}
+	if len(values) > 0 {
+		var vals []interface{}
+		for _, v := range values {
+			vals = append(vals, v)
+		}
+		expr["values"] = vals
+	}
+	exprs, _ := sel["matchExpressions"].([]interface{})
+	sel["matchExpressions"] = append(exprs, expr)
+	return b.setSpec("scopeSelector", sel)
+}
+
+func (b Builder) setSpec(key string, val interface{}) Builder {
+	spec := b.getQuotaSpec()
+	spec[key] = val
+	b["spec"] = spec
+	return b
+}
+
+func (b Builder) getQuotaSpec() map[string]interface{} {
+	specIface, ok := b["spec"]
+	if !ok {
+		specIface = map[string]interface{}{}
+	}
+	return specIface.(map[string]interface{})
+}
@@ -0,0 +1,77 @@
+package resourcequota
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/objmeta"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResourceQuotaTyped(t *testing.T) {
+	tests := map[string]struct {
+		builder  Builder
+		expected coreV1.ResourceQuota
+	}{
+		"empty": {
+			builder:  Builder{},
+			expected: coreV1.ResourceQuota{},
+		},
+		"object meta only": {
+			builder:  Object(objmeta.Name("compute").Namespace("team-a")),
+			expected: coreV1.ResourceQuota{ObjectMeta: metaV1.ObjectMeta{Name: "compute", Namespace: "team-a"}},
+		},
+		"hard limits": {
+			builder: Object(objmeta.Name("compute")).
+				Hard(coreV1.ResourceList{coreV1.ResourceRequestsCPU: resource.MustParse("4")}).
+				AddHard(coreV1.ResourceRequestsMemory, "8Gi").AddHard(coreV1.ResourcePods, "20"),
+			expected: coreV1.ResourceQuota{
+				ObjectMeta: metaV1.ObjectMeta{Name: "compute"},
+				Spec: coreV1.ResourceQuotaSpec{
+					Hard: coreV1.ResourceList{
+						coreV1.ResourceRequestsCPU:    resource.MustParse("4"),
+						coreV1.ResourceRequestsMemory: resource.MustParse("8Gi"),
+						coreV1.ResourcePods:           resource.MustParse("20"),
+					},
+				},
+			},
+		},
+		"scopes and selector": {
+			builder: Object(objmeta.Name("high-priority")).AddHard(coreV1.ResourcePods, "10").
+				Scopes(coreV1.ResourceQuotaScopeNotBestEffort).
+				AddScopeSelector(coreV1.ResourceQuotaScopePriorityClass, coreV1.ScopeSelectorOpIn, "high").
+				AddScopeSelector(coreV1.ResourceQuotaScopeTerminating, coreV1.ScopeSelectorOpExists),
+			expected: coreV1.ResourceQuota{
+				ObjectMeta: metaV1.ObjectMeta{Name: "high-priority"},
+				Spec: coreV1.ResourceQuotaSpec{
+					Hard:   coreV1.ResourceList{coreV1.ResourcePods: resource.MustParse("10")},
+					Scopes: []coreV1.ResourceQuotaScope{coreV1.ResourceQuotaScopeNotBestEffort},
+					ScopeSelector: &coreV1.ScopeSelector{MatchExpressions: []coreV1.ScopedResourceSelectorRequirement{
+						{ScopeName: coreV1.ResourceQuotaScopePriorityClass, Operator: coreV1.ScopeSelectorOpIn, Values: []string{"high"}},
+						{ScopeName: coreV1.ResourceQuotaScopeTerminating, Operator: coreV1.ScopeSelectorOpExists},
+					}},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			quota, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(quota, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", quota, test.expected)
+			}
+		})
+	}
+}
+
+func TestResourceQuotaInvalid(t *testing.T) {
+	if _, err := Object(objmeta.Name("compute")).AddHard(coreV1.ResourcePods, "twenty").T(); err == nil {
+		t.Error("expecting error, got none")
+	}
+}
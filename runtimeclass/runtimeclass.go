@@ -0,0 +1,83 @@
+// Package runtimeclass contains builder types to build values of type nodeV1.RuntimeClass
+package runtimeclass
+
+import (
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	coreV1 "k8s.io/api/core/v1"
+	nodeV1 "k8s.io/api/node/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Builder provides a way to build values of type nodeV1.RuntimeClass
+type Builder map[string]interface{}
+
+// Object starts a new RuntimeClass builder using the provided object metadata
+// and the name of the CRI handler configured on the nodes (i.e. runsc)
+func Object(metadata objmeta.Builder, handler string) Builder {
+	meta, err := metadata.U()
+	return Builder(unstruct.SetErr(map[string]interface{}{"metadata": meta, "handler": handler}, err))
+}
+
+// U returns the unstructured value of the builder
+func (b Builder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b Builder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b Builder) T() (nodeV1.RuntimeClass, error) {
+	var class nodeV1.RuntimeClass
+	if err := unstruct.FromUnstructured(b, &class); err != nil {
+		return nodeV1.RuntimeClass{}, err
+	}
+	if err := naming.Validate("RuntimeClass", class.Name); err != nil {
+		return nodeV1.RuntimeClass{}, err
+	}
+	return class, nil
+}
+
+// Overhead sets the resources, added to the containers' requests, consumed by the runtime for each pod
+func (b Builder) Overhead(podFixed coreV1.ResourceList) Builder {
+	fixed := map[string]interface{}{}
+	for name, qty := range podFixed {
+		fixed[string(name)] = qty.String()
+	}
+	b["overhead"] = map[string]interface{}{"podFixed": fixed}
+	return b
+}
+
+// Scheduling restricts pods using the class to nodes with the labels and
+// adds the tolerations to them
+func (b Builder) Scheduling(nodeSelector map[string]string, tolerations ...coreV1.Toleration) Builder {
+	scheduling := map[string]interface{}{}
+	if len(nodeSelector) > 0 {
+		selector := map[string]interface{}{}
+		for k, v := range nodeSelector {
+			selector[k] = v
+		}
+		scheduling["nodeSelector"] = selector
+	}
+	if len(tolerations) > 0 {
+		var slice []interface{}
+		for i := range tolerations {
+			u, _ := runtime.DefaultUnstructuredConverter.ToUnstructured(&tolerations[i])
+			slice = append(slice, u)
+		}
+		scheduling["tolerations"] = slice
+	}
+	b["scheduling"] = scheduling
+	return b
+}
+
+// Name returns the name of the class, as referenced by pod specs
+func (b Builder) Name() string {
+	meta, _ := b["metadata"].(map[string]interface{})
+	name, _ := meta["name"].(string)
+	return name
+}
@@ -0,0 +1,58 @@
+package runtimeclass
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/objmeta"
+	coreV1 "k8s.io/api/core/v1"
+	nodeV1 "k8s.io/api/node/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRuntimeClassTyped(t *testing.T) {
+	toleration := coreV1.Toleration{Key: "sandbox", Operator: coreV1.TolerationOpExists, Effect: coreV1.TaintEffectNoSchedule}
+	tests := map[string]struct {
+		builder  Builder
+		expected nodeV1.RuntimeClass
+	}{
+		"empty": {
+			builder:  Builder{},
+			expected: nodeV1.RuntimeClass{},
+		},
+		"handler only": {
+			builder:  Object(objmeta.Name("gvisor"), "runsc"),
+			expected: nodeV1.RuntimeClass{ObjectMeta: metaV1.ObjectMeta{Name: "gvisor"}, Handler: "runsc"},
+		},
+		"overhead and scheduling": {
+			builder: Object(objmeta.Name("kata"), "kata").
+				Overhead(coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("250m"), coreV1.ResourceMemory: resource.MustParse("120Mi")}).
+				Scheduling(map[string]string{"sandbox": "kata"}, toleration),
+			expected: nodeV1.RuntimeClass{
+				ObjectMeta: metaV1.ObjectMeta{Name: "kata"},
+				Handler:    "kata",
+				Overhead: &nodeV1.Overhead{PodFixed: coreV1.ResourceList{
+					coreV1.ResourceCPU:    resource.MustParse("250m"),
+					coreV1.ResourceMemory: resource.MustParse("120Mi"),
+				}},
+				Scheduling: &nodeV1.Scheduling{
+					NodeSelector: map[string]string{"sandbox": "kata"},
+					Tolerations:  []coreV1.Toleration{toleration},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			class, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(class, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", class, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+// Package selector contains builder types to build label selectors of type
+// metaV1.LabelSelector, shared by the kinds selecting objects by their labels
+package selector
+
+import (
+	"fmt"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Builder provides a way to build values of type metaV1.LabelSelector.
+// An empty builder selects everything.
+type Builder map[string]interface{}
+
+// MatchLabels starts a new selector builder matching the labels
+func MatchLabels(labels map[string]string) Builder {
+	return Builder{}.MatchLabels(labels)
+}
+
+// In starts a new selector builder matching objects with the key set to one of the values
+func In(key string, vals ...string) Builder {
+	return Builder{}.In(key, vals...)
+}
+
+// NotIn starts a new selector builder matching objects without the key
+// or with the key set to none of the values
+func NotIn(key string, vals ...string) Builder {
+	return Builder{}.NotIn(key, vals...)
+}
+
+// Exists starts a new selector builder matching objects with the key
+func Exists(key string) Builder {
+	return Builder{}.Exists(key)
+}
+
+// DoesNotExist starts a new selector builder matching objects without the key
+func DoesNotExist(key string) Builder {
+	return Builder{}.DoesNotExist(key)
+}
+
+// From creates a new builder using the provided label selector as its base
+func From(obj metaV1.LabelSelector) Builder {
+	u, _ := runtime.DefaultUnstructuredConverter.ToUnstructured(&obj)
+	return Builder(u)
+}
+
+// U returns the unstructured value of the builder
+func (b Builder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b Builder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder. An error is returned if a label
+// key or value is invalid, or if the values do not suit an expression's operator.
+func (b Builder) T() (metaV1.LabelSelector, error) {
+	var sel metaV1.LabelSelector
+	if err := unstruct.FromUnstructured(b, &sel); err != nil {
+		return metaV1.LabelSelector{}, err
+	}
+	if _, err := metaV1.LabelSelectorAsSelector(&sel); err != nil {
+		return metaV1.LabelSelector{}, fmt.Errorf("selector: %w", err)
+	}
+	return sel, nil
+}
+
+// Map returns the labels of the selector in the map form used by services
+// and replication controllers, which do not support expressions
+func (b Builder) Map() (map[string]string, error) {
+	sel, err := b.T()
+	if err != nil {
+		return nil, err
+	}
+	if len(sel.MatchExpressions) > 0 {
+		return nil, fmt.Errorf("selector: expressions cannot be converted to a map selector")
+	}
+	return sel.MatchLabels, nil
+}
+
+// Matches reports whether the selector matches the labels, an invalid
+// selector matches nothing
+func (b Builder) Matches(lbls map[string]string) bool {
+	sel, err := b.T()
+	if err != nil {
+		return false
+	}
+	s, err := metaV1.LabelSelectorAsSelector(&sel)
+	if err != nil {
+		return false
+	}
+	return s.Matches(labels.Set(lbls))
+}
+
+// MatchLabels adds the labels to the labels matched by the selector
+func (b Builder) MatchLabels(labels map[string]string) Builder {
+	match, ok := b["matchLabels"].(map[string]interface{})
+	if !ok {
+		match = map[string]interface{}{}
+	}
+	for k, v := range labels {
+		match[k] = v
+	}
+	b["matchLabels"] = match
+	return b
+}
+
+// In adds an expression matching objects with the key set to one of the values
+func (b Builder) In(key string, vals ...string) Builder {
+	return b.expression(key, metaV1.LabelSelectorOpIn, vals)
+}
+
+// NotIn adds an expression matching objects without the key or with the key
+// set to none of the values
+func (b Builder) NotIn(key string, vals ...string) Builder {
+	return b.expression(key, metaV1.LabelSelectorOpNotIn, vals)
+}
+
+// Exists adds an expression matching objects with the key
+func (b Builder) Exists(key string) Builder {
+	return b.expression(key, metaV1.LabelSelectorOpExists, nil)
+}
+
+// DoesNotExist adds an expression matching objects without the key
+func (b Builder) DoesNotExist(key string) Builder {
+	return b.expression(key, metaV1.LabelSelectorOpDoesNotExist, nil)
+}
+
+func (b Builder) expression(key string, op metaV1.LabelSelectorOperator, vals []string) Builder {
+	expr := map[string]interface{}{"key": key, "operator": string(op)}
+	if len(vals) > 0 {
+		values := make([]interface{}, len(vals))
+		for i, v := range vals {
+			values[i] = v
+		}
+		expr["values"] = values
+	}
+	exprs, _ := b["matchExpressions"].([]interface{})
+	b["matchExpressions"] = append(exprs, expr)
+	return b
+}
@@ -0,0 +1,123 @@
+package selector
+
+import (
+	"reflect"
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSelectorTyped(t *testing.T) {
+	tests := map[string]struct {
+		builder  Builder
+		expected metaV1.LabelSelector
+	}{
+		"empty": {
+			builder:  Builder{},
+			expected: metaV1.LabelSelector{},
+		},
+		"match labels": {
+			builder:  MatchLabels(map[string]string{"app": "web"}).MatchLabels(map[string]string{"tier": "front"}),
+			expected: metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web", "tier": "front"}},
+		},
+		"expressions": {
+			builder: In("env", "prod", "staging").NotIn("tier", "db").Exists("app").DoesNotExist("canary"),
+			expected: metaV1.LabelSelector{MatchExpressions: []metaV1.LabelSelectorRequirement{
+				{Key: "env", Operator: metaV1.LabelSelectorOpIn, Values: []string{"prod", "staging"}},
+				{Key: "tier", Operator: metaV1.LabelSelectorOpNotIn, Values: []string{"db"}},
+				{Key: "app", Operator: metaV1.LabelSelectorOpExists},
+				{Key: "canary", Operator: metaV1.LabelSelectorOpDoesNotExist},
+			}},
+		},
+		"from": {
+			builder: From(metaV1.LabelSelector{
+				MatchLabels:      map[string]string{"app": "web"},
+				MatchExpressions: []metaV1.LabelSelectorRequirement{{Key: "env", Operator: metaV1.LabelSelectorOpIn, Values: []string{"prod"}}},
+			}),
+			expected: metaV1.LabelSelector{
+				MatchLabels:      map[string]string{"app": "web"},
+				MatchExpressions: []metaV1.LabelSelectorRequirement{{Key: "env", Operator: metaV1.LabelSelectorOpIn, Values: []string{"prod"}}},
+			},
+		},
+		"labels and expressions": {
+			builder: Exists("app").MatchLabels(map[string]string{"tier": "front"}),
+			expected: metaV1.LabelSelector{
+				MatchLabels:      map[string]string{"tier": "front"},
+				MatchExpressions: []metaV1.LabelSelectorRequirement{{Key: "app", Operator: metaV1.LabelSelectorOpExists}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			sel, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(sel, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", sel, test.expected)
+			}
+		})
+	}
+}
+
+func TestSelectorInvalid(t *testing.T) {
+	tests := map[string]Builder{
+		"in without values":   In("env"),
+		"exists with values":  Builder{}.expression("app", metaV1.LabelSelectorOpExists, []string{"web"}),
+		"invalid label key":   MatchLabels(map[string]string{"bad key": "web"}),
+		"invalid label value": MatchLabels(map[string]string{"app": "web app"}),
+		"invalid expr key":    NotIn("-env", "prod"),
+		"unknown operator":    Builder{}.expression("app", "Like", []string{"web"}),
+	}
+
+	for name, builder := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := builder.T(); err == nil {
+				t.Error("expecting error, got none")
+			}
+			if builder.Matches(map[string]string{"app": "web", "env": "prod"}) {
+				t.Error("invalid selector must match nothing")
+			}
+		})
+	}
+}
+
+func TestSelectorMap(t *testing.T) {
+	labels, err := MatchLabels(map[string]string{"app": "web"}).Map()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(labels, map[string]string{"app": "web"}) {
+		t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", labels, map[string]string{"app": "web"})
+	}
+	if _, err := MatchLabels(map[string]string{"app": "web"}).Exists("tier").Map(); err == nil {
+		t.Error("expecting error, got none")
+	}
+}
+
+func TestSelectorMatches(t *testing.T) {
+	labels := map[string]string{"app": "web", "env": "prod"}
+	tests := map[string]struct {
+		builder  Builder
+		expected bool
+	}{
+		"empty matches everything": {builder: Builder{}, expected: true},
+		"match labels":             {builder: MatchLabels(map[string]string{"app": "web"}), expected: true},
+		"match labels mismatch":    {builder: MatchLabels(map[string]string{"app": "api"}), expected: false},
+		"in":                       {builder: In("env", "prod", "staging"), expected: true},
+		"not in":                   {builder: NotIn("env", "prod"), expected: false},
+		"not in missing key":       {builder: NotIn("tier", "db"), expected: true},
+		"exists":                   {builder: Exists("app"), expected: true},
+		"does not exist":           {builder: DoesNotExist("app"), expected: false},
+		"all requirements":         {builder: MatchLabels(map[string]string{"app": "web"}).Exists("tier"), expected: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if matched := test.builder.Matches(labels); matched != test.expected {
+				t.Errorf("expecting match %t, got %t", test.expected, matched)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vladimirvivien/kob/endpointslice"
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/objmeta"
+	discoveryV1 "k8s.io/api/discovery/v1"
+)
+
+// ExternalService holds the builders of a selectorless service and of the
+// endpoint slices pointing it at addresses outside of the cluster
+type ExternalService struct {
+	Service Builder
+	Slices  []endpointslice.Builder
+}
+
+// External creates a selectorless service with one endpoint slice per
+// address type (IPv4, IPv6 or FQDN) of addrs. Slices are named
+// <name>-<address type> and labeled with kubernetes.io/service-name.
+// Use Ports to expose the service, and Namespace when not in the default namespace.
+func External(name string, addrs ...string) ExternalService {
+	ext := ExternalService{Service: Object(objmeta.Name(name))}
+	slices := map[discoveryV1.AddressType]endpointslice.Builder{}
+	for _, addr := range addrs {
+		addrType := endpointslice.AddressType(addr)
+		slice, ok := slices[addrType]
+		if !ok {
+			slice = endpointslice.For(objmeta.Name(name+"-"+strings.ToLower(string(addrType))), name, addrType)
+			slices[addrType] = slice
+			ext.Slices = append(ext.Slices, slice)
+		}
+		slice.AddEndpoint(endpointslice.Endpoint(addr).Ready(true))
+	}
+	return ext
+}
+
+// Namespace sets the namespace of the service and its endpoint slices
+func (e ExternalService) Namespace(ns string) ExternalService {
+	setNamespace(e.Service, ns)
+	for _, slice := range e.Slices {
+		setNamespace(slice, ns)
+	}
+	return e
+}
+
+// Ports sets the ports of the service and the matching ports of its endpoint
+// slices, which use the target port number when set. An invalid port is
+// recorded as an error returned by the T method of the service and the slices.
+func (e ExternalService) Ports(ports ...PortBuilder) ExternalService {
+	e.Service.Ports(ports...)
+	var slicePorts []endpointslice.PortBuilder
+	for _, p := range ports {
+		port, err := p.T()
+		if err != nil {
+			err = fmt.Errorf("service: external port: %w", err)
+			unstruct.SetErr(e.Service, err)
+			for _, slice := range e.Slices {
+				unstruct.SetErr(slice, err)
+			}
+			continue
+		}
+		slicePort := endpointslice.Port(port.Port)
+		if port.TargetPort.IntVal != 0 {
+			slicePort = endpointslice.Port(port.TargetPort.IntVal)
+		}
+		if port.Name != "" {
+			slicePort.Name(port.Name)
+		}
+		if port.Protocol != "" {
+			slicePort.Protocol(port.Protocol)
+		}
+		if port.AppProtocol != nil {
+			slicePort.AppProtocol(*port.AppProtocol)
+		}
+		slicePorts = append(slicePorts, slicePort)
+	}
+	for _, slice := range e.Slices {
+		slice.Ports(slicePorts...)
+	}
+	return e
+}
+
+func setNamespace(obj map[string]interface{}, ns string) {
+	meta, ok := obj["metadata"].(map[string]interface{})
+	if !ok {
+		meta = map[string]interface{}{}
+		obj["metadata"] = meta
+	}
+	meta["namespace"] = ns
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	coreV1 "k8s.io/api/core/v1"
+	discoveryV1 "k8s.io/api/discovery/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestExternal(t *testing.T) {
+	ext := External("db", "10.0.0.10", "fd00::10", "10.0.0.11").
+		Namespace("shop").
+		Ports(Port(5432).Name("postgres").TargetPort(15432).Protocol(coreV1.ProtocolTCP))
+
+	svc, err := ext.Service.T()
+	if err != nil {
+		t.Fatalf("failed to convert to typed value: %s", err)
+	}
+	expectedSvc := coreV1.Service{
+		ObjectMeta: metaV1.ObjectMeta{Name: "db", Namespace: "shop"},
+		Spec: coreV1.ServiceSpec{Ports: []coreV1.ServicePort{
+			{Name: "postgres", Port: 5432, TargetPort: intstr.FromInt(15432), Protocol: coreV1.ProtocolTCP},
+		}},
+	}
+	if !reflect.DeepEqual(svc, expectedSvc) {
+		t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", svc, expectedSvc)
+	}
+
+	ready, tcp, port, portName := true, coreV1.ProtocolTCP, int32(15432), "postgres"
+	labels := map[string]string{"kubernetes.io/service-name": "db", "endpointslice.kubernetes.io/managed-by": "kob"}
+	ports := []discoveryV1.EndpointPort{{Name: &portName, Protocol: &tcp, Port: &port}}
+	expectedSlices := []discoveryV1.EndpointSlice{
+		{
+			ObjectMeta:  metaV1.ObjectMeta{Name: "db-ipv4", Namespace: "shop", Labels: labels},
+			AddressType: discoveryV1.AddressTypeIPv4,
+			Endpoints: []discoveryV1.Endpoint{
+				{Addresses: []string{"10.0.0.10"}, Conditions: discoveryV1.EndpointConditions{Ready: &ready}},
+				{Addresses: []string{"10.0.0.11"}, Conditions: discoveryV1.EndpointConditions{Ready: &ready}},
+			},
+			Ports: ports,
+		},
+		{
+			ObjectMeta:  metaV1.ObjectMeta{Name: "db-ipv6", Namespace: "shop", Labels: labels},
+			AddressType: discoveryV1.AddressTypeIPv6,
+			Endpoints:   []discoveryV1.Endpoint{{Addresses: []string{"fd00::10"}, Conditions: discoveryV1.EndpointConditions{Ready: &ready}}},
+			Ports:       ports,
+		},
+	}
+	if len(ext.Slices) != len(expectedSlices) {
+		t.Fatalf("expecting %d slices, got %d", len(expectedSlices), len(ext.Slices))
+	}
+	for i, builder := range ext.Slices {
+		slice, err := builder.T()
+		if err != nil {
+			t.Fatalf("failed to convert to typed value: %s", err)
+		}
+		if !reflect.DeepEqual(slice, expectedSlices[i]) {
+			t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", slice, expectedSlices[i])
+		}
+	}
+}
+
+func TestExternalInvalidPort(t *testing.T) {
+	ext := External("db", "10.0.0.10").Ports(Port(5432).Name("postgres"), PortBuilder{"port": "postgres"})
+	if _, err := ext.Service.T(); err == nil {
+		t.Error("expecting service error, got none")
+	}
+	for _, slice := range ext.Slices {
+		if _, err := slice.T(); err == nil {
+			t.Error("expecting slice error, got none")
+		}
+	}
+}
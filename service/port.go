@@ -0,0 +1,69 @@
+package service
+
+import (
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	coreV1 "k8s.io/api/core/v1"
+)
+
+// PortBuilder provides a way to build values of type coreV1.ServicePort
+type PortBuilder map[string]interface{}
+
+// Port starts a new port builder with the port number exposed by the service
+func Port(port int32) PortBuilder {
+	return PortBuilder{"port": int64(port)}
+}
+
+// U returns the unstructured value of the builder
+func (b PortBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b PortBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b PortBuilder) T() (coreV1.ServicePort, error) {
+	var port coreV1.ServicePort
+	if err := unstruct.FromUnstructured(b, &port); err != nil {
+		return coreV1.ServicePort{}, err
+	}
+	return port, nil
+}
+
+// Name sets the name of the port
+func (b PortBuilder) Name(name string) PortBuilder {
+	b["name"] = name
+	return b
+}
+
+// Protocol sets the IP protocol of the port (TCP, UDP, or SCTP)
+func (b PortBuilder) Protocol(proto coreV1.Protocol) PortBuilder {
+	b["protocol"] = proto
+	return b
+}
+
+// AppProtocol sets the application protocol of the port (i.e. http, kubernetes.io/h2c)
+func (b PortBuilder) AppProtocol(proto string) PortBuilder {
+	b["appProtocol"] = proto
+	return b
+}
+
+// TargetPort sets the port number targeted on the selected pods
+func (b PortBuilder) TargetPort(port int32) PortBuilder {
+	b["targetPort"] = int64(port)
+	return b
+}
+
+// TargetPortName sets the named container port targeted on the selected pods
+func (b PortBuilder) TargetPortName(name string) PortBuilder {
+	b["targetPort"] = name
+	return b
+}
+
+// NodePort sets the port exposed on each node for NodePort or LoadBalancer services
+func (b PortBuilder) NodePort(port int32) PortBuilder {
+	b["nodePort"] = int64(port)
+	return b
+}
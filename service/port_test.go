@@ -0,0 +1,46 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestServicePort(t *testing.T) {
+	appProto := "http"
+	tests := map[string]struct {
+		builder  PortBuilder
+		expected coreV1.ServicePort
+	}{
+		"empty": {
+			builder:  PortBuilder{},
+			expected: coreV1.ServicePort{},
+		},
+		"port only": {
+			builder:  Port(80),
+			expected: coreV1.ServicePort{Port: 80},
+		},
+		"target port number": {
+			builder:  Port(80).TargetPort(8080),
+			expected: coreV1.ServicePort{Port: 80, TargetPort: intstr.FromInt(8080)},
+		},
+		"all": {
+			builder:  Port(80).Name("http").Protocol(coreV1.ProtocolTCP).AppProtocol("http").TargetPortName("web").NodePort(30080),
+			expected: coreV1.ServicePort{Name: "http", Port: 80, Protocol: coreV1.ProtocolTCP, AppProtocol: &appProto, TargetPort: intstr.FromString("web"), NodePort: 30080},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			port, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(port, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", port, test.expected)
+			}
+		})
+	}
+}
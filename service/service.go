@@ -0,0 +1,126 @@
+// Package service contains builder types to build values of type coreV1.Service
+package service
+
+import (
+	"fmt"
+
+	"github.com/vladimirvivien/kob/deployment"
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	"github.com/vladimirvivien/kob/selector"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Builder provides a way to build values of type coreV1.Service
+type Builder map[string]interface{}
+
+// Object starts a new Service builder using the provided object metadata
+func Object(metadata objmeta.Builder) Builder {
+	meta, err := metadata.U()
+	return Builder(unstruct.SetErr(map[string]interface{}{"metadata": meta}, err))
+}
+
+// From creates a new builder using the provided service as its base
+func From(obj coreV1.Service) Builder {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&obj)
+	if err != nil {
+		return Builder(unstruct.SetErr(map[string]interface{}{}, fmt.Errorf("service: from: %w", err)))
+	}
+	return Builder(u)
+}
+
+// For starts a new Service builder, named after the deployment (see
+// naming.Name) in the deployment's namespace, selecting the pods managed by
+// the deployment with the labels of its selector (see LabelSelector).
+// An invalid deployment, or a selector with expressions, is recorded as an
+// error returned by T.
+func For(dep deployment.Builder) Builder {
+	obj, err := dep.T()
+	if err != nil {
+		return Builder(unstruct.SetErr(map[string]interface{}{}, fmt.Errorf("service: target deployment: %w", err)))
+	}
+	sel, err := dep.PodSelector()
+	if err != nil {
+		return Builder(unstruct.SetErr(map[string]interface{}{}, fmt.Errorf("service: target deployment: %w", err)))
+	}
+	return Object(objmeta.Name(naming.Name(obj.Name, "svc")).Namespace(obj.Namespace)).LabelSelector(sel)
+}
+
+// U returns the unstructured value of the builder
+func (b Builder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b Builder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b Builder) T() (coreV1.Service, error) {
+	var svc coreV1.Service
+	if err := unstruct.FromUnstructured(b, &svc); err != nil {
+		return coreV1.Service{}, err
+	}
+	if err := naming.Validate("Service", svc.Name); err != nil {
+		return coreV1.Service{}, err
+	}
+	return svc, nil
+}
+
+// Type sets the service type (i.e. ClusterIP, NodePort, LoadBalancer)
+func (b Builder) Type(t coreV1.ServiceType) Builder {
+	return b.setSpec("type", t)
+}
+
+// Selector sets the labels used to select the pods targeted by the service
+func (b Builder) Selector(labels map[string]string) Builder {
+	sel := map[string]interface{}{}
+	for k, v := range labels {
+		sel[k] = v
+	}
+	return b.setSpec("selector", sel)
+}
+
+// LabelSelector sets the labels used to select the pods targeted by the
+// service to the labels of sel. Services only select on labels, a selector
+// with expressions is recorded as an error returned by T.
+func (b Builder) LabelSelector(sel selector.Builder) Builder {
+	labels, err := sel.Map()
+	if err != nil {
+		unstruct.SetErr(b, fmt.Errorf("service: selector: %w", err))
+		return b
+	}
+	return b.Selector(labels)
+}
+
+// Ports sets the ports exposed by the service
+func (b Builder) Ports(ports ...PortBuilder) Builder {
+	var slice []interface{}
+	for _, p := range ports {
+		slice = append(slice, unstruct.Nest(p))
+	}
+	return b.setSpec("ports", slice)
+}
+
+// ClusterIP sets the IP address of the service (use "None" for a headless service)
+func (b Builder) ClusterIP(ip string) Builder {
+	return b.setSpec("clusterIP", ip)
+}
+
+func (b Builder) setSpec(key string, val interface{}) Builder {
+	spec := b.getServiceSpec()
+	spec[key] = val
+	b["spec"] = spec
+	return b
+}
+
+func (b Builder) getServiceSpec() map[string]interface{} {
+	specIface, ok := b["spec"]
+	if !ok {
+		specIface = map[string]interface{}{}
+	}
+	return specIface.(map[string]interface{})
+}
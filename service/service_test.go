@@ -0,0 +1,165 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vladimirvivien/kob/deployment"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	"github.com/vladimirvivien/kob/selector"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestServiceUnstructured(t *testing.T) {
+	tests := map[string]struct {
+		builder  Builder
+		expected map[string]interface{}
+	}{
+		"empty": {
+			builder:  Builder{},
+			expected: map[string]interface{}{},
+		},
+		"service with object meta only": {
+			builder:  Object(objmeta.Name("simple-svc").Namespace("default")),
+			expected: map[string]interface{}{"metadata": map[string]interface{}{"name": "simple-svc", "namespace": "default"}},
+		},
+		"service with selector and port": {
+			builder: Object(objmeta.Name("simple-svc")).Selector(map[string]string{"app": "web"}).Ports(Port(80)),
+			expected: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "simple-svc"},
+				"spec": map[string]interface{}{
+					"selector": map[string]interface{}{"app": "web"},
+					"ports":    []interface{}{map[string]interface{}{"port": int64(80)}},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc := test.builder
+			if !reflect.DeepEqual(svc.U(), test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", svc.U(), test.expected)
+			}
+		})
+	}
+}
+
+func TestServiceTyped(t *testing.T) {
+	tests := map[string]struct {
+		builder  Builder
+		expected coreV1.Service
+	}{
+		"empty": {
+			builder:  Builder{},
+			expected: coreV1.Service{},
+		},
+		"object meta only": {
+			builder:  Object(objmeta.Name("simple-svc").Namespace("default")),
+			expected: coreV1.Service{ObjectMeta: metaV1.ObjectMeta{Name: "simple-svc", Namespace: "default"}},
+		},
+		"all fields": {
+			builder: Object(objmeta.Name("simple-svc")).Type(coreV1.ServiceTypeClusterIP).ClusterIP("None").
+				Selector(map[string]string{"app": "web"}).Ports(Port(80).Name("http").TargetPortName("web")),
+			expected: coreV1.Service{
+				ObjectMeta: metaV1.ObjectMeta{Name: "simple-svc"},
+				Spec: coreV1.ServiceSpec{
+					Type:      coreV1.ServiceTypeClusterIP,
+					ClusterIP: "None",
+					Selector:  map[string]string{"app": "web"},
+					Ports:     []coreV1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromString("web")}},
+				},
+			},
+		},
+		"from typed": {
+			builder: From(coreV1.Service{ObjectMeta: metaV1.ObjectMeta{Name: "simple-svc"}, Spec: coreV1.ServiceSpec{ClusterIP: "None"}}).
+				Selector(map[string]string{"app": "web"}),
+			expected: coreV1.Service{
+				ObjectMeta: metaV1.ObjectMeta{Name: "simple-svc"},
+				Spec:       coreV1.ServiceSpec{ClusterIP: "None", Selector: map[string]string{"app": "web"}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(svc, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", svc, test.expected)
+			}
+		})
+	}
+}
+
+func TestServiceInvalidName(t *testing.T) {
+	for _, name := range []string{"My_Service", "1-web", "web.example"} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Object(objmeta.Name(name)).T(); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
+
+func TestServiceLabelSelector(t *testing.T) {
+	svc, err := Object(objmeta.Name("web")).LabelSelector(selector.MatchLabels(map[string]string{"app": "web"})).T()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := map[string]string{"app": "web"}; !reflect.DeepEqual(svc.Spec.Selector, expected) {
+		t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", svc.Spec.Selector, expected)
+	}
+
+	if _, err := Object(objmeta.Name("web")).LabelSelector(selector.In("app", "web", "api")).T(); err == nil {
+		t.Error("expecting error, got none")
+	}
+}
+
+func TestServiceFor(t *testing.T) {
+	web := map[string]string{"app": "web"}
+	svc, err := For(deployment.Object(objmeta.Name("web").Namespace("shop")).Selector(web)).Ports(Port(80)).T()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := coreV1.Service{
+		ObjectMeta: metaV1.ObjectMeta{Name: naming.Name("web", "svc"), Namespace: "shop"},
+		Spec:       coreV1.ServiceSpec{Selector: web, Ports: []coreV1.ServicePort{{Port: 80}}},
+	}
+	if !reflect.DeepEqual(svc, expected) {
+		t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", svc, expected)
+	}
+}
+
+func TestServiceForLongName(t *testing.T) {
+	name := strings.Repeat("web.", 60) + "app"
+	svc, err := For(deployment.Object(objmeta.Name(name)).Selector(map[string]string{"app": "web"})).T()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(svc.Name) > naming.MaxLength || svc.Name != naming.Name(name, "svc") {
+		t.Errorf("unexpected name %q", svc.Name)
+	}
+}
+
+func TestServiceForInvalid(t *testing.T) {
+	tests := map[string]Builder{
+		"invalid deployment":   For(deployment.Object(objmeta.Name("My_App"))),
+		"empty selector":       For(deployment.Object(objmeta.Name("web"))),
+		"selector expressions": For(deployment.Object(objmeta.Name("web")).LabelSelector(selector.In("app", "web", "api"))),
+	}
+
+	for name, builder := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := builder.T(); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
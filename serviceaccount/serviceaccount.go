@@ -0,0 +1,58 @@
+// Package serviceaccount contains builder types to build values of type coreV1.ServiceAccount
+package serviceaccount
+
+import (
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	coreV1 "k8s.io/api/core/v1"
+)
+
+// Builder provides a way to build values of type coreV1.ServiceAccount
+type Builder map[string]interface{}
+
+// Object starts a new ServiceAccount builder using the provided object metadata
+func Object(metadata objmeta.Builder) Builder {
+	meta, err := metadata.U()
+	return Builder(unstruct.SetErr(map[string]interface{}{"metadata": meta}, err))
+}
+
+// U returns the unstructured value of the builder
+func (b Builder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b Builder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b Builder) T() (coreV1.ServiceAccount, error) {
+	var sa coreV1.ServiceAccount
+	if err := unstruct.FromUnstructured(b, &sa); err != nil {
+		return coreV1.ServiceAccount{}, err
+	}
+	if err := naming.Validate("ServiceAccount", sa.Name); err != nil {
+		return coreV1.ServiceAccount{}, err
+	}
+	return sa, nil
+}
+
+// AutomountServiceAccountToken sets whether pods running as the service
+// account should have an API token automatically mounted
+func (b Builder) AutomountServiceAccountToken(mount bool) Builder {
+	b["automountServiceAccountToken"] = mount
+	return b
+}
+
+// ImagePullSecrets sets the names of the secrets used to pull images for
+// pods running as the service account
+func (b Builder) ImagePullSecrets(names ...string) Builder {
+	var slice []interface{}
+	for _, n := range names {
+		slice = append(slice, map[string]interface{}{"name": n})
+	}
+	b["imagePullSecrets"] = slice
+	return b
+}
@@ -0,0 +1,75 @@
+package serviceaccount
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/objmeta"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestServiceAccountUnstructured(t *testing.T) {
+	tests := map[string]struct {
+		builder  Builder
+		expected map[string]interface{}
+	}{
+		"empty": {
+			builder:  Builder{},
+			expected: map[string]interface{}{},
+		},
+		"object meta only": {
+			builder:  Object(objmeta.Name("simple-sa").Namespace("default")),
+			expected: map[string]interface{}{"metadata": map[string]interface{}{"name": "simple-sa", "namespace": "default"}},
+		},
+		"no token mount": {
+			builder: Object(objmeta.Name("simple-sa")).AutomountServiceAccountToken(false),
+			expected: map[string]interface{}{
+				"metadata":                     map[string]interface{}{"name": "simple-sa"},
+				"automountServiceAccountToken": false,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			sa := test.builder
+			if !reflect.DeepEqual(sa.U(), test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", sa.U(), test.expected)
+			}
+		})
+	}
+}
+
+func TestServiceAccountTyped(t *testing.T) {
+	mount := true
+	tests := map[string]struct {
+		builder  Builder
+		expected coreV1.ServiceAccount
+	}{
+		"empty": {
+			builder:  Builder{},
+			expected: coreV1.ServiceAccount{},
+		},
+		"all fields": {
+			builder: Object(objmeta.Name("simple-sa").Namespace("default")).AutomountServiceAccountToken(true).ImagePullSecrets("registry-a", "registry-b"),
+			expected: coreV1.ServiceAccount{
+				ObjectMeta:                   metaV1.ObjectMeta{Name: "simple-sa", Namespace: "default"},
+				AutomountServiceAccountToken: &mount,
+				ImagePullSecrets:             []coreV1.LocalObjectReference{{Name: "registry-a"}, {Name: "registry-b"}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			sa, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(sa, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", sa, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Package storage contains builder types to build PersistentVolumeClaim,
+// PersistentVolume and StorageClass objects
+package storage
+
+import (
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	"github.com/vladimirvivien/kob/pod"
+	coreV1 "k8s.io/api/core/v1"
+)
+
+// ClaimBuilder provides a way to build values of type coreV1.PersistentVolumeClaim
+type ClaimBuilder map[string]interface{}
+
+// Claim starts a new PersistentVolumeClaim builder using the provided object metadata
+func Claim(metadata objmeta.Builder) ClaimBuilder {
+	meta, err := metadata.U()
+	return ClaimBuilder(unstruct.SetErr(map[string]interface{}{"metadata": meta}, err))
+}
+
+// U returns the unstructured value of the builder
+func (b ClaimBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b ClaimBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder. An error is returned if the
+// storage request is not a valid quantity.
+func (b ClaimBuilder) T() (coreV1.PersistentVolumeClaim, error) {
+	var pvc coreV1.PersistentVolumeClaim
+	if err := unstruct.FromUnstructured(b, &pvc); err != nil {
+		return coreV1.PersistentVolumeClaim{}, err
+	}
+	if err := naming.Validate("PersistentVolumeClaim", pvc.Name); err != nil {
+		return coreV1.PersistentVolumeClaim{}, err
+	}
+	return pvc, nil
+}
+
+// AccessModes sets the access modes (i.e. ReadWriteOnce) requested by the claim
+func (b ClaimBuilder) AccessModes(modes ...coreV1.PersistentVolumeAccessMode) ClaimBuilder {
+	return b.setSpec("accessModes", accessModes(modes))
+}
+
+// Request sets the amount of storage requested as a quantity string (i.e. "10Gi")
+func (b ClaimBuilder) Request(qty string) ClaimBuilder {
+	return b.setSpec("resources", map[string]interface{}{
+		"requests": map[string]interface{}{string(coreV1.ResourceStorage): qty},
+	})
+}
+
+// StorageClassName sets the name of the storage class used to provision the claim
+func (b ClaimBuilder) StorageClassName(name string) ClaimBuilder {
+	return b.setSpec("storageClassName", name)
+}
+
+// VolumeMode sets whether the claimed volume is a Filesystem or a Block device
+func (b ClaimBuilder) VolumeMode(mode coreV1.PersistentVolumeMode) ClaimBuilder {
+	return b.setSpec("volumeMode", mode)
+}
+
+// VolumeName binds the claim to the named persistent volume
+func (b ClaimBuilder) VolumeName(name string) ClaimBuilder {
+	return b.setSpec("volumeName", name)
+}
+
+// DataSource populates the claim from an existing object in the same
+// namespace (i.e. a VolumeSnapshot or another PersistentVolumeClaim)
+func (b ClaimBuilder) DataSource(apiGroup, kind, name string) ClaimBuilder {
+	src := map[string]interface{}{"kind": kind, "name": name}
+	if apiGroup != "" {
+		src["apiGroup"] = apiGroup
+	}
+	return b.setSpec("dataSource", src)
+}
+
+// DataSourceRef populates the claim from an object handled by a volume
+// populator, possibly in another namespace (an empty namespace uses the claim's)
+func (b ClaimBuilder) DataSourceRef(apiGroup, kind, name, namespace string) ClaimBuilder {
+	src := map[string]interface{}{"kind": kind, "name": name}
+	if apiGroup != "" {
+		src["apiGroup"] = apiGroup
+	}
+	if namespace != "" {
+		src["namespace"] = namespace
+	}
+	return b.setSpec("dataSourceRef", src)
+}
+
+// FromSnapshot is a shortcut to populate the claim from the named VolumeSnapshot
+func (b ClaimBuilder) FromSnapshot(name string) ClaimBuilder {
+	return b.DataSource("snapshot.storage.k8s.io", "VolumeSnapshot", name)
+}
+
+// ClaimVolume creates a pod volume, with the given name, that mounts the claim
+func ClaimVolume(name string, claim ClaimBuilder) pod.VolumeBuilder {
+	meta, _ := claim["metadata"].(map[string]interface{})
+	claimName, _ := meta["name"].(string)
+	return pod.Volume(name).PersistentVolumeClaim(claimName, false)
+}
+
+func (b ClaimBuilder) setSpec(key string, val interface{}) ClaimBuilder {
+	spec, ok := b["spec"].(map[string]interface{})
+	if !ok {
+		spec = map[string]interface{}{}
+	}
+	spec[key] = val
+	b["spec"] = spec
+	return b
+}
+
+func accessModes(modes []coreV1.PersistentVolumeAccessMode) []interface{} {
+	var slice []interface{}
+	for _, m := range modes {
+		slice = append(slice, m)
+	}
+	return slice
+}
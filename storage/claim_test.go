@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/objmeta"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClaimTyped(t *testing.T) {
+	fast, block := "fast", coreV1.PersistentVolumeBlock
+	snapshotGroup, populatorNs := "snapshot.storage.k8s.io", "backups"
+	tests := map[string]struct {
+		builder  ClaimBuilder
+		expected coreV1.PersistentVolumeClaim
+	}{
+		"empty": {
+			builder:  ClaimBuilder{},
+			expected: coreV1.PersistentVolumeClaim{},
+		},
+		"object meta only": {
+			builder:  Claim(objmeta.Name("data").Namespace("default")),
+			expected: coreV1.PersistentVolumeClaim{ObjectMeta: metaV1.ObjectMeta{Name: "data", Namespace: "default"}},
+		},
+		"all fields": {
+			builder: Claim(objmeta.Name("data")).AccessModes(coreV1.ReadWriteOnce).Request("10Gi").
+				StorageClassName("fast").VolumeMode(coreV1.PersistentVolumeBlock).VolumeName("pv-0001").FromSnapshot("nightly"),
+			expected: coreV1.PersistentVolumeClaim{
+				ObjectMeta: metaV1.ObjectMeta{Name: "data"},
+				Spec: coreV1.PersistentVolumeClaimSpec{
+					AccessModes:      []coreV1.PersistentVolumeAccessMode{coreV1.ReadWriteOnce},
+					Resources:        coreV1.ResourceRequirements{Requests: coreV1.ResourceList{coreV1.ResourceStorage: resource.MustParse("10Gi")}},
+					StorageClassName: &fast,
+					VolumeMode:       &block,
+					VolumeName:       "pv-0001",
+					DataSource:       &coreV1.TypedLocalObjectReference{APIGroup: &snapshotGroup, Kind: "VolumeSnapshot", Name: "nightly"},
+				},
+			},
+		},
+		"data source ref": {
+			builder: Claim(objmeta.Name("data")).DataSourceRef("", "PersistentVolumeClaim", "seed", "backups"),
+			expected: coreV1.PersistentVolumeClaim{
+				ObjectMeta: metaV1.ObjectMeta{Name: "data"},
+				Spec: coreV1.PersistentVolumeClaimSpec{
+					DataSourceRef: &coreV1.TypedObjectReference{Kind: "PersistentVolumeClaim", Name: "seed", Namespace: &populatorNs},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pvc, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(pvc, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", pvc, test.expected)
+			}
+		})
+	}
+}
+
+func TestClaimInvalidRequest(t *testing.T) {
+	if _, err := Claim(objmeta.Name("data")).Request("ten gigs").T(); err == nil {
+		t.Error("expecting error, got none")
+	}
+}
+
+func TestClaimVolume(t *testing.T) {
+	vol, err := ClaimVolume("data", Claim(objmeta.Name("data-claim")).Request("1Gi")).T()
+	if err != nil {
+		t.Fatalf("failed to convert to typed value: %s", err)
+	}
+	expected := coreV1.Volume{Name: "data", VolumeSource: coreV1.VolumeSource{PersistentVolumeClaim: &coreV1.PersistentVolumeClaimVolumeSource{ClaimName: "data-claim"}}}
+	if !reflect.DeepEqual(vol, expected) {
+		t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", vol, expected)
+	}
+}
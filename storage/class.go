@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	coreV1 "k8s.io/api/core/v1"
+	storageV1 "k8s.io/api/storage/v1"
+)
+
+// ClassBuilder provides a way to build values of type storageV1.StorageClass
+type ClassBuilder map[string]interface{}
+
+// Class starts a new StorageClass builder using the provided object metadata
+// and the name of the volume provisioner (i.e. ebs.csi.aws.com)
+func Class(metadata objmeta.Builder, provisioner string) ClassBuilder {
+	meta, err := metadata.U()
+	return ClassBuilder(unstruct.SetErr(map[string]interface{}{"metadata": meta, "provisioner": provisioner}, err))
+}
+
+// U returns the unstructured value of the builder
+func (b ClassBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b ClassBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder
+func (b ClassBuilder) T() (storageV1.StorageClass, error) {
+	var class storageV1.StorageClass
+	if err := unstruct.FromUnstructured(b, &class); err != nil {
+		return storageV1.StorageClass{}, err
+	}
+	if err := naming.Validate("StorageClass", class.Name); err != nil {
+		return storageV1.StorageClass{}, err
+	}
+	return class, nil
+}
+
+// Parameters sets the provisioner specific parameters
+func (b ClassBuilder) Parameters(params map[string]string) ClassBuilder {
+	p := map[string]interface{}{}
+	for k, v := range params {
+		p[k] = v
+	}
+	b["parameters"] = p
+	return b
+}
+
+// VolumeBindingMode sets when volumes are provisioned and bound
+// (Immediate or WaitForFirstConsumer)
+func (b ClassBuilder) VolumeBindingMode(mode storageV1.VolumeBindingMode) ClassBuilder {
+	b["volumeBindingMode"] = mode
+	return b
+}
+
+// AllowVolumeExpansion sets whether claims of the class can be resized
+func (b ClassBuilder) AllowVolumeExpansion(allow bool) ClassBuilder {
+	b["allowVolumeExpansion"] = allow
+	return b
+}
+
+// ReclaimPolicy sets the reclaim policy of the volumes provisioned by the class
+func (b ClassBuilder) ReclaimPolicy(pol coreV1.PersistentVolumeReclaimPolicy) ClassBuilder {
+	b["reclaimPolicy"] = pol
+	return b
+}
+
+// Default marks the class as the cluster default for claims without a storage class
+func (b ClassBuilder) Default() ClassBuilder {
+	meta, ok := b["metadata"].(map[string]interface{})
+	if !ok {
+		meta = map[string]interface{}{}
+	}
+	annotations, ok := meta["annotations"].(map[string]interface{})
+	if !ok {
+		annotations = map[string]interface{}{}
+	}
+	annotations["storageclass.kubernetes.io/is-default-class"] = "true"
+	meta["annotations"] = annotations
+	b["metadata"] = meta
+	return b
+}
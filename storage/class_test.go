@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/objmeta"
+	coreV1 "k8s.io/api/core/v1"
+	storageV1 "k8s.io/api/storage/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClassTyped(t *testing.T) {
+	waitForConsumer := storageV1.VolumeBindingWaitForFirstConsumer
+	expand, retain := true, coreV1.PersistentVolumeReclaimRetain
+	tests := map[string]struct {
+		builder  ClassBuilder
+		expected storageV1.StorageClass
+	}{
+		"empty": {
+			builder:  ClassBuilder{},
+			expected: storageV1.StorageClass{},
+		},
+		"provisioner only": {
+			builder:  Class(objmeta.Name("fast"), "ebs.csi.aws.com"),
+			expected: storageV1.StorageClass{ObjectMeta: metaV1.ObjectMeta{Name: "fast"}, Provisioner: "ebs.csi.aws.com"},
+		},
+		"all fields": {
+			builder: Class(objmeta.Name("fast"), "ebs.csi.aws.com").Parameters(map[string]string{"type": "gp3"}).
+				VolumeBindingMode(storageV1.VolumeBindingWaitForFirstConsumer).AllowVolumeExpansion(true).
+				ReclaimPolicy(coreV1.PersistentVolumeReclaimRetain).Default(),
+			expected: storageV1.StorageClass{
+				ObjectMeta:           metaV1.ObjectMeta{Name: "fast", Annotations: map[string]string{"storageclass.kubernetes.io/is-default-class": "true"}},
+				Provisioner:          "ebs.csi.aws.com",
+				Parameters:           map[string]string{"type": "gp3"},
+				VolumeBindingMode:    &waitForConsumer,
+				AllowVolumeExpansion: &expand,
+				ReclaimPolicy:        &retain,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			class, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(class, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", class, test.expected)
+			}
+		})
+	}
+}
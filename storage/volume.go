@@ -0,0 +1,147 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	coreV1 "k8s.io/api/core/v1"
+)
+
+// VolumeBuilder provides a way to build values of type coreV1.PersistentVolume
+type VolumeBuilder map[string]interface{}
+
+// Volume starts a new PersistentVolume builder using the provided object metadata
+func Volume(metadata objmeta.Builder) VolumeBuilder {
+	meta, err := metadata.U()
+	return VolumeBuilder(unstruct.SetErr(map[string]interface{}{"metadata": meta}, err))
+}
+
+// U returns the unstructured value of the builder
+func (b VolumeBuilder) U() map[string]interface{} {
+	return unstruct.Strip(b)
+}
+
+// Err returns the error recorded while building, if any
+func (b VolumeBuilder) Err() error {
+	return unstruct.Err(b)
+}
+
+// T returns the typed value of the builder. An error is returned if more
+// than one volume source is set or if a local volume has no node affinity.
+func (b VolumeBuilder) T() (coreV1.PersistentVolume, error) {
+	var pv coreV1.PersistentVolume
+	if err := unstruct.FromUnstructured(b, &pv); err != nil {
+		return coreV1.PersistentVolume{}, err
+	}
+	if err := naming.Validate("PersistentVolume", pv.Name); err != nil {
+		return coreV1.PersistentVolume{}, err
+	}
+	if err := validateVolume(pv); err != nil {
+		return coreV1.PersistentVolume{}, err
+	}
+	return pv, nil
+}
+
+// Capacity sets the storage capacity of the volume as a quantity string (i.e. "100Gi")
+func (b VolumeBuilder) Capacity(qty string) VolumeBuilder {
+	return b.setSpec("capacity", map[string]interface{}{string(coreV1.ResourceStorage): qty})
+}
+
+// AccessModes sets the ways the volume can be mounted
+func (b VolumeBuilder) AccessModes(modes ...coreV1.PersistentVolumeAccessMode) VolumeBuilder {
+	return b.setSpec("accessModes", accessModes(modes))
+}
+
+// ReclaimPolicy sets what happens to the volume when released from its claim
+func (b VolumeBuilder) ReclaimPolicy(pol coreV1.PersistentVolumeReclaimPolicy) VolumeBuilder {
+	return b.setSpec("persistentVolumeReclaimPolicy", pol)
+}
+
+// StorageClassName sets the name of the storage class the volume belongs to
+func (b VolumeBuilder) StorageClassName(name string) VolumeBuilder {
+	return b.setSpec("storageClassName", name)
+}
+
+// VolumeMode sets whether the volume is a Filesystem or a Block device
+func (b VolumeBuilder) VolumeMode(mode coreV1.PersistentVolumeMode) VolumeBuilder {
+	return b.setSpec("volumeMode", mode)
+}
+
+// MountOptions sets the mount options used when mounting the volume
+func (b VolumeBuilder) MountOptions(opts ...string) VolumeBuilder {
+	var slice []interface{}
+	for _, o := range opts {
+		slice = append(slice, o)
+	}
+	return b.setSpec("mountOptions", slice)
+}
+
+// CSI sources the volume from the external CSI driver using the volume handle
+func (b VolumeBuilder) CSI(driver, volumeHandle string) VolumeBuilder {
+	return b.setSpec("csi", map[string]interface{}{"driver": driver, "volumeHandle": volumeHandle})
+}
+
+// HostPath sources the volume from a directory on the host (single-node testing only)
+func (b VolumeBuilder) HostPath(path string) VolumeBuilder {
+	return b.setSpec("hostPath", map[string]interface{}{"path": path})
+}
+
+// Local sources the volume from a local disk, partition or directory. Local
+// volumes also require a node affinity.
+func (b VolumeBuilder) Local(path string) VolumeBuilder {
+	return b.setSpec("local", map[string]interface{}{"path": path})
+}
+
+// NodeAffinity restricts the nodes the volume can be accessed from to
+// those with label key set to one of the values
+func (b VolumeBuilder) NodeAffinity(key string, values ...string) VolumeBuilder {
+	var vals []interface{}
+	for _, v := range values {
+		vals = append(vals, v)
+	}
+	return b.setSpec("nodeAffinity", map[string]interface{}{
+		"required": map[string]interface{}{
+			"nodeSelectorTerms": []interface{}{
+				map[string]interface{}{
+					"matchExpressions": []interface{}{
+						map[string]interface{}{"key": key, "operator": coreV1.NodeSelectorOpIn, "values": vals},
+					},
+				},
+			},
+		},
+	})
+}
+
+// ClaimRef pre-binds the volume to the named claim
+func (b VolumeBuilder) ClaimRef(namespace, name string) VolumeBuilder {
+	return b.setSpec("claimRef", map[string]interface{}{"namespace": namespace, "name": name})
+}
+
+func (b VolumeBuilder) setSpec(key string, val interface{}) VolumeBuilder {
+	spec, ok := b["spec"].(map[string]interface{})
+	if !ok {
+		spec = map[string]interface{}{}
+	}
+	spec[key] = val
+	b["spec"] = spec
+	return b
+}
+
+func validateVolume(pv coreV1.PersistentVolume) error {
+	src := pv.Spec.PersistentVolumeSource
+	sources := 0
+	for _, set := range []bool{src.CSI != nil, src.HostPath != nil, src.Local != nil} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return fmt.Errorf("storage: persistent volume %s has more than one volume source", pv.Name)
+	}
+	if src.Local != nil && pv.Spec.NodeAffinity == nil {
+		return fmt.Errorf("storage: local persistent volume %s requires a node affinity", pv.Name)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/objmeta"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestVolumeTyped(t *testing.T) {
+	tests := map[string]struct {
+		builder  VolumeBuilder
+		expected coreV1.PersistentVolume
+	}{
+		"empty": {
+			builder:  VolumeBuilder{},
+			expected: coreV1.PersistentVolume{},
+		},
+		"csi volume": {
+			builder: Volume(objmeta.Name("pv-0001")).Capacity("100Gi").AccessModes(coreV1.ReadWriteOnce).
+				ReclaimPolicy(coreV1.PersistentVolumeReclaimRetain).StorageClassName("fast").
+				CSI("ebs.csi.aws.com", "vol-0123").ClaimRef("default", "data"),
+			expected: coreV1.PersistentVolume{
+				ObjectMeta: metaV1.ObjectMeta{Name: "pv-0001"},
+				Spec: coreV1.PersistentVolumeSpec{
+					Capacity:                      coreV1.ResourceList{coreV1.ResourceStorage: resource.MustParse("100Gi")},
+					AccessModes:                   []coreV1.PersistentVolumeAccessMode{coreV1.ReadWriteOnce},
+					PersistentVolumeReclaimPolicy: coreV1.PersistentVolumeReclaimRetain,
+					StorageClassName:              "fast",
+					PersistentVolumeSource:        coreV1.PersistentVolumeSource{CSI: &coreV1.CSIPersistentVolumeSource{Driver: "ebs.csi.aws.com", VolumeHandle: "vol-0123"}},
+					ClaimRef:                      &coreV1.ObjectReference{Namespace: "default", Name: "data"},
+				},
+			},
+		},
+		"local volume": {
+			builder: Volume(objmeta.Name("local-pv")).Capacity("1Ti").Local("/mnt/disks/ssd1").NodeAffinity("kubernetes.io/hostname", "node-1").MountOptions("noatime"),
+			expected: coreV1.PersistentVolume{
+				ObjectMeta: metaV1.ObjectMeta{Name: "local-pv"},
+				Spec: coreV1.PersistentVolumeSpec{
+					Capacity:               coreV1.ResourceList{coreV1.ResourceStorage: resource.MustParse("1Ti")},
+					PersistentVolumeSource: coreV1.PersistentVolumeSource{Local: &coreV1.LocalVolumeSource{Path: "/mnt/disks/ssd1"}},
+					MountOptions:           []string{"noatime"},
+					NodeAffinity: &coreV1.VolumeNodeAffinity{
+						Required: &coreV1.NodeSelector{
+							NodeSelectorTerms: []coreV1.NodeSelectorTerm{{
+								MatchExpressions: []coreV1.NodeSelectorRequirement{{Key: "kubernetes.io/hostname", Operator: coreV1.NodeSelectorOpIn, Values: []string{"node-1"}}},
+							}},
+						},
+					},
+				},
+			},
+		},
+		"host path volume": {
+			builder: Volume(objmeta.Name("dev-pv")).HostPath("/tmp/data"),
+			expected: coreV1.PersistentVolume{
+				ObjectMeta: metaV1.ObjectMeta{Name: "dev-pv"},
+				Spec: coreV1.PersistentVolumeSpec{
+					PersistentVolumeSource: coreV1.PersistentVolumeSource{HostPath: &coreV1.HostPathVolumeSource{Path: "/tmp/data"}},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pv, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(pv, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", pv, test.expected)
+			}
+		})
+	}
+}
+
+func TestVolumeInvalid(t *testing.T) {
+	tests := map[string]VolumeBuilder{
+		"local without node affinity": Volume(objmeta.Name("local-pv")).Local("/mnt/disks/ssd1"),
+		"multiple sources":            Volume(objmeta.Name("pv")).HostPath("/tmp").CSI("csi.example.com", "vol-1"),
+		"invalid capacity":            Volume(objmeta.Name("pv")).Capacity("lots"),
+	}
+
+	for name, builder := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := builder.T(); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Package tenant contains helpers to build the set of objects used to
+// onboard a team (tenant) into its own namespace
+package tenant
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/limitrange"
+	"github.com/vladimirvivien/kob/namespace"
+	"github.com/vladimirvivien/kob/objmeta"
+	"github.com/vladimirvivien/kob/resourcequota"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Size describes the total amount of resources granted to a tenant
+type Size struct {
+	// CPU is the total of CPU requests (limits are allowed up to twice as much)
+	CPU string
+	// Memory is the total of memory requests (limits are allowed up to twice as much)
+	Memory string
+	// Storage is the total of storage requested by claims
+	Storage string
+	// Pods is the maximum number of pods
+	Pods int
+	// Claims is the maximum number of persistent volume claims
+	Claims int
+}
+
+var (
+	Small  = Size{CPU: "2", Memory: "4Gi", Storage: "20Gi", Pods: 20, Claims: 5}
+	Medium = Size{CPU: "8", Memory: "16Gi", Storage: "100Gi", Pods: 50, Claims: 10}
+	Large  = Size{CPU: "32", Memory: "64Gi", Storage: "500Gi", Pods: 200, Claims: 25}
+)
+
+// Tenant holds the builders of the objects created for a tenant
+type Tenant struct {
+	Namespace  namespace.Builder
+	Quota      resourcequota.Builder
+	LimitRange limitrange.Builder
+}
+
+// Bundle creates, for the named tenant, a namespace with pod security labels
+// (baseline enforced, restricted audited and warned), a resource quota sized
+// by size, and a limit range whose container defaults make every pod fit the
+// quota (which requires requests and limits to be set). A quantity of size that
+// cannot be parsed is recorded as an error returned by the T method of the
+// quota and of the limit range.
+func Bundle(name string, size Size) Tenant {
+	cpu, cpuErr := quantity("cpu", size.CPU)
+	mem, memErr := quantity("memory", size.Memory)
+	storage, storageErr := quantity("storage", size.Storage)
+
+	ns := namespace.Object(objmeta.Name(name)).
+		PodSecurity(namespace.ModeEnforce, namespace.LevelBaseline).
+		PodSecurity(namespace.ModeAudit, namespace.LevelRestricted).
+		PodSecurity(namespace.ModeWarn, namespace.LevelRestricted)
+
+	quota := resourcequota.Object(objmeta.Name(name + "-quota").Namespace(name)).Hard(coreV1.ResourceList{
+		coreV1.ResourceRequestsCPU:            cpu,
+		coreV1.ResourceRequestsMemory:         mem,
+		coreV1.ResourceLimitsCPU:              double(cpu),
+		coreV1.ResourceLimitsMemory:           double(mem),
+		coreV1.ResourceRequestsStorage:        storage,
+		coreV1.ResourcePods:                   resource.MustParse(strconv.Itoa(size.Pods)),
+		coreV1.ResourcePersistentVolumeClaims: resource.MustParse(strconv.Itoa(size.Claims)),
+	})
+
+	limits := limitrange.Object(objmeta.Name(name+"-limits").Namespace(name)).Limits(
+		limitrange.Container().
+			DefaultRequest(coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("100m"), coreV1.ResourceMemory: resource.MustParse("128Mi")}).
+			Default(coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("500m"), coreV1.ResourceMemory: resource.MustParse("512Mi")}).
+			Max(coreV1.ResourceList{coreV1.ResourceCPU: double(cpu), coreV1.ResourceMemory: double(mem)}),
+		limitrange.PersistentVolumeClaim().
+			Max(coreV1.ResourceList{coreV1.ResourceStorage: storage}),
+	)
+
+	for _, err := range []error{cpuErr, memErr, storageErr} {
+		unstruct.SetErr(quota, err)
+		unstruct.SetErr(limits, err)
+	}
+	return Tenant{Namespace: ns, Quota: quota, LimitRange: limits}
+}
+
+// quantity parses the quantity of the resource of a size
+func quantity(res, qty string) (resource.Quantity, error) {
+	q, err := resource.ParseQuantity(qty)
+	if err != nil {
+		return resource.Quantity{}, fmt.Errorf("tenant: invalid %s size %q: %w", res, qty, err)
+	}
+	return q, nil
+}
+
+func double(qty resource.Quantity) resource.Quantity {
+	d := qty.DeepCopy()
+	d.Add(qty)
+	return d
+}
@@ -0,0 +1,79 @@
+package tenant
+
+import (
+	"testing"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestBundle(t *testing.T) {
+	tenant := Bundle("team-a", Small)
+
+	ns, err := tenant.Namespace.T()
+	if err != nil {
+		t.Fatalf("failed to convert namespace: %s", err)
+	}
+	if ns.Name != "team-a" || ns.Labels["pod-security.kubernetes.io/enforce"] != "baseline" {
+		t.Errorf("unexpected namespace: %#v", ns.ObjectMeta)
+	}
+
+	quota, err := tenant.Quota.T()
+	if err != nil {
+		t.Fatalf("failed to convert quota: %s", err)
+	}
+	if quota.Namespace != "team-a" {
+		t.Errorf("quota namespace: expecting team-a, got %s", quota.Namespace)
+	}
+	checks := map[coreV1.ResourceName]string{
+		coreV1.ResourceRequestsCPU:            "2",
+		coreV1.ResourceLimitsCPU:              "4",
+		coreV1.ResourceLimitsMemory:           "8Gi",
+		coreV1.ResourcePods:                   "20",
+		coreV1.ResourcePersistentVolumeClaims: "5",
+	}
+	for name, expected := range checks {
+		qty := quota.Spec.Hard[name]
+		if qty.Cmp(resource.MustParse(expected)) != 0 {
+			t.Errorf("quota %s: expecting %s, got %s", name, expected, qty.String())
+		}
+	}
+
+	lr, err := tenant.LimitRange.T()
+	if err != nil {
+		t.Fatalf("failed to convert limit range: %s", err)
+	}
+	if lr.Namespace != "team-a" || len(lr.Spec.Limits) != 2 {
+		t.Fatalf("unexpected limit range: %#v", lr)
+	}
+	// container defaults must fit within the quota
+	container := lr.Spec.Limits[0]
+	maxCPU := quota.Spec.Hard[coreV1.ResourceLimitsCPU]
+	defCPU := container.Default[coreV1.ResourceCPU]
+	if defCPU.Cmp(maxCPU) > 0 {
+		t.Errorf("default cpu limit %s exceeds quota %s", defCPU.String(), maxCPU.String())
+	}
+}
+
+func TestBundleInvalidSize(t *testing.T) {
+	tests := map[string]Size{
+		"empty size":     {},
+		"invalid cpu":    {CPU: "two", Memory: "4Gi", Storage: "20Gi"},
+		"invalid memory": {CPU: "2", Memory: "4 GB", Storage: "20Gi"},
+	}
+
+	for name, size := range tests {
+		t.Run(name, func(t *testing.T) {
+			tenant := Bundle("team-a", size)
+			if _, err := tenant.Quota.T(); err == nil {
+				t.Error("expecting quota error, got none")
+			}
+			if _, err := tenant.LimitRange.T(); err == nil {
+				t.Error("expecting limit range error, got none")
+			}
+			if _, err := tenant.Namespace.T(); err != nil {
+				t.Errorf("unexpected namespace error: %s", err)
+			}
+		})
+	}
+}
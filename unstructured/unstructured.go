@@ -0,0 +1,218 @@
+// Package unstructured contains a builder type to build values of type
+// unstructuredV1.Unstructured for arbitrary kinds, including custom resources
+package unstructured
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vladimirvivien/kob/internal/unstruct"
+	"github.com/vladimirvivien/kob/naming"
+	"github.com/vladimirvivien/kob/objmeta"
+	unstructuredV1 "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Builder provides a way to build values of type unstructuredV1.Unstructured.
+// Paths are dot-separated field names (i.e. spec.template.metadata), where a
+// literal dot in a field name is escaped as \. (i.e. metadata.labels.app\.kubernetes\.io/name).
+// The first error encountered while setting values is recorded and returned by U, T and Into.
+type Builder struct {
+	obj unstructuredV1.Unstructured
+	err error
+}
+
+// Object starts a new builder for the group, version and kind using the provided object metadata
+func Object(gvk schema.GroupVersionKind, metadata objmeta.Builder) Builder {
+	meta, err := metadata.U()
+	b := Builder{obj: unstructuredV1.Unstructured{Object: map[string]interface{}{}}, err: err}
+	b.obj.SetGroupVersionKind(gvk)
+	if len(meta) > 0 {
+		b.obj.Object["metadata"] = meta
+	}
+	return b
+}
+
+// From creates a new builder using a copy of the provided object as its base.
+// The values of the object are converted into the JSON-compatible values of
+// unstructured objects (i.e. the *int32 replicas stored by deployment.Replicas
+// into an int64), an error converting them is recorded.
+func From(obj unstructuredV1.Unstructured) Builder {
+	b := Builder{obj: unstructuredV1.Unstructured{Object: map[string]interface{}{}}}
+	if obj.Object == nil {
+		return b
+	}
+	if err := unstruct.Err(obj.Object); err != nil {
+		b.err = fmt.Errorf("unstructured: from: %w", err)
+		return b
+	}
+	v, err := unstruct.JSONValue(obj.Object)
+	if err != nil {
+		b.err = fmt.Errorf("unstructured: from: %w", err)
+		return b
+	}
+	if u, ok := v.(map[string]interface{}); ok {
+		b.obj.Object = u
+	}
+	return b
+}
+
+// FromUnstructured creates a new builder using a copy of the provided
+// unstructured value as its base (see From)
+func FromUnstructured(unstruct map[string]any) Builder {
+	return From(unstructuredV1.Unstructured{Object: unstruct})
+}
+
+// U returns the unstructured value of the builder
+func (b Builder) U() (map[string]any, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b.obj.Object, nil
+}
+
+// T returns the typed value of the builder
+func (b Builder) T() (unstructuredV1.Unstructured, error) {
+	if b.err != nil {
+		return unstructuredV1.Unstructured{}, b.err
+	}
+	obj := b.obj
+	if err := naming.Validate(obj.GetKind(), obj.GetName()); err != nil {
+		return unstructuredV1.Unstructured{}, err
+	}
+	return obj, nil
+}
+
+// Into converts the value of the builder into obj, which should be a pointer
+// to a typed API value (i.e. a custom resource struct)
+func (b Builder) Into(obj any) error {
+	if b.err != nil {
+		return b.err
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(b.obj.Object, obj)
+}
+
+// Get returns the value at path and whether it was found
+func (b Builder) Get(path string) (any, bool) {
+	val, found, err := unstructuredV1.NestedFieldNoCopy(b.obj.Object, splitPath(path)...)
+	if err != nil {
+		return nil, false
+	}
+	return val, found
+}
+
+// Set sets val at path, creating intermediate maps as needed. Val may be any
+// JSON-serializable value or a kob builder.
+func (b Builder) Set(path string, val any) Builder {
+	if b.err != nil {
+		return b
+	}
+	v, err := unstruct.JSONValue(val)
+	if err != nil {
+		b.err = fmt.Errorf("unstructured: set %s: %w", path, err)
+		return b
+	}
+	if err := unstructuredV1.SetNestedField(b.obj.Object, v, splitPath(path)...); err != nil {
+		b.err = fmt.Errorf("unstructured: set %s: %w", path, err)
+	}
+	return b
+}
+
+// Append appends vals to the list at path, creating the list if needed
+func (b Builder) Append(path string, vals ...any) Builder {
+	if b.err != nil {
+		return b
+	}
+	fields := splitPath(path)
+	list, _, err := unstructuredV1.NestedFieldNoCopy(b.obj.Object, fields...)
+	if err != nil {
+		b.err = fmt.Errorf("unstructured: append %s: %w", path, err)
+		return b
+	}
+	slice, ok := list.([]interface{})
+	if list != nil && !ok {
+		b.err = fmt.Errorf("unstructured: append %s: value is of type %T, not a list", path, list)
+		return b
+	}
+	for _, val := range vals {
+		v, err := unstruct.JSONValue(val)
+		if err != nil {
+			b.err = fmt.Errorf("unstructured: append %s: %w", path, err)
+			return b
+		}
+		slice = append(slice, v)
+	}
+	if err := unstructuredV1.SetNestedField(b.obj.Object, slice, fields...); err != nil {
+		b.err = fmt.Errorf("unstructured: append %s: %w", path, err)
+	}
+	return b
+}
+
+// Merge deep merges the fields of val into the map at path, with values
+// from val replacing existing non-map values
+func (b Builder) Merge(path string, val any) Builder {
+	if b.err != nil {
+		return b
+	}
+	v, err := unstruct.JSONValue(val)
+	if err != nil {
+		b.err = fmt.Errorf("unstructured: merge %s: %w", path, err)
+		return b
+	}
+	src, ok := v.(map[string]interface{})
+	if !ok {
+		b.err = fmt.Errorf("unstructured: merge %s: value is of type %T, not a map", path, val)
+		return b
+	}
+	fields := splitPath(path)
+	existing, _, err := unstructuredV1.NestedFieldNoCopy(b.obj.Object, fields...)
+	if err != nil {
+		b.err = fmt.Errorf("unstructured: merge %s: %w", path, err)
+		return b
+	}
+	dst, ok := existing.(map[string]interface{})
+	if existing != nil && !ok {
+		b.err = fmt.Errorf("unstructured: merge %s: existing value is of type %T, not a map", path, existing)
+		return b
+	}
+	if dst == nil {
+		dst = map[string]interface{}{}
+	}
+	if err := unstructuredV1.SetNestedField(b.obj.Object, merge(dst, src), fields...); err != nil {
+		b.err = fmt.Errorf("unstructured: merge %s: %w", path, err)
+	}
+	return b
+}
+
+func merge(dst, src map[string]interface{}) map[string]interface{} {
+	for key, val := range src {
+		srcMap, srcOK := val.(map[string]interface{})
+		dstMap, dstOK := dst[key].(map[string]interface{})
+		if srcOK && dstOK {
+			dst[key] = merge(dstMap, srcMap)
+			continue
+		}
+		dst[key] = val
+	}
+	return dst
+}
+
+// splitPath splits a dot-separated path, honoring \. escapes
+func splitPath(path string) []string {
+	var fields []string
+	var field strings.Builder
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '\\' && i+1 < len(path) && path[i+1] == '.':
+			field.WriteByte('.')
+			i++
+		case path[i] == '.':
+			fields = append(fields, field.String())
+			field.Reset()
+		default:
+			field.WriteByte(path[i])
+		}
+	}
+	return append(fields, field.String())
+}
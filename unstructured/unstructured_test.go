@@ -0,0 +1,215 @@
+package unstructured
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/kob/container"
+	"github.com/vladimirvivien/kob/deployment"
+	"github.com/vladimirvivien/kob/objmeta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	unstructuredV1 "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var certificateGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+
+func TestUnstructuredTyped(t *testing.T) {
+	tests := map[string]struct {
+		builder  Builder
+		expected map[string]interface{}
+	}{
+		"object meta only": {
+			builder: Object(certificateGVK, objmeta.Name("web-tls").Namespace("default")),
+			expected: map[string]interface{}{
+				"apiVersion": "cert-manager.io/v1",
+				"kind":       "Certificate",
+				"metadata":   map[string]interface{}{"name": "web-tls", "namespace": "default"},
+			},
+		},
+		"set nested values": {
+			builder: Object(certificateGVK, objmeta.Name("web-tls")).
+				Set("spec.secretName", "web-tls").
+				Set("spec.dnsNames", []string{"example.com"}).
+				Set("spec.duration", "2160h").
+				Set("spec.privateKey.size", 256).
+				Set("metadata.annotations.cert-manager\\.io/issue-temporary-certificate", "true"),
+			expected: map[string]interface{}{
+				"apiVersion": "cert-manager.io/v1",
+				"kind":       "Certificate",
+				"metadata": map[string]interface{}{
+					"name":        "web-tls",
+					"annotations": map[string]interface{}{"cert-manager.io/issue-temporary-certificate": "true"},
+				},
+				"spec": map[string]interface{}{
+					"secretName": "web-tls",
+					"dnsNames":   []interface{}{"example.com"},
+					"duration":   "2160h",
+					"privateKey": map[string]interface{}{"size": int64(256)},
+				},
+			},
+		},
+		"append to list": {
+			builder: Object(certificateGVK, objmeta.Name("web-tls")).
+				Set("spec.dnsNames", []string{"example.com"}).
+				Append("spec.dnsNames", "www.example.com").
+				Append("spec.usages", "server auth", "client auth"),
+			expected: map[string]interface{}{
+				"apiVersion": "cert-manager.io/v1",
+				"kind":       "Certificate",
+				"metadata":   map[string]interface{}{"name": "web-tls"},
+				"spec": map[string]interface{}{
+					"dnsNames": []interface{}{"example.com", "www.example.com"},
+					"usages":   []interface{}{"server auth", "client auth"},
+				},
+			},
+		},
+		"merge maps": {
+			builder: Object(certificateGVK, objmeta.Name("web-tls")).
+				Set("spec.issuerRef", map[string]string{"name": "letsencrypt", "kind": "Issuer"}).
+				Merge("spec", map[string]interface{}{
+					"issuerRef":  map[string]string{"kind": "ClusterIssuer"},
+					"secretName": "web-tls",
+				}),
+			expected: map[string]interface{}{
+				"apiVersion": "cert-manager.io/v1",
+				"kind":       "Certificate",
+				"metadata":   map[string]interface{}{"name": "web-tls"},
+				"spec": map[string]interface{}{
+					"issuerRef":  map[string]interface{}{"name": "letsencrypt", "kind": "ClusterIssuer"},
+					"secretName": "web-tls",
+				},
+			},
+		},
+		"set builder value": {
+			builder: Object(schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Rollout"}, objmeta.Name("web")).
+				Append("spec.template.spec.containers", container.WithNameAndImage("web", "nginx")),
+			expected: map[string]interface{}{
+				"apiVersion": "argoproj.io/v1alpha1",
+				"kind":       "Rollout",
+				"metadata":   map[string]interface{}{"name": "web"},
+				"spec": map[string]interface{}{
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"containers": []interface{}{map[string]interface{}{"name": "web", "image": "nginx"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			obj, err := test.builder.T()
+			if err != nil {
+				t.Fatalf("failed to convert to typed value: %s", err)
+			}
+			if !reflect.DeepEqual(obj.Object, test.expected) {
+				t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", obj.Object, test.expected)
+			}
+		})
+	}
+}
+
+func TestUnstructuredInvalid(t *testing.T) {
+	base := func() Builder {
+		return Object(certificateGVK, objmeta.Name("web-tls")).Set("spec.secretName", "web-tls")
+	}
+	tests := map[string]Builder{
+		"set through non-map":  base().Set("spec.secretName.value", "x"),
+		"append to non-list":   base().Append("spec.secretName", "x"),
+		"merge non-map value":  base().Merge("spec", "x"),
+		"merge into non-map":   base().Merge("spec.secretName", map[string]string{"a": "b"}),
+		"unserializable value": base().Set("spec.ch", make(chan int)),
+		"error kept after set": base().Append("spec.secretName", "x").Set("spec.duration", "1h"),
+	}
+	for name, builder := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := builder.T(); err == nil {
+				t.Error("expecting error, got none")
+			}
+			if _, err := builder.U(); err == nil {
+				t.Error("expecting error, got none")
+			}
+		})
+	}
+}
+
+type certificate struct {
+	metaV1.TypeMeta   `json:",inline"`
+	metaV1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              certificateSpec `json:"spec"`
+}
+
+type certificateSpec struct {
+	SecretName string   `json:"secretName"`
+	DNSNames   []string `json:"dnsNames,omitempty"`
+	Replicas   int32    `json:"replicas,omitempty"`
+}
+
+func TestUnstructuredInto(t *testing.T) {
+	var cert certificate
+	err := Object(certificateGVK, objmeta.Name("web-tls").Namespace("default")).
+		Set("spec.secretName", "web-tls").
+		Append("spec.dnsNames", "example.com").
+		Set("spec.replicas", 2).
+		Into(&cert)
+	if err != nil {
+		t.Fatalf("failed to convert into typed value: %s", err)
+	}
+	expected := certificate{
+		TypeMeta:   metaV1.TypeMeta{APIVersion: "cert-manager.io/v1", Kind: "Certificate"},
+		ObjectMeta: metaV1.ObjectMeta{Name: "web-tls", Namespace: "default"},
+		Spec:       certificateSpec{SecretName: "web-tls", DNSNames: []string{"example.com"}, Replicas: 2},
+	}
+	if !reflect.DeepEqual(cert, expected) {
+		t.Errorf("object not equal \n\n Constructor: %#v \n\n Expected: %#v", cert, expected)
+	}
+}
+
+func TestUnstructuredFrom(t *testing.T) {
+	obj := unstructuredV1.Unstructured{}
+	obj.SetGroupVersionKind(certificateGVK)
+	b := From(obj).Set("spec.secretName", "web-tls")
+
+	val, found := b.Get("spec.secretName")
+	if !found || val != "web-tls" {
+		t.Errorf("unexpected value %v (found %t)", val, found)
+	}
+	if _, found := b.Get("spec.missing"); found {
+		t.Error("expecting missing field")
+	}
+	copied, _ := FromUnstructured(b.obj.Object).T()
+	if kind := copied.GetKind(); kind != "Certificate" {
+		t.Errorf("unexpected kind %s", kind)
+	}
+}
+
+func TestUnstructuredFromBuilder(t *testing.T) {
+	dep := deployment.Object(objmeta.Name("web")).Replicas(2).PodSpec(container.Name("web"))
+	b := FromUnstructured(dep.U()).
+		Merge("spec", map[string]interface{}{"minReadySeconds": 5}).
+		Append("spec.template.spec.containers", map[string]interface{}{"name": "sidecar"})
+	u, err := b.U()
+	if err != nil {
+		t.Fatalf("failed to convert to unstructured value: %s", err)
+	}
+	if replicas, _, _ := unstructuredV1.NestedInt64(u, "spec", "replicas"); replicas != 2 {
+		t.Errorf("unexpected replicas %d", replicas)
+	}
+	containers, _, _ := unstructuredV1.NestedSlice(u, "spec", "template", "spec", "containers")
+	if len(containers) != 2 {
+		t.Errorf("expecting 2 containers, got %d", len(containers))
+	}
+	if _, found := dep.U()["spec"].(map[string]interface{})["minReadySeconds"]; found {
+		t.Error("expecting the builder value not to be modified")
+	}
+
+	if _, err := FromUnstructured(map[string]any{"spec": make(chan int)}).T(); err == nil {
+		t.Error("expecting error, got none")
+	}
+	if _, err := FromUnstructured(deployment.Object(objmeta.Name("web").AddLabel("bad key!", "x"))).T(); err == nil {
+		t.Error("expecting error, got none")
+	}
+}